@@ -0,0 +1,136 @@
+// Package clock abstracts over the passage of time, so that systems of
+// goroutines which use timers can be run against virtual time in
+// simulations.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and provides timers.
+//
+// All its functions should be thread-safe.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After waits for d to elapse and then sends the current time on the
+	// returned channel, like time.After.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer returns a Timer which fires once d has elapsed, like
+	// time.NewTimer. Unlike After, the timer can be stopped, which releases
+	// it.
+	NewTimer(d time.Duration) Timer
+	// WithTimeout returns a copy of ctx which is cancelled once d has
+	// elapsed, like context.WithTimeout.
+	WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+// Timer is a single event created by Clock.NewTimer
+type Timer interface {
+	// C returns the channel on which the timer sends the current time when it
+	// fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer has
+	// already fired or been stopped.
+	Stop() bool
+}
+
+// Tracker is implemented by clocks which keep track of when the goroutines
+// using them are all blocked, such as a Virtual clock whose time must only be
+// advanced once they are. The functions Go, Block, Unblock, Wake and Woken
+// call through to the Tracker of a Clock, and do nothing else for other
+// clocks.
+//
+// A tracked goroutine calls Block right before it blocks, and Unblock as soon
+// as it runs again. Whoever makes an event available to a tracked goroutine,
+// e.g. by sending it a message, calls Wake before doing so, and the goroutine
+// calls Woken after Unblock once it has received the event. Events which
+// aren't received after all, e.g. because the send was abandoned, must be
+// retracted with Woken as well. A Tracker thus never sees all its goroutines
+// blocked while one of them has an event waiting for it, or has been woken by
+// one but not yet called Unblock.
+type Tracker interface {
+	// Go calls f in a new goroutine, which is tracked until f returns
+	Go(f func())
+	// Block records that a tracked goroutine is about to block
+	Block()
+	// Unblock records that a tracked goroutine is running again
+	Unblock()
+	// Wake records that an event is being made available to a tracked
+	// goroutine
+	Wake()
+	// Woken records that an event recorded with Wake has been received, or
+	// won't be
+	Woken()
+}
+
+// Go calls f in a new goroutine, which c tracks if it's a Tracker
+func Go(c Clock, f func()) {
+	if t, ok := c.(Tracker); ok {
+		t.Go(f)
+		return
+	}
+	go f()
+}
+
+// Block calls c.Block if c is a Tracker
+func Block(c Clock) {
+	if t, ok := c.(Tracker); ok {
+		t.Block()
+	}
+}
+
+// Unblock calls c.Unblock if c is a Tracker
+func Unblock(c Clock) {
+	if t, ok := c.(Tracker); ok {
+		t.Unblock()
+	}
+}
+
+// Wake calls c.Wake if c is a Tracker
+func Wake(c Clock) {
+	if t, ok := c.(Tracker); ok {
+		t.Wake()
+	}
+}
+
+// Woken calls c.Woken if c is a Tracker
+func Woken(c Clock) {
+	if t, ok := c.(Tracker); ok {
+		t.Woken()
+	}
+}
+
+// Real is the Clock provided by package time
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
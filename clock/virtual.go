@@ -0,0 +1,293 @@
+package clock
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// Virtual is a Clock whose time only passes when it is advanced explicitly.
+// Timers fire in order of their deadlines, and timers with the same deadline
+// fire in the order in which they were created. A timer's time value which
+// hasn't been received yet is discarded when the timer is stopped.
+//
+// Virtual is also a Tracker, so that whoever advances it can wait with
+// AwaitIdle until the goroutines using it are all blocked. It counts the
+// firing of a timer as an event for the tracked goroutine receiving from the
+// timer's channel, which has to call Woken once it has received the time, and
+// must otherwise stop the timer.
+type Virtual struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers timerHeap
+	seq    uint64
+
+	// running is the number of tracked goroutines which aren't blocked, and
+	// pending the number of events which haven't been received yet
+	running int
+	pending int
+	// chIdle is closed once running and pending are both zero
+	chIdle chan struct{}
+}
+
+var _ Clock = (*Virtual)(nil)
+var _ Tracker = (*Virtual)(nil)
+
+// NewVirtual returns a Virtual clock whose current time is start
+func NewVirtual(start time.Time) *Virtual {
+	return &Virtual{now: start}
+}
+
+func (v *Virtual) Now() time.Time {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.now
+}
+
+func (v *Virtual) After(d time.Duration) <-chan time.Time {
+	return v.NewTimer(d).C()
+}
+
+func (v *Virtual) NewTimer(d time.Duration) Timer {
+	return v.newTimer(d, make(chan time.Time, 1), nil)
+}
+
+// newTimer returns a timer which sends the time on ch when it fires, or calls
+// f if ch is nil
+func (v *Virtual) newTimer(d time.Duration, ch chan time.Time, f func()) *virtualTimer {
+	v.mutex.Lock()
+	t := &virtualTimer{v, v.now, v.seq, ch, f, -1, false}
+	v.seq++
+	if d > 0 {
+		t.deadline = v.now.Add(d)
+		heap.Push(&v.timers, t)
+		v.mutex.Unlock()
+		return t
+	}
+	v.mutex.Unlock()
+	v.fire([]*virtualTimer{t})
+	return t
+}
+
+// WithTimeout returns a context which is cancelled once the clock has been
+// advanced by d. Unlike with context.WithTimeout, the returned context
+// doesn't report a deadline.
+func (v *Virtual) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	childCtx, childCancel := context.WithCancel(ctx)
+	timer := v.newTimer(d, nil, childCancel)
+	return childCtx, func() {
+		timer.Stop()
+		childCancel()
+	}
+}
+
+// NextDeadline returns the deadline of the earliest pending timer, or false
+// if there is none.
+func (v *Virtual) NextDeadline() (time.Time, bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if len(v.timers) == 0 {
+		return time.Time{}, false
+	}
+	return v.timers[0].deadline, true
+}
+
+// Advance moves the clock forward by d, firing all timers which expire on
+// the way.
+func (v *Virtual) Advance(d time.Duration) {
+	v.AdvanceTo(v.Now().Add(d))
+}
+
+// AdvanceTo moves the clock forward to t, firing all timers which expire on
+// the way. Timers see the time at which they expire. Does nothing if t is not
+// after the current time.
+func (v *Virtual) AdvanceTo(t time.Time) {
+	v.mutex.Lock()
+	var expired []*virtualTimer
+	for len(v.timers) != 0 && !v.timers[0].deadline.After(t) {
+		timer := heap.Pop(&v.timers).(*virtualTimer)
+		v.now = timer.deadline
+		expired = append(expired, timer)
+	}
+	if t.After(v.now) {
+		v.now = t
+	}
+	v.mutex.Unlock()
+	v.fire(expired)
+}
+
+// Step moves the clock forward to the deadline of the earliest pending timer
+// and fires only that timer, even if other timers share its deadline. This
+// lets the caller wait for the effects of each timer before firing the next.
+// Returns false, and does nothing, if there is no pending timer.
+func (v *Virtual) Step() bool {
+	v.mutex.Lock()
+	if len(v.timers) == 0 {
+		v.mutex.Unlock()
+		return false
+	}
+	timer := heap.Pop(&v.timers).(*virtualTimer)
+	v.now = timer.deadline
+	v.mutex.Unlock()
+	v.fire([]*virtualTimer{timer})
+	return true
+}
+
+// fire sends the expired timers' deadlines on their channels, or calls their
+// functions, in order
+func (v *Virtual) fire(expired []*virtualTimer) {
+	for _, timer := range expired {
+		if timer.ch == nil {
+			timer.f()
+			continue
+		}
+		v.mutex.Lock()
+		timer.fired = true
+		v.pending++
+		timer.ch <- timer.deadline
+		v.mutex.Unlock()
+	}
+}
+
+// Go calls f in a new goroutine, which v tracks until f returns
+func (v *Virtual) Go(f func()) {
+	v.track(1, 0)
+	go func() {
+		defer v.track(-1, 0)
+		f()
+	}()
+}
+
+func (v *Virtual) Block() {
+	v.track(-1, 0)
+}
+
+func (v *Virtual) Unblock() {
+	v.track(1, 0)
+}
+
+func (v *Virtual) Wake() {
+	v.track(0, 1)
+}
+
+func (v *Virtual) Woken() {
+	v.track(0, -1)
+}
+
+func (v *Virtual) track(running, pending int) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.running += running
+	v.pending += pending
+	v.signalIdle()
+}
+
+// signalIdle closes chIdle if the tracked goroutines are all blocked. Must be
+// called with the mutex held.
+func (v *Virtual) signalIdle() {
+	if v.running <= 0 && v.pending <= 0 && v.chIdle != nil {
+		close(v.chIdle)
+		v.chIdle = nil
+	}
+}
+
+// AwaitIdle blocks until all goroutines tracked by v are blocked, and all
+// events recorded with Wake have been received. Returns false if ctx is done
+// first.
+func (v *Virtual) AwaitIdle(ctx context.Context) bool {
+	for {
+		v.mutex.Lock()
+		if v.running <= 0 && v.pending <= 0 {
+			v.mutex.Unlock()
+			return ctx.Err() == nil
+		}
+		if v.chIdle == nil {
+			v.chIdle = make(chan struct{})
+		}
+		chIdle := v.chIdle
+		v.mutex.Unlock()
+
+		select {
+		case <-chIdle:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+type virtualTimer struct {
+	clock    *Virtual
+	deadline time.Time
+	seq      uint64
+	// ch receives the deadline when the timer fires. If it's nil, f is called
+	// instead.
+	ch chan time.Time
+	f  func()
+	// index in clock.timers, or -1 if the timer isn't pending. Guarded by
+	// clock.mutex.
+	index int
+	// fired is true once the deadline has been sent on ch. Guarded by
+	// clock.mutex.
+	fired bool
+}
+
+func (t *virtualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *virtualTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	if t.index >= 0 {
+		heap.Remove(&t.clock.timers, t.index)
+		return true
+	}
+	if !t.fired {
+		return false
+	}
+	select {
+	case <-t.ch:
+		// The deadline was never received, so the event recorded when the
+		// timer fired is retracted
+		t.clock.pending--
+		t.clock.signalIdle()
+		return true
+	default:
+		return false
+	}
+}
+
+// timerHeap implements heap.Interface, ordered by deadline and seq
+type timerHeap []*virtualTimer
+
+func (h timerHeap) Len() int { return len(h) }
+
+func (h timerHeap) Less(i, j int) bool {
+	if !h[i].deadline.Equal(h[j].deadline) {
+		return h[i].deadline.Before(h[j].deadline)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x interface{}) {
+	t := x.(*virtualTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	x.index = -1
+	*h = old[:n-1]
+	return x
+}
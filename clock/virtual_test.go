@@ -0,0 +1,200 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var start = time.Unix(1600000000, 0)
+
+func fired(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestVirtualTimersFireInOrder(t *testing.T) {
+	v := NewVirtual(start)
+	chLate := v.After(2 * time.Second)
+	chFirst := v.After(time.Second)
+	chSecond := v.After(time.Second)
+
+	v.Advance(999 * time.Millisecond)
+	for _, ch := range []<-chan time.Time{chFirst, chSecond, chLate} {
+		if _, ok := fired(ch); ok {
+			t.Fatal("timer fired before its deadline")
+		}
+	}
+
+	v.Advance(time.Millisecond)
+	for _, ch := range []<-chan time.Time{chFirst, chSecond} {
+		if at, ok := fired(ch); !ok || !at.Equal(start.Add(time.Second)) {
+			t.Errorf("timer fired at %v (%v), expected %v", at, ok, start.Add(time.Second))
+		}
+	}
+	if deadline, ok := v.NextDeadline(); !ok || !deadline.Equal(start.Add(2*time.Second)) {
+		t.Errorf("NextDeadline() = %v, %v, expected %v", deadline, ok, start.Add(2*time.Second))
+	}
+
+	v.AdvanceTo(start.Add(time.Minute))
+	if at, ok := fired(chLate); !ok || !at.Equal(start.Add(2*time.Second)) {
+		t.Errorf("timer fired at %v (%v), expected the time it expired", at, ok)
+	}
+	if now := v.Now(); !now.Equal(start.Add(time.Minute)) {
+		t.Errorf("Now() = %v, expected %v", now, start.Add(time.Minute))
+	}
+}
+
+func TestVirtualStepFiresOneTimer(t *testing.T) {
+	v := NewVirtual(start)
+	chFirst := v.After(time.Second)
+	chSecond := v.After(time.Second)
+
+	if !v.Step() {
+		t.Fatal("Step() with pending timers must return true")
+	}
+	if at, ok := fired(chFirst); !ok || !at.Equal(start.Add(time.Second)) {
+		t.Errorf("timer fired at %v (%v), expected %v", at, ok, start.Add(time.Second))
+	}
+	if _, ok := fired(chSecond); ok {
+		t.Errorf("Step() fired a second timer with the same deadline")
+	}
+	if now := v.Now(); !now.Equal(start.Add(time.Second)) {
+		t.Errorf("Now() = %v, expected %v", now, start.Add(time.Second))
+	}
+
+	if !v.Step() {
+		t.Fatal("Step() with pending timers must return true")
+	}
+	if _, ok := fired(chSecond); !ok {
+		t.Errorf("timer didn't fire")
+	}
+	if v.Step() {
+		t.Errorf("Step() without pending timers must return false")
+	}
+}
+
+func TestVirtualNonPositiveDurationFiresImmediately(t *testing.T) {
+	v := NewVirtual(start)
+	for _, d := range []time.Duration{0, -time.Second} {
+		if at, ok := fired(v.After(d)); !ok || !at.Equal(start) {
+			t.Errorf("After(%v) fired at %v (%v), expected immediately", d, at, ok)
+		}
+	}
+	if _, ok := v.NextDeadline(); ok {
+		t.Errorf("expired timers must not be pending")
+	}
+}
+
+func TestVirtualTimerStop(t *testing.T) {
+	v := NewVirtual(start)
+	stopped := v.NewTimer(time.Second)
+	kept := v.NewTimer(2 * time.Second)
+
+	if !stopped.Stop() {
+		t.Errorf("Stop() on a pending timer must return true")
+	}
+	if stopped.Stop() {
+		t.Errorf("Stop() on a stopped timer must return false")
+	}
+	if deadline, ok := v.NextDeadline(); !ok || !deadline.Equal(start.Add(2*time.Second)) {
+		t.Errorf("stopped timer must not be pending, NextDeadline() = %v, %v", deadline, ok)
+	}
+
+	v.Advance(time.Minute)
+	if _, ok := fired(stopped.C()); ok {
+		t.Errorf("stopped timer fired")
+	}
+	if _, ok := fired(kept.C()); !ok {
+		t.Errorf("timer didn't fire")
+	}
+	if kept.Stop() {
+		t.Errorf("Stop() on a fired timer must return false")
+	}
+}
+
+func TestVirtualWithTimeout(t *testing.T) {
+	v := NewVirtual(start)
+
+	ctx, cancel := v.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	v.Advance(time.Second)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context wasn't cancelled after its timeout")
+	}
+
+	_, cancel = v.WithTimeout(context.Background(), time.Second)
+	cancel()
+	if _, ok := v.NextDeadline(); ok {
+		t.Errorf("cancelling the context must release its timer")
+	}
+}
+
+// idle returns whether v becomes idle within a short real time
+func idle(v *Virtual) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	return v.AwaitIdle(ctx)
+}
+
+func TestVirtualAwaitIdle(t *testing.T) {
+	v := NewVirtual(start)
+	chEvent := make(chan struct{}, 1)
+	chReceived := make(chan struct{})
+	v.Go(func() {
+		v.Block()
+		<-chEvent
+		v.Unblock()
+		v.Woken()
+		close(chReceived)
+	})
+	if !idle(v) {
+		t.Fatal("AwaitIdle() must return true once the tracked goroutine blocks")
+	}
+
+	v.Wake()
+	if idle(v) {
+		t.Errorf("AwaitIdle() must not return true while an event is pending")
+	}
+	chEvent <- struct{}{}
+	<-chReceived
+	if !idle(v) {
+		t.Errorf("AwaitIdle() must return true once the event was received")
+	}
+}
+
+func TestVirtualStopRetractsFiredTimer(t *testing.T) {
+	v := NewVirtual(start)
+	timer := v.NewTimer(time.Second)
+	v.Advance(time.Second)
+	if idle(v) {
+		t.Errorf("AwaitIdle() must not return true while a fired timer wasn't received")
+	}
+	if !timer.Stop() {
+		t.Errorf("Stop() on a fired timer which wasn't received must return true")
+	}
+	if _, ok := fired(timer.C()); ok {
+		t.Errorf("stopped timer must not deliver its time")
+	}
+	if !idle(v) {
+		t.Errorf("AwaitIdle() must return true once the fired timer was stopped")
+	}
+}
+
+func TestRealTimerStop(t *testing.T) {
+	timer := Real.NewTimer(time.Hour)
+	if !timer.Stop() {
+		t.Errorf("Stop() on a pending timer must return true")
+	}
+	timer = Real.NewTimer(0)
+	<-timer.C()
+	if timer.Stop() {
+		t.Errorf("Stop() on a fired timer must return false")
+	}
+}
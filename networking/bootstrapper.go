@@ -2,6 +2,9 @@ package networking
 
 import (
 	"context"
+	"sort"
+
+	p2pnetwork "github.com/libp2p/go-libp2p-core/network"
 	p2ppeer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	dhtrouter "github.com/SeerLink/libocr/networking/dht-router"
@@ -11,7 +14,8 @@ import (
 )
 
 var (
-	_ types.Bootstrapper = &bootstrapper{}
+	_ types.Bootstrapper          = &bootstrapper{}
+	_ types.NetworkStatusReporter = &bootstrapper{}
 )
 
 type bootstrapper struct {
@@ -140,6 +144,33 @@ func (b *bootstrapper) Close() error {
 	return errors.Wrap(b.peer.deregister(b), "could not unregister bootstrapper")
 }
 
+// NetworkStatus reports whether we are connected to each of the oracles and
+// bootstrappers of the config, ordered by peer ID, and the size of the DHT
+// routing table.
+func (b *bootstrapper) NetworkStatus() types.NetworkStatus {
+	peers := []types.PeerStatus{}
+	for pid := range b.peerAllowlist {
+		if pid == b.peer.ID() {
+			continue
+		}
+		peers = append(peers, types.PeerStatus{
+			pid.Pretty(),
+			b.peer.Network().Connectedness(pid) == p2pnetwork.Connected,
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].PeerID < peers[j].PeerID
+	})
+
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+	routingTableSize := 0
+	if b.state == bootstrapperStarted && b.routing != nil {
+		routingTableSize = b.routing.RoutingTableSize()
+	}
+	return types.NetworkStatus{peers, routingTableSize}
+}
+
 // Conform to allower interface
 func (b *bootstrapper) isAllowed(id p2ppeer.ID) bool {
 	_, ok := b.peerAllowlist[id]
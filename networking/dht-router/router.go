@@ -29,6 +29,8 @@ type PeerDiscoveryRouter interface {
 	Start()
 	Close() error
 	ProtocolID() p2pprotocol.ID
+	// RoutingTableSize returns the number of peers in the routing table
+	RoutingTableSize() int
 }
 
 type DHTRouter struct {
@@ -131,6 +133,10 @@ func (router DHTRouter) ProtocolID() p2pprotocol.ID {
 	return router.config.ProtocolID()
 }
 
+func (router DHTRouter) RoutingTableSize() int {
+	return router.dht.RoutingTable().Size()
+}
+
 func (router DHTRouter) logger() types.Logger {
 	return router.config.logger
 }
@@ -0,0 +1,28 @@
+package networking
+
+import (
+	"github.com/SeerLink/libocr/offchainreporting/metrics"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// networkMetrics holds the metrics reported by a peer and its endpoints
+type networkMetrics struct {
+	streamReconnects   types.Counter
+	rateLimiterDropped types.Counter
+}
+
+func newNetworkMetrics(m types.Metrics) networkMetrics {
+	m = metrics.OrNop(m)
+	return networkMetrics{
+		m.Counter(types.MetricOpts{
+			Name:       "ocr_network_stream_reconnects_total",
+			Help:       "Number of times an outgoing stream to a peer was reopened after a write failed, by remote peer",
+			LabelNames: []string{"remote_peer_id"},
+		}),
+		m.Counter(types.MetricOpts{
+			Name:       "ocr_network_rate_limited_messages_total",
+			Help:       "Number of incoming messages dropped by the rate limiter, by remote peer",
+			LabelNames: []string{"remote_peer_id"},
+		}),
+	}
+}
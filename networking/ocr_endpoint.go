@@ -23,6 +23,7 @@ import (
 
 var (
 	_ types.BinaryNetworkEndpoint = &ocrEndpoint{}
+	_ types.NetworkStatusReporter = &ocrEndpoint{}
 )
 
 type EndpointConfig struct {
@@ -323,6 +324,7 @@ func (o *ocrEndpoint) runSend(oid types.OracleID) {
 		if !shouldRetry {
 			return
 		}
+		o.peer.metrics.streamReconnects.Inc(destPeerID.Pretty())
 	}
 }
 
@@ -538,6 +540,7 @@ func (o *ocrEndpoint) streamReceiver(s p2pnetwork.Stream) {
 			return
 		}
 		if !isAllowed {
+			o.peer.metrics.rateLimiterDropped.Inc(remotePeerID.Pretty())
 			countDropped += 1
 			if isPowerOfTwo(countDropped) {
 				o.logger.Info("Messages were dropped by the rate limiter", types.LogFields{
@@ -698,6 +701,27 @@ func (o *ocrEndpoint) getConfigDigest() types.ConfigDigest {
 	return o.configDigest
 }
 
+// NetworkStatus reports whether we are connected to each of the other
+// oracles, and the size of the DHT routing table. The entry for our own oracle
+// is always connected.
+func (o *ocrEndpoint) NetworkStatus() types.NetworkStatus {
+	peers := make([]types.PeerStatus, len(o.peerMapping))
+	for oid, pid := range o.peerMapping {
+		peers[oid] = types.PeerStatus{
+			pid.Pretty(),
+			oid == o.ownOracleID || o.peer.Network().Connectedness(pid) == p2pnetwork.Connected,
+		}
+	}
+
+	o.stateMu.RLock()
+	defer o.stateMu.RUnlock()
+	routingTableSize := 0
+	if o.state == ocrEndpointStarted && o.routing != nil {
+		routingTableSize = o.routing.RoutingTableSize()
+	}
+	return types.NetworkStatus{peers, routingTableSize}
+}
+
 func isPowerOfTwo(num uint64) bool {
 	return num != 0 && (num&(num-1)) == 0
 }
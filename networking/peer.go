@@ -45,6 +45,8 @@ type PeerConfig struct {
 	// This should be 0 most of times, but when needed (eg when counter is somehow rolled back)
 	// users can bump this value to manually bump the counter.
 	DHTAnnouncementCounterUserPrefix uint32
+	// Receives metrics about the peer's connections. Optional, may be nil.
+	Metrics types.Metrics
 }
 
 // concretePeer represents a libp2p peer with one peer ID listening on one port
@@ -54,6 +56,7 @@ type concretePeer struct {
 	gater          *connectionGater
 	logger         types.Logger
 	endpointConfig EndpointConfig
+	metrics        networkMetrics
 	registrants    map[types.ConfigDigest]struct{}
 	registrantsMu  *sync.Mutex
 
@@ -144,6 +147,7 @@ func NewPeer(c PeerConfig) (*concretePeer, error) {
 		tls:                              tls,
 		logger:                           logger,
 		endpointConfig:                   c.EndpointConfig,
+		metrics:                          newNetworkMetrics(c.Metrics),
 		registrants:                      make(map[types.ConfigDigest]struct{}),
 		registrantsMu:                    &sync.Mutex{},
 		dhtAnnouncementCounterUserPrefix: c.DHTAnnouncementCounterUserPrefix,
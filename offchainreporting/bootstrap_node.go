@@ -2,8 +2,10 @@ package offchainreporting
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/debughandler"
 	"github.com/SeerLink/libocr/offchainreporting/internal/managed"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 	"github.com/SeerLink/libocr/subprocesses"
@@ -34,6 +36,18 @@ type BootstrapNode struct {
 
 	// cancel sends a cancel message to all subprocesses, via a context.Context
 	cancel context.CancelFunc
+
+	// ctx is cancelled once the BootstrapNode is closed. It's created along
+	// with the BootstrapNode, so that Status may read it before and during
+	// Start.
+	ctx context.Context
+
+	// chStarted is closed once the BootstrapNode has been started
+	chStarted chan struct{}
+
+	// chStatusRequests forwards requests from Status to the managed bootstrap
+	// node
+	chStatusRequests chan managed.BootstrapNodeStatusRequest
 }
 
 func NewBootstrapNode(args BootstrapNodeArgs) (*BootstrapNode, error) {
@@ -41,9 +55,14 @@ func NewBootstrapNode(args BootstrapNodeArgs) (*BootstrapNode, error) {
 		return nil, errors.Wrapf(err,
 			"bad local config while creating bootstrap node")
 	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &BootstrapNode{
-		bootstrapArgs: args,
-		started:       semaphore.NewWeighted(1),
+		bootstrapArgs:    args,
+		started:          semaphore.NewWeighted(1),
+		cancel:           cancel,
+		ctx:              ctx,
+		chStarted:        make(chan struct{}),
+		chStatusRequests: make(chan managed.BootstrapNodeStatusRequest),
 	}, nil
 }
 
@@ -51,35 +70,68 @@ func NewBootstrapNode(args BootstrapNodeArgs) (*BootstrapNode, error) {
 func (b *BootstrapNode) Start() error {
 	b.failIfAlreadyStarted()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	b.cancel = cancel
 	b.subprocesses.Go(func() {
-		defer cancel()
+		defer b.cancel()
 		managed.RunManagedBootstrapNode(
-			ctx,
+			b.ctx,
 
 			b.bootstrapArgs.BootstrapperFactory,
 			b.bootstrapArgs.Bootstrappers,
+			b.chStatusRequests,
 			b.bootstrapArgs.ContractConfigTracker,
 			b.bootstrapArgs.Database,
 			b.bootstrapArgs.LocalConfig,
 			b.bootstrapArgs.Logger,
 		)
 	})
+	close(b.chStarted)
 	return nil
 }
 
 // Close shuts down a BootstrapNode. Can safely be called multiple times.
 func (b *BootstrapNode) Close() error {
-	if b.cancel != nil {
-		b.cancel()
-	}
+	b.cancel()
 	// Wait for all subprocesses to shut down, before shutting down other resources.
 	// (Wouldn't want anything to panic from attempting to use a closed resource.)
 	b.subprocesses.Wait()
 	return nil
 }
 
+// Status returns a snapshot of the state of the bootstrap node. It returns an
+// error if the node hasn't been started, has been closed, or if ctx is done
+// before the node responds.
+func (b *BootstrapNode) Status(ctx context.Context) (types.BootstrapNodeStatus, error) {
+	select {
+	case <-b.chStarted:
+	default:
+		return types.BootstrapNodeStatus{}, errors.New("bootstrap node hasn't been started")
+	}
+	chResponse := make(chan types.BootstrapNodeStatus, 1)
+	select {
+	case b.chStatusRequests <- managed.BootstrapNodeStatusRequest{chResponse}:
+	case <-b.ctx.Done():
+		return types.BootstrapNodeStatus{}, errors.New("bootstrap node has been closed")
+	case <-ctx.Done():
+		return types.BootstrapNodeStatus{}, errors.Wrap(ctx.Err(), "could not request status")
+	}
+	select {
+	case status := <-chResponse:
+		return status, nil
+	case <-b.ctx.Done():
+		return types.BootstrapNodeStatus{}, errors.New("bootstrap node has been closed")
+	case <-ctx.Done():
+		return types.BootstrapNodeStatus{}, errors.Wrap(ctx.Err(), "could not receive status")
+	}
+}
+
+// DebugHandler returns an http.Handler serving liveness and readiness probes
+// at /healthz and /readyz, and the bootstrap node's status as JSON at
+// /status. The caller is responsible for serving it, e.g. on an existing debug
+// server.
+func (b *BootstrapNode) DebugHandler() http.Handler {
+	return debughandler.NewBootstrapNodeHandler(b.Status)
+}
+
 func (b *BootstrapNode) failIfAlreadyStarted() {
 	if !b.started.TryAcquire(1) {
 		panic("can only start a BootstrapNode once")
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/confighelper"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// diffOutput is the output of ocrconfig diff
+type diffOutput struct {
+	Parameters []parameterChangeJSON `json:"parameters"`
+	Oracles    []oracleChangeJSON    `json:"oracles"`
+	Warnings   []string              `json:"warnings"`
+}
+
+type parameterChangeJSON struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// oracleChangeJSON represents a config.OracleChange. The oracle ids are
+// omitted if the oracle isn't part of the respective config.
+type oracleChangeJSON struct {
+	OnChainSigningAddress common.Address `json:"onChainSigningAddress"`
+	OldOracleID           *int           `json:"oldOracleID,omitempty"`
+	NewOracleID           *int           `json:"newOracleID,omitempty"`
+	ChangedFields         []string       `json:"changedFields,omitempty"`
+}
+
+func diff(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	livePath := flags.String("live", "",
+		"file with the ConfigSet event log of the live config, as returned by eth_getLogs")
+	proposedPath := flags.String("proposed", "",
+		"file with the setConfig arguments of the proposed config, as output by ocrconfig generate")
+	latency := flags.Duration("latency", 0,
+		"expected one-way latency between oracles, to check the proposed timing parameters against")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *livePath == "" || *proposedPath == "" {
+		return errors.Errorf("-live and -proposed are required")
+	}
+
+	event, err := readConfigSetEvent(*livePath)
+	if err != nil {
+		return err
+	}
+	live := confighelper.ContractConfigFromConfigSetEvent(*event)
+
+	raw, err := readInput(*proposedPath)
+	if err != nil {
+		return errors.Wrap(err, "could not read proposed config")
+	}
+	var setConfigArgs generateOutput
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&setConfigArgs); err != nil {
+		return errors.Wrap(err, "could not parse proposed config")
+	}
+	proposed := types.ContractConfig{
+		types.ConfigDigest{},
+		setConfigArgs.Signers,
+		setConfigArgs.Transmitters,
+		setConfigArgs.Threshold,
+		setConfigArgs.EncodedConfigVersion,
+		setConfigArgs.Encoded,
+	}
+
+	d, err := confighelper.DiffContractConfigs(live, proposed, *latency)
+	if err != nil {
+		return err
+	}
+
+	output := diffOutput{[]parameterChangeJSON{}, []oracleChangeJSON{}, d.Warnings}
+	for _, change := range d.Parameters {
+		output.Parameters = append(output.Parameters, parameterChangeJSON{change.Name, change.Old, change.New})
+	}
+	for _, change := range d.Oracles {
+		change := change
+		oracle := oracleChangeJSON{
+			common.Address(change.OnChainSigningAddress),
+			nil,
+			nil,
+			change.ChangedFields,
+		}
+		if !change.Added() {
+			oracle.OldOracleID = &change.OldOracleID
+		}
+		if !change.Removed() {
+			oracle.NewOracleID = &change.NewOracleID
+		}
+		output.Oracles = append(output.Oracles, oracle)
+	}
+	return writeJSON(stdout, output)
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"flag"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	p2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/confighelper"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// generateInput is the input of ocrconfig generate. Durations are given as
+// strings understood by time.ParseDuration, e.g. "30s", and keys as hex
+// strings. If DeviationRule is omitted, the relative rule implied by AlphaPPB
+// is used. reportReqCrossChecks, which is off if omitted, needs all oracles to
+// be upgraded (see confighelper.ContractSetConfigArgs). For example:
+//
+//	{
+//	  "deltaProgress": "30s",
+//	  "deltaResend": "10s",
+//	  "deltaRound": "10s",
+//	  "deltaGrace": "1s",
+//	  "deltaC": "10m",
+//	  "heartbeat": {"period": "1h", "offset": "0s"},
+//	  "alphaPPB": 5000000,
+//	  "deltaStage": "5s",
+//	  "rMax": 4,
+//	  "s": [1, 2, 2],
+//	  "f": 1,
+//	  "oracles": [
+//	    {
+//	      "onChainSigningAddress": "0x...",
+//	      "transmitAddress": "0x...",
+//	      "offchainPublicKey": "0x...",
+//	      "peerID": "12D3KooW...",
+//	      "sharedSecretEncryptionPublicKey": "0x..."
+//	    },
+//	    ...
+//	  ]
+//	}
+type generateInput struct {
+	DeltaProgress        duration           `json:"deltaProgress"`
+	DeltaResend          duration           `json:"deltaResend"`
+	DeltaRound           duration           `json:"deltaRound"`
+	DeltaGrace           duration           `json:"deltaGrace"`
+	DeltaC               duration           `json:"deltaC"`
+	Heartbeat            heartbeatJSON      `json:"heartbeat"`
+	AlphaPPB             uint64             `json:"alphaPPB"`
+	DeviationRule        *deviationRuleJSON `json:"deviationRule,omitempty"`
+	ReportReqCrossChecks bool               `json:"reportReqCrossChecks,omitempty"`
+	DeltaStage           duration           `json:"deltaStage"`
+	RMax                 uint8              `json:"rMax"`
+	S                    []int              `json:"s"`
+	F                    int                `json:"f"`
+	Oracles              []oracleJSON       `json:"oracles"`
+}
+
+type heartbeatJSON struct {
+	Period duration `json:"period"`
+	Offset duration `json:"offset"`
+}
+
+// deviationRuleJSON represents a confighelper.DeviationRule. Mode is one of
+// "relative", "absolute", "relativeOrAbsolute" and "relativeAndAbsolute", and
+// the absolute thresholds are decimal strings, since they may not fit into a
+// JSON number.
+type deviationRuleJSON struct {
+	Mode         string `json:"mode"`
+	UpPPB        uint64 `json:"upPPB"`
+	DownPPB      uint64 `json:"downPPB"`
+	UpAbsolute   string `json:"upAbsolute,omitempty"`
+	DownAbsolute string `json:"downAbsolute,omitempty"`
+}
+
+type oracleJSON struct {
+	OnChainSigningAddress           common.Address `json:"onChainSigningAddress"`
+	TransmitAddress                 common.Address `json:"transmitAddress"`
+	OffchainPublicKey               hexutil.Bytes  `json:"offchainPublicKey"`
+	PeerID                          string         `json:"peerID"`
+	SharedSecretEncryptionPublicKey hexutil.Bytes  `json:"sharedSecretEncryptionPublicKey"`
+}
+
+// generateOutput holds the arguments for setConfig on the OffchainAggregator
+type generateOutput struct {
+	Signers              []common.Address `json:"signers"`
+	Transmitters         []common.Address `json:"transmitters"`
+	Threshold            uint8            `json:"threshold"`
+	EncodedConfigVersion uint64           `json:"encodedConfigVersion"`
+	Encoded              hexutil.Bytes    `json:"encoded"`
+}
+
+func generate(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	in := flags.String("in", "", "file with the config parameters as JSON (default: stdin)")
+	encryptionsPaths := flags.String("encryptions", "",
+		"comma-separated files with the results of a shared secret ceremony among the oracles, "+
+			"which must all agree (default: generate a fresh shared secret)")
+	authenticated := flags.Bool("authenticated", false,
+		"encrypt the shared secret with authenticated encryption, which needs all oracles to be upgraded")
+	contract := flags.String("contract", "",
+		"address of the OffchainAggregator the config is set on (required with -authenticated)")
+	configCount := flags.Uint64("count", 0,
+		"configCount the config will have on the contract (required with -authenticated)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *authenticated && *encryptionsPaths != "" {
+		return errors.Errorf("-authenticated and -encryptions are mutually exclusive")
+	}
+	if *authenticated != (*contract != "") || *authenticated != (*configCount != 0) {
+		return errors.Errorf("-contract and -count are required with -authenticated, and only allowed with it")
+	}
+	if *contract != "" && !common.IsHexAddress(*contract) {
+		return errors.Errorf("-contract %q is not an address", *contract)
+	}
+
+	sharedSecretEncryptions, err := readSharedSecretEncryptions(*encryptionsPaths)
+	if err != nil {
+		return err
+	}
+
+	raw, err := readInput(*in)
+	if err != nil {
+		return errors.Wrap(err, "could not read input")
+	}
+	var input generateInput
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&input); err != nil {
+		return errors.Wrap(err, "could not parse input")
+	}
+
+	deviationRule := confighelper.RelativeDeviationRule(input.AlphaPPB)
+	if input.DeviationRule != nil {
+		deviationRule, err = input.DeviationRule.deviationRule()
+		if err != nil {
+			return err
+		}
+	}
+
+	oracles := []confighelper.OracleIdentity{}
+	for i, o := range input.Oracles {
+		oracle, err := o.oracleIdentity()
+		if err != nil {
+			return errors.Wrapf(err, "invalid oracle %d", i)
+		}
+		oracles = append(oracles, oracle)
+	}
+
+	parameters := confighelper.ConfigParameters{
+		DeltaProgress: time.Duration(input.DeltaProgress),
+		DeltaResend:   time.Duration(input.DeltaResend),
+		DeltaRound:    time.Duration(input.DeltaRound),
+		DeltaGrace:    time.Duration(input.DeltaGrace),
+		DeltaC:        time.Duration(input.DeltaC),
+		Heartbeat: confighelper.HeartbeatSchedule{
+			time.Duration(input.Heartbeat.Period),
+			time.Duration(input.Heartbeat.Offset),
+		},
+		AlphaPPB:             input.AlphaPPB,
+		DeviationRule:        deviationRule,
+		ReportReqCrossChecks: input.ReportReqCrossChecks,
+		DeltaStage:           time.Duration(input.DeltaStage),
+		RMax:                 input.RMax,
+		S:                    input.S,
+		Oracles:              oracles,
+		F:                    input.F,
+	}
+	var signers, transmitters []common.Address
+	var threshold uint8
+	var encodedConfigVersion uint64
+	var encoded []byte
+	if *authenticated {
+		signers, transmitters, threshold, encodedConfigVersion, encoded, err =
+			confighelper.ContractSetConfigArgsWithAuthenticatedSharedSecret(
+				parameters,
+				common.HexToAddress(*contract),
+				*configCount,
+			)
+	} else if sharedSecretEncryptions == nil {
+		signers, transmitters, threshold, encodedConfigVersion, encoded, err =
+			confighelper.ContractSetConfigArgs(parameters)
+	} else {
+		signers, transmitters, threshold, encodedConfigVersion, encoded, err =
+			confighelper.ContractSetConfigArgsWithSharedSecretEncryptions(parameters, *sharedSecretEncryptions)
+	}
+	if err != nil {
+		return errors.Wrap(err, "invalid config")
+	}
+
+	return writeJSON(stdout, generateOutput{
+		signers,
+		transmitters,
+		threshold,
+		encodedConfigVersion,
+		encoded,
+	})
+}
+
+// readSharedSecretEncryptions reads the results of a shared secret ceremony,
+// as written by the oracles taking part in it. Every honest oracle outputs the
+// same SharedSecretEncryptions, so they must all agree. paths is a
+// comma-separated list of files; if it's empty, the result is nil.
+func readSharedSecretEncryptions(paths string) (*confighelper.SharedSecretEncryptions, error) {
+	if paths == "" {
+		return nil, nil
+	}
+	var result *confighelper.SharedSecretEncryptions
+	var first string
+	for _, path := range strings.Split(paths, ",") {
+		raw, err := readInput(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read shared secret encryptions from %v", path)
+		}
+		var e confighelper.SharedSecretEncryptions
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return nil, errors.Wrapf(err, "could not parse shared secret encryptions from %v", path)
+		}
+		if result == nil {
+			result, first = &e, path
+		} else if !result.Equal(e) {
+			return nil, errors.Errorf("shared secret encryptions from %v differ from those from %v", path, first)
+		}
+	}
+	return result, nil
+}
+
+var deviationModes = map[string]confighelper.DeviationMode{
+	"relative":            confighelper.DeviationModeRelative,
+	"absolute":            confighelper.DeviationModeAbsolute,
+	"relativeOrAbsolute":  confighelper.DeviationModeRelativeOrAbsolute,
+	"relativeAndAbsolute": confighelper.DeviationModeRelativeAndAbsolute,
+}
+
+func (r deviationRuleJSON) deviationRule() (confighelper.DeviationRule, error) {
+	mode, ok := deviationModes[r.Mode]
+	if !ok {
+		return confighelper.DeviationRule{}, errors.Errorf("unknown deviation mode %q", r.Mode)
+	}
+	upAbsolute, err := parseOptionalBigInt(r.UpAbsolute)
+	if err != nil {
+		return confighelper.DeviationRule{}, errors.Wrap(err, "invalid upAbsolute")
+	}
+	downAbsolute, err := parseOptionalBigInt(r.DownAbsolute)
+	if err != nil {
+		return confighelper.DeviationRule{}, errors.Wrap(err, "invalid downAbsolute")
+	}
+	return confighelper.DeviationRule{
+		mode,
+		r.UpPPB,
+		r.DownPPB,
+		upAbsolute,
+		downAbsolute,
+	}, nil
+}
+
+func parseOptionalBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	x, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.Errorf("%q is not a decimal integer", s)
+	}
+	return x, nil
+}
+
+func (o oracleJSON) oracleIdentity() (confighelper.OracleIdentity, error) {
+	if len(o.OffchainPublicKey) != ed25519.PublicKeySize {
+		return confighelper.OracleIdentity{}, errors.Errorf(
+			"offchainPublicKey must be %d bytes, got %d",
+			ed25519.PublicKeySize, len(o.OffchainPublicKey))
+	}
+	var sharedSecretEncryptionPublicKey types.SharedSecretEncryptionPublicKey
+	if len(o.SharedSecretEncryptionPublicKey) != len(sharedSecretEncryptionPublicKey) {
+		return confighelper.OracleIdentity{}, errors.Errorf(
+			"sharedSecretEncryptionPublicKey must be %d bytes, got %d",
+			len(sharedSecretEncryptionPublicKey), len(o.SharedSecretEncryptionPublicKey))
+	}
+	copy(sharedSecretEncryptionPublicKey[:], o.SharedSecretEncryptionPublicKey)
+	if o.PeerID == "" {
+		return confighelper.OracleIdentity{}, errors.Errorf("peerID is missing")
+	}
+	if _, err := p2ppeer.Decode(o.PeerID); err != nil {
+		return confighelper.OracleIdentity{}, errors.Wrapf(err, "invalid peerID %q", o.PeerID)
+	}
+	return confighelper.OracleIdentity{
+		types.OnChainSigningAddress(o.OnChainSigningAddress),
+		o.TransmitAddress,
+		types.OffchainPublicKey(o.OffchainPublicKey),
+		o.PeerID,
+		sharedSecretEncryptionPublicKey,
+	}, nil
+}
+
+// duration is a time.Duration which is represented in JSON as a string
+// understood by time.ParseDuration
+type duration time.Duration
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.Errorf("duration must be a string such as \"30s\", got %s", b)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(parsed)
+	return nil
+}
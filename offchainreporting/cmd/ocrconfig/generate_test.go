@@ -0,0 +1,35 @@
+package main
+
+import (
+	"crypto/rand"
+	"testing"
+
+	p2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	p2ppeer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestOracleIdentityRejectsInvalidPeerID(t *testing.T) {
+	_, publicKey, err := p2pcrypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerID, err := p2ppeer.IDFromPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := oracleJSON{
+		OffchainPublicKey:               make([]byte, 32),
+		PeerID:                          peerID.Pretty(),
+		SharedSecretEncryptionPublicKey: make([]byte, 32),
+	}
+	if _, err := o.oracleIdentity(); err != nil {
+		t.Fatalf("valid peerID was rejected: %v", err)
+	}
+
+	for _, invalid := range []string{"12D3KooW...", "not a peer id", peerID.Pretty()[1:]} {
+		o.PeerID = invalid
+		if _, err := o.oracleIdentity(); err == nil {
+			t.Errorf("invalid peerID %q was accepted", invalid)
+		}
+	}
+}
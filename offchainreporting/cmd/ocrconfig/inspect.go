@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/gethwrappers/offchainaggregator"
+	"github.com/SeerLink/libocr/offchainreporting/confighelper"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+)
+
+// inspectOutput is the output of ocrconfig inspect. Fields which cannot be
+// determined from the input are omitted.
+type inspectOutput struct {
+	ContractAddress      *common.Address `json:"contractAddress,omitempty"`
+	ConfigCount          *uint64         `json:"configCount,omitempty"`
+	ConfigDigest         hexutil.Bytes   `json:"configDigest,omitempty"`
+	ConfigDigestMatches  *bool           `json:"configDigestMatches,omitempty"`
+	EncodedConfigVersion uint64          `json:"encodedConfigVersion"`
+
+	DeltaProgress        duration          `json:"deltaProgress"`
+	DeltaResend          duration          `json:"deltaResend"`
+	DeltaRound           duration          `json:"deltaRound"`
+	DeltaGrace           duration          `json:"deltaGrace"`
+	DeltaC               duration          `json:"deltaC"`
+	Heartbeat            heartbeatJSON     `json:"heartbeat"`
+	AlphaPPB             uint64            `json:"alphaPPB"`
+	DeviationRule        deviationRuleJSON `json:"deviationRule"`
+	ReportReqCrossChecks bool              `json:"reportReqCrossChecks"`
+	DeltaStage           duration          `json:"deltaStage"`
+	RMax                 uint8             `json:"rMax"`
+	S                    []int             `json:"s"`
+	N                    int               `json:"n"`
+	F                    int               `json:"f"`
+
+	Oracles                 []inspectOracleJSON            `json:"oracles"`
+	SharedSecretEncryptions config.SharedSecretEncryptions `json:"sharedSecretEncryptions"`
+
+	// Error explains why oracles would reject the config, if they would
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings"`
+}
+
+type inspectOracleJSON struct {
+	OnChainSigningAddress *common.Address `json:"onChainSigningAddress,omitempty"`
+	TransmitAddress       *common.Address `json:"transmitAddress,omitempty"`
+	OffchainPublicKey     hexutil.Bytes   `json:"offchainPublicKey"`
+	PeerID                string          `json:"peerID"`
+}
+
+func inspect(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	logPath := flags.String("log", "",
+		"file with a ConfigSet event log of the OffchainAggregator, as returned by eth_getLogs")
+	encoded := flags.String("encoded", "",
+		"hex-encoded ContractConfig.Encoded, to inspect instead of a ConfigSet event")
+	version := flags.Uint64("version", config.EncodedConfigVersion,
+		"EncodedConfigVersion of -encoded")
+	threshold := flags.Uint("threshold", 0,
+		"threshold (F) of the config of -encoded")
+	expectedDigest := flags.String("digest", "",
+		"hex-encoded ConfigDigest to compare the recomputed digest against (requires -log)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		output inspectOutput
+		cfg    config.PublicConfig
+		sse    config.SharedSecretEncryptions
+		err    error
+	)
+	switch {
+	case *logPath != "" && *encoded == "":
+		var event *offchainaggregator.OffchainAggregatorConfigSet
+		event, err = readConfigSetEvent(*logPath)
+		if err != nil {
+			return err
+		}
+		contractConfig := confighelper.ContractConfigFromConfigSetEvent(*event)
+		cfg, sse, err = config.PublicConfigFromContractConfigUnchecked(contractConfig)
+		if err != nil {
+			return errors.Wrap(err, "could not decode config")
+		}
+		output.ContractAddress = &event.Raw.Address
+		output.ConfigCount = &event.ConfigCount
+		output.ConfigDigest = contractConfig.ConfigDigest[:]
+		output.EncodedConfigVersion = contractConfig.EncodedConfigVersion
+		if *expectedDigest != "" {
+			expected, err := hexutil.Decode(*expectedDigest)
+			if err != nil {
+				return errors.Wrap(err, "invalid -digest")
+			}
+			matches := bytes.Equal(expected, contractConfig.ConfigDigest[:])
+			output.ConfigDigestMatches = &matches
+		}
+	case *encoded != "" && *logPath == "":
+		if *expectedDigest != "" {
+			return errors.Errorf("-digest requires -log, since the ConfigDigest depends on the contract address and config count")
+		}
+		if *threshold > 255 {
+			return errors.Errorf("-threshold must fit into 8 bits")
+		}
+		var b []byte
+		b, err = hexutil.Decode(*encoded)
+		if err != nil {
+			return errors.Wrap(err, "invalid -encoded")
+		}
+		cfg, sse, err = config.PublicConfigFromEncodedUnchecked(*version, b, uint8(*threshold))
+		if err != nil {
+			return errors.Wrap(err, "could not decode config")
+		}
+		output.EncodedConfigVersion = *version
+	default:
+		return errors.Errorf("exactly one of -log and -encoded is required")
+	}
+
+	output.DeltaProgress = duration(cfg.DeltaProgress)
+	output.DeltaResend = duration(cfg.DeltaResend)
+	output.DeltaRound = duration(cfg.DeltaRound)
+	output.DeltaGrace = duration(cfg.DeltaGrace)
+	output.DeltaC = duration(cfg.DeltaC)
+	output.Heartbeat = heartbeatJSON{duration(cfg.Heartbeat.Period), duration(cfg.Heartbeat.Offset)}
+	output.AlphaPPB = cfg.AlphaPPB
+	output.DeviationRule = deviationRuleToJSON(cfg.DeviationRule)
+	output.ReportReqCrossChecks = cfg.ReportReqCrossChecks
+	output.DeltaStage = duration(cfg.DeltaStage)
+	output.RMax = cfg.RMax
+	output.S = cfg.S
+	output.N = cfg.N()
+	output.F = cfg.F
+
+	output.Oracles = []inspectOracleJSON{}
+	for _, identity := range cfg.OracleIdentities {
+		oracle := inspectOracleJSON{
+			nil,
+			nil,
+			hexutil.Bytes(identity.OffchainPublicKey),
+			identity.PeerID,
+		}
+		if *logPath != "" {
+			signer := common.Address(identity.OnChainSigningAddress)
+			transmitter := identity.TransmitAddress
+			oracle.OnChainSigningAddress = &signer
+			oracle.TransmitAddress = &transmitter
+		}
+		output.Oracles = append(output.Oracles, oracle)
+	}
+
+	output.SharedSecretEncryptions = sse
+
+	if err := cfg.CheckParameters(); err != nil {
+		output.Error = err.Error()
+	}
+	output.Warnings = cfg.SuspiciousParameters()
+
+	return writeJSON(stdout, output)
+}
+
+func readConfigSetEvent(path string) (*offchainaggregator.OffchainAggregatorConfigSet, error) {
+	raw, err := readInput(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read log")
+	}
+	var log gethtypes.Log
+	if err := json.Unmarshal(raw, &log); err != nil {
+		return nil, errors.Wrap(err, "could not parse log")
+	}
+	contractABI, err := abi.JSON(strings.NewReader(offchainaggregator.OffchainAggregatorABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse OffchainAggregator ABI")
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != contractABI.Events["ConfigSet"].ID {
+		return nil, errors.Errorf("log is not a ConfigSet event")
+	}
+	filterer, err := offchainaggregator.NewOffchainAggregatorFilterer(log.Address, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not construct OffchainAggregator filterer")
+	}
+	event, err := filterer.ParseConfigSet(log)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse log as ConfigSet event")
+	}
+	return event, nil
+}
+
+func deviationRuleToJSON(r observation.DeviationRule) deviationRuleJSON {
+	rv := deviationRuleJSON{r.Mode.String(), r.UpPPB, r.DownPPB, "", ""}
+	if r.UpAbsolute != nil {
+		rv.UpAbsolute = r.UpAbsolute.String()
+	}
+	if r.DownAbsolute != nil {
+		rv.DownAbsolute = r.DownAbsolute.String()
+	}
+	return rv
+}
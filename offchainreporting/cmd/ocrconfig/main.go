@@ -0,0 +1,115 @@
+// Command ocrconfig works with the configs which the OffchainAggregator
+// contract disseminates to oracles.
+//
+// Usage:
+//
+//	ocrconfig generate [-in parameters.json] [-encryptions a.json,b.json,... | -authenticated -contract address -count n]
+//	ocrconfig inspect -log log.json [-digest configDigest]
+//	ocrconfig inspect -encoded hex [-version n] [-threshold f]
+//	ocrconfig diff -live log.json -proposed setconfig.json [-latency d]
+//	ocrconfig rotate-secret -live log.json [-keys keys.json]
+//
+// generate reads the protocol parameters and oracle identities of a new
+// config as JSON, and writes the arguments for a call to setConfig on the
+// OffchainAggregator as JSON. See generateInput for the input format. Each run
+// generates a fresh shared secret, unless -encryptions lists the results of a
+// shared secret ceremony among the oracles (see
+// offchainreporting.RunSharedSecretCeremony), in which case the ceremony's
+// shared secret is used. Passing the results of several oracles guards
+// against a single oracle reporting wrong ones. With -authenticated, the
+// shared secret is encrypted with authenticated encryption bound to the
+// config, which only upgraded oracles can decode. The encryptions are bound to
+// the ConfigDigest too, so -contract and -count must give the
+// OffchainAggregator the config is set on and the configCount it will have
+// there, one more than that of its latest ConfigSet event.
+//
+// inspect decodes a config into JSON, either from a ConfigSet event log of
+// the OffchainAggregator as returned by eth_getLogs, or from the raw Encoded
+// bytes of the config. When given a log, it recomputes the ConfigDigest, and
+// compares it against -digest if present. The output includes the reason
+// oracles would reject the config, if any, and warnings about parameters which
+// are legal but suspicious.
+//
+// diff compares a proposed config, given as the output of generate, against
+// the live config, given as its ConfigSet event log. It lists the changed
+// parameters and oracles, and warns about risky transitions, such as
+// rotating out more than F oracles at once, or timing parameters which don't
+// leave enough room for the expected latency between oracles.
+//
+// rotate-secret writes the arguments for a call to setConfig which keeps the
+// live config, given as its ConfigSet event log, but deals a fresh shared
+// secret. The current SharedSecretEncryptionPublicKeys of the oracles are read
+// as a JSON list of objects with fields onChainSigningAddress and
+// sharedSecretEncryptionPublicKey. The fresh shared secret is chosen by
+// rotate-secret itself, even if the live one came from a shared secret
+// ceremony, so whoever runs it learns the shared secret. To keep the
+// ceremony's guarantee, run a new ceremony and pass its results to generate
+// with -encryptions instead.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"generate", "generate setConfig arguments from parameters and oracle identities", generate},
+	{"inspect", "decode a config from a ConfigSet event or its encoded bytes", inspect},
+	{"diff", "compare a proposed config against the live one", diff},
+	{"rotate-secret", "deal a fresh shared secret chosen by this command (not a ceremony), keeping the live config otherwise", rotateSecret},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			if err := c.run(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "ocrconfig %s: %v\n", c.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: ocrconfig <command> [arguments]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.usage)
+	}
+}
+
+// readInput returns the contents of the file at path, or of stdin if path is
+// empty or "-"
+func readInput(path string) ([]byte, error) {
+	if path == "" || path == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return errors.Wrap(err, "could not write output")
+	}
+	return nil
+}
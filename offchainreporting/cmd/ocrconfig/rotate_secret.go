@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/confighelper"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// sharedSecretEncryptionKeyJSON assigns a SharedSecretEncryptionPublicKey to
+// the oracle with the given OnChainSigningAddress. The input of ocrconfig
+// rotate-secret is a list of these, one for each oracle of the live config,
+// in any order.
+type sharedSecretEncryptionKeyJSON struct {
+	OnChainSigningAddress           common.Address `json:"onChainSigningAddress"`
+	SharedSecretEncryptionPublicKey hexutil.Bytes  `json:"sharedSecretEncryptionPublicKey"`
+}
+
+func rotateSecret(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("rotate-secret", flag.ContinueOnError)
+	livePath := flags.String("live", "",
+		"file with the ConfigSet event log of the live config, as returned by eth_getLogs")
+	keysPath := flags.String("keys", "",
+		"file with the current SharedSecretEncryptionPublicKeys of the oracles as JSON (default: stdin)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *livePath == "" {
+		return errors.Errorf("-live is required")
+	}
+
+	event, err := readConfigSetEvent(*livePath)
+	if err != nil {
+		return err
+	}
+	live := confighelper.ContractConfigFromConfigSetEvent(*event)
+
+	raw, err := readInput(*keysPath)
+	if err != nil {
+		return errors.Wrap(err, "could not read keys")
+	}
+	var keys []sharedSecretEncryptionKeyJSON
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&keys); err != nil {
+		return errors.Wrap(err, "could not parse keys")
+	}
+
+	keysBySigner := map[common.Address]types.SharedSecretEncryptionPublicKey{}
+	for _, k := range keys {
+		var key types.SharedSecretEncryptionPublicKey
+		if len(k.SharedSecretEncryptionPublicKey) != len(key) {
+			return errors.Errorf("sharedSecretEncryptionPublicKey of 0x%x must be %d bytes, got %d",
+				k.OnChainSigningAddress, len(key), len(k.SharedSecretEncryptionPublicKey))
+		}
+		if _, ok := keysBySigner[k.OnChainSigningAddress]; ok {
+			return errors.Errorf("more than one key for 0x%x", k.OnChainSigningAddress)
+		}
+		copy(key[:], k.SharedSecretEncryptionPublicKey)
+		keysBySigner[k.OnChainSigningAddress] = key
+	}
+	if len(keysBySigner) != len(live.Signers) {
+		return errors.Errorf("need a key for each of the %d oracles of the live config, got %d",
+			len(live.Signers), len(keysBySigner))
+	}
+	sharedSecretEncryptionPublicKeys := []types.SharedSecretEncryptionPublicKey{}
+	for _, signer := range live.Signers {
+		key, ok := keysBySigner[signer]
+		if !ok {
+			return errors.Errorf("no key for oracle 0x%x of the live config", signer)
+		}
+		sharedSecretEncryptionPublicKeys = append(sharedSecretEncryptionPublicKeys, key)
+	}
+
+	signers, transmitters, threshold, encodedConfigVersion, encoded, err :=
+		confighelper.ContractSetConfigArgsForSharedSecretRotation(live, sharedSecretEncryptionPublicKeys)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(stdout, generateOutput{
+		signers,
+		transmitters,
+		threshold,
+		encodedConfigVersion,
+		encoded,
+	})
+}
@@ -0,0 +1,148 @@
+// Command ocrkeys manages the keystore files of oracles' PrivateKeys, see
+// package keystore.
+//
+// Usage:
+//
+//	ocrkeys generate -out keys.json -password-file password.txt [-light]
+//	ocrkeys export -in keys.json -password-file password.txt
+//
+// generate creates a keystore file holding fresh keys, encrypted with the
+// password read from the password file. It refuses to overwrite an existing
+// file. -light uses weak scrypt parameters, which are only meant for tests.
+//
+// export decrypts a keystore file and writes the public identity of its keys
+// as JSON, with the field names ocrconfig expects for oracles. generate writes
+// the same output for the keys it creates.
+//
+// Passwords are read from files, rather than passed as arguments, so that
+// they don't show up in process listings. A trailing newline is ignored.
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/keystore"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"generate", "generate keys into a new keystore file", generate},
+	{"export", "write the public identity of the keys in a keystore file", export},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			if err := c.run(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "ocrkeys %s: %v\n", c.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: ocrkeys <command> [arguments]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.usage)
+	}
+}
+
+func generate(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	out := flags.String("out", "", "path of the keystore file to create")
+	passwordFile := flags.String("password-file", "", "file holding the password to encrypt the keys with")
+	light := flags.Bool("light", false, "use weak scrypt parameters, for tests only")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.Errorf("-out is required")
+	}
+	password, err := readPassword(*passwordFile)
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return errors.Errorf("password must not be empty")
+	}
+
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if *light {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+
+	keys, err := keystore.NewKeys(rand.Reader)
+	if err != nil {
+		return err
+	}
+	if err := keystore.WriteKeysFile(*out, keys, password, scryptN, scryptP); err != nil {
+		return errors.Wrap(err, "could not write keystore file")
+	}
+	return writeJSON(stdout, keys.PublicIdentity())
+}
+
+func export(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	in := flags.String("in", "", "path of the keystore file")
+	passwordFile := flags.String("password-file", "", "file holding the password the keys are encrypted with")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return errors.Errorf("-in is required")
+	}
+	password, err := readPassword(*passwordFile)
+	if err != nil {
+		return err
+	}
+
+	keys, err := keystore.ReadKeysFile(*in, password)
+	if err != nil {
+		return err
+	}
+	return writeJSON(stdout, keys.PublicIdentity())
+}
+
+func readPassword(path string) (string, error) {
+	if path == "" {
+		return "", errors.Errorf("-password-file is required")
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read password")
+	}
+	b = bytes.TrimSuffix(b, []byte("\n"))
+	b = bytes.TrimSuffix(b, []byte("\r"))
+	return string(b), nil
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return errors.Wrap(err, "could not write output")
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// Command ocrsigner is a reference remote signer, see package remotesigner.
+// It holds the keys of a keystore file, and serves an oracle's signing
+// requests on a Unix socket.
+//
+// Usage:
+//
+//	ocrsigner -keystore keys.json -password-file password.txt \
+//		-socket /run/ocr/signer.sock -config-digests digests.txt \
+//		-guard-state guard.json
+//
+// The signer only signs reports, observations and telemetry for the configs
+// whose ConfigDigests are listed in the -config-digests file, one hex digest
+// per line. Blank lines and lines starting with # are ignored. The file is
+// read again on SIGHUP, so configs can be allowed without a restart.
+//
+// Only the user running the signer may connect to the socket, unless
+// -socket-group and -socket-mode grant a group access, e.g. the group of an
+// oracle running as another user:
+//
+//	ocrsigner ... -socket-group ocr -socket-mode 0660
+//
+// The signer also refuses to sign two different reports, or two different
+// observations, for the same round, or to sign for a round earlier than one
+// it already signed for. It keeps track of what it signed in the -guard-state
+// file, which is created if it doesn't exist. Keep the file for as long as the
+// keys are in use, and never run two signers with the same keys. Configs
+// removed from the -config-digests file are dropped from the -guard-state
+// file, so only remove configs which have been replaced on chain.
+//
+// The first signature of each report and observation waits until the
+// -guard-state file has been rewritten and synced to disk, which adds the
+// latency of a few fsyncs to each round. Keep the file on a local disk with
+// fast syncs; retried signatures don't touch it.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/keystore"
+	"github.com/SeerLink/libocr/offchainreporting/remotesigner"
+	"github.com/SeerLink/libocr/offchainreporting/signingpolicy"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "ocrsigner: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("ocrsigner", flag.ContinueOnError)
+	keystorePath := flags.String("keystore", "", "path of the keystore file")
+	passwordFile := flags.String("password-file", "", "file holding the password the keys are encrypted with")
+	socket := flags.String("socket", "", "path of the Unix socket to listen on")
+	socketMode := flags.String("socket-mode", "0600", "permissions of the socket, at most 0660")
+	socketGroup := flags.String("socket-group", "", "name or id of the group owning the socket (default: the user's group)")
+	configDigestsPath := flags.String("config-digests", "", "file listing the config digests to sign for")
+	guardStatePath := flags.String("guard-state", "", "file recording the rounds signed for")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *keystorePath == "" || *socket == "" || *configDigestsPath == "" || *guardStatePath == "" {
+		return errors.Errorf("-keystore, -socket, -config-digests and -guard-state are required")
+	}
+	socketPermissions, err := parseSocketPermissions(*socketMode, *socketGroup)
+	if err != nil {
+		return err
+	}
+	password, err := readPassword(*passwordFile)
+	if err != nil {
+		return err
+	}
+	keys, err := keystore.ReadKeysFile(*keystorePath, password)
+	if err != nil {
+		return err
+	}
+	configDigests, err := readConfigDigests(*configDigestsPath)
+	if err != nil {
+		return err
+	}
+
+	guard, err := signingpolicy.NewSigningGuard(signingpolicy.NewFileGuardStore(*guardStatePath))
+	if err != nil {
+		return err
+	}
+	if err := guard.Prune(configDigests); err != nil {
+		return err
+	}
+
+	logger := &stderrLogger{}
+	allowlist := signingpolicy.NewConfigDigestAllowlist(configDigests)
+	listener, err := remotesigner.ListenUnix(*socket, socketPermissions)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:     remotesigner.NewServer(keys, signingpolicy.All(allowlist, guard), logger),
+		ReadTimeout: 10 * time.Second,
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			if sig != syscall.SIGHUP {
+				server.Close()
+				return
+			}
+			configDigests, err := readConfigDigests(*configDigestsPath)
+			if err != nil {
+				logger.Error("ocrsigner: could not reload config digests, keeping the old ones", types.LogFields{
+					"error": err,
+				})
+				continue
+			}
+			allowlist.Set(configDigests)
+			logger.Info("ocrsigner: reloaded config digests", types.LogFields{
+				"count": len(configDigests),
+			})
+			if err := guard.Prune(configDigests); err != nil {
+				logger.Error("ocrsigner: could not prune signing guard state", types.LogFields{
+					"error": err,
+				})
+			}
+		}
+	}()
+
+	logger.Info("ocrsigner: serving", types.LogFields{
+		"socket":        *socket,
+		"identity":      keys.PublicIdentity(),
+		"configDigests": len(configDigests),
+	})
+	err = server.Serve(listener)
+	os.Remove(*socket)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// parseSocketPermissions parses mode as an octal number, and group as the
+// name or id of a group
+func parseSocketPermissions(mode string, group string) (remotesigner.SocketPermissions, error) {
+	perm := remotesigner.DefaultSocketPermissions
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return remotesigner.SocketPermissions{}, errors.Wrap(err, "invalid -socket-mode")
+	}
+	perm.Mode = os.FileMode(m)
+	if group != "" {
+		g, err := user.LookupGroup(group)
+		if err != nil {
+			g, err = user.LookupGroupId(group)
+		}
+		if err != nil {
+			return remotesigner.SocketPermissions{}, errors.Wrap(err, "invalid -socket-group")
+		}
+		perm.Gid, err = strconv.Atoi(g.Gid)
+		if err != nil {
+			return remotesigner.SocketPermissions{}, errors.Wrapf(err, "invalid id of group %s", group)
+		}
+	}
+	return perm, nil
+}
+
+func readPassword(path string) (string, error) {
+	if path == "" {
+		return "", errors.Errorf("-password-file is required")
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read password")
+	}
+	b = bytes.TrimSuffix(b, []byte("\n"))
+	b = bytes.TrimSuffix(b, []byte("\r"))
+	return string(b), nil
+}
+
+// readConfigDigests reads a file with one hex ConfigDigest per line
+func readConfigDigests(path string) ([]types.ConfigDigest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read config digests")
+	}
+	defer f.Close()
+	var rv []types.ConfigDigest
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		b, err := hex.DecodeString(strings.TrimPrefix(line, "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s:%d: invalid config digest", path, lineNumber)
+		}
+		cd, err := types.BytesToConfigDigest(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s:%d: invalid config digest", path, lineNumber)
+		}
+		rv = append(rv, cd)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read config digests")
+	}
+	return rv, nil
+}
+
+// stderrLogger writes log lines as JSON to stderr
+type stderrLogger struct {
+	mutex sync.Mutex
+}
+
+var _ types.Logger = (*stderrLogger)(nil)
+
+func (l *stderrLogger) log(level string, msg string, fields types.LogFields) {
+	line := map[string]interface{}{}
+	for k, v := range fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		line[k] = v
+	}
+	line["ts"] = time.Now().UTC().Format(time.RFC3339Nano)
+	line["level"] = level
+	line["msg"] = msg
+	b, err := json.Marshal(line)
+	if err != nil {
+		b = []byte(fmt.Sprintf(`{"level":%q,"msg":%q,"fields":%q}`, level, msg, fmt.Sprint(fields)))
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	os.Stderr.Write(append(b, '\n'))
+}
+
+func (l *stderrLogger) Trace(msg string, fields types.LogFields) { l.log("trace", msg, fields) }
+func (l *stderrLogger) Debug(msg string, fields types.LogFields) { l.log("debug", msg, fields) }
+func (l *stderrLogger) Info(msg string, fields types.LogFields)  { l.log("info", msg, fields) }
+func (l *stderrLogger) Warn(msg string, fields types.LogFields)  { l.log("warn", msg, fields) }
+func (l *stderrLogger) Error(msg string, fields types.LogFields) { l.log("error", msg, fields) }
@@ -3,11 +3,14 @@
 package confighelper
 
 import (
+	"crypto/rand"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/SeerLink/libocr/gethwrappers/offchainaggregator"
 	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 )
 
@@ -38,10 +41,137 @@ type OracleIdentity struct {
 	SharedSecretEncryptionPublicKey types.SharedSecretEncryptionPublicKey
 }
 
-func ContractSetConfigArgsForIntegrationTest(
-	oracles []OracleIdentity,
-	f int,
-	alphaPPB uint64,
+// HeartbeatSchedule makes oracles report at fixed boundaries even if the
+// value doesn't deviate. The zero value disables heartbeats.
+type HeartbeatSchedule = config.HeartbeatSchedule
+
+// DeviationRule decides whether a new value differs enough from the last
+// reported value to be worth reporting.
+type DeviationRule = observation.DeviationRule
+
+// DeviationMode determines how the thresholds of a DeviationRule are combined
+type DeviationMode = observation.DeviationMode
+
+const (
+	DeviationModeRelative            = observation.DeviationModeRelative
+	DeviationModeAbsolute            = observation.DeviationModeAbsolute
+	DeviationModeRelativeOrAbsolute  = observation.DeviationModeRelativeOrAbsolute
+	DeviationModeRelativeAndAbsolute = observation.DeviationModeRelativeAndAbsolute
+)
+
+// RelativeDeviationRule returns the symmetric relative rule with the given
+// threshold. This is the rule of configs which only specify alphaPPB.
+func RelativeDeviationRule(thresholdPPB uint64) DeviationRule {
+	return observation.RelativeDeviationRule(thresholdPPB)
+}
+
+// deviationRuleOrDefault returns deviationRule, unless it's the zero
+// DeviationRule, in which case it returns the rule implied by alphaPPB. A zero
+// rule would otherwise report every change of the value, whatever alphaPPB
+// says.
+func deviationRuleOrDefault(alphaPPB uint64, deviationRule DeviationRule) DeviationRule {
+	if deviationRule.Mode == DeviationModeRelative &&
+		deviationRule.UpPPB == 0 && deviationRule.DownPPB == 0 &&
+		deviationRule.UpAbsolute == nil && deviationRule.DownAbsolute == nil {
+		return RelativeDeviationRule(alphaPPB)
+	}
+	return deviationRule
+}
+
+// ConfigParameters holds the protocol parameters and oracles of a new config,
+// as taken by ContractSetConfigArgs and its variants. Use keyed fields, so
+// that parameters of the same type can't be swapped silently, and so that
+// parameters added by later config versions don't break callers.
+type ConfigParameters struct {
+	DeltaProgress time.Duration
+	DeltaResend   time.Duration
+	DeltaRound    time.Duration
+	DeltaGrace    time.Duration
+	DeltaC        time.Duration
+	// Heartbeat is optional, the zero HeartbeatSchedule disables heartbeats
+	Heartbeat HeartbeatSchedule
+	AlphaPPB  uint64
+	// DeviationRule decides when the value deviates enough to be reported.
+	// Leave it zero to use the relative rule implied by AlphaPPB, as configs
+	// without a DeviationRule do.
+	DeviationRule DeviationRule
+	// ReportReqCrossChecks makes followers exchange digests of the report-reqs
+	// they accepted, to detect observers who sign conflicting observations.
+	// Oracles running versions of this library without support for
+	// cross-checks reject configs which enable them, so only enable them once
+	// all oracles have been upgraded.
+	ReportReqCrossChecks bool
+	DeltaStage           time.Duration
+	RMax                 uint8
+	S                    []int
+	// Oracles lists every oracle taking part in the config, in oracle id
+	// order
+	Oracles []OracleIdentity
+	// F is the number of oracles which may be faulty
+	F int
+}
+
+// publicConfig returns the PublicConfig described by p, and the
+// SharedSecretEncryptionPublicKeys of its oracles
+func (p ConfigParameters) publicConfig() (config.PublicConfig, []types.SharedSecretEncryptionPublicKey) {
+	identities, sharedSecretEncryptionPublicKeys := splitOracleIdentities(p.Oracles)
+	return config.PublicConfig{
+		p.DeltaProgress,
+		p.DeltaResend,
+		p.DeltaRound,
+		p.DeltaGrace,
+		p.DeltaC,
+		p.Heartbeat,
+		p.AlphaPPB,
+		deviationRuleOrDefault(p.AlphaPPB, p.DeviationRule),
+		p.ReportReqCrossChecks,
+		p.DeltaStage,
+		p.RMax,
+		p.S,
+		identities,
+		p.F,
+		types.ConfigDigest{},
+	}, sharedSecretEncryptionPublicKeys
+}
+
+// ContractSetConfigArgs returns the arguments for a call to setConfig on the
+// OffchainAggregator which installs a config with the given parameters. A
+// fresh shared secret is generated for each call, so calling this twice with
+// the same parameters yields different encodedConfigs.
+//
+// The parameters are checked with the same rules oracles apply when they
+// receive the config, so a config produced by this function won't be rejected
+// by well-behaved oracles.
+func ContractSetConfigArgs(parameters ConfigParameters) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+	err error,
+) {
+	publicConfig, sharedSecretEncryptionPublicKeys := parameters.publicConfig()
+	return config.ContractSetConfigArgs(publicConfig, sharedSecretEncryptionPublicKeys, rand.Reader)
+}
+
+// ContractSetConfigArgsWithAuthenticatedSharedSecret is like
+// ContractSetConfigArgs, but encrypts the shared secret with an authenticated
+// encryption scheme which binds each encryption to the config and to the
+// oracle it's meant for. The resulting config has
+// EncodedConfigVersionWithAuthenticatedSharedSecret, which oracles running
+// older versions of this library reject, so only use this once all oracles
+// have been upgraded. Until then, ContractSetConfigArgs produces configs all
+// oracles accept.
+//
+// The encryptions are bound to the ConfigDigest the config will have, so
+// contractAddress must be the OffchainAggregator the config is set on, and
+// configCount the count it will have there, i.e. one more than the
+// configCount of the latest ConfigSet event. If another config is set first,
+// oracles reject this one, and it needs to be generated again.
+func ContractSetConfigArgsWithAuthenticatedSharedSecret(
+	parameters ConfigParameters,
+	contractAddress common.Address,
+	configCount uint64,
 ) (
 	signers []common.Address,
 	transmitters []common.Address,
@@ -50,11 +180,51 @@ func ContractSetConfigArgsForIntegrationTest(
 	encodedConfig []byte,
 	err error,
 ) {
-	S := []int{}
+	publicConfig, sharedSecretEncryptionPublicKeys := parameters.publicConfig()
+	return config.ContractSetConfigArgsWithAuthenticatedSharedSecret(
+		publicConfig,
+		contractAddress,
+		configCount,
+		sharedSecretEncryptionPublicKeys,
+		rand.Reader,
+	)
+}
+
+// SharedSecretEncryptions holds the encryptions of a config's shared secret
+// to each oracle. It can be marshaled to and from JSON.
+type SharedSecretEncryptions = config.SharedSecretEncryptions
+
+// ContractSetConfigArgsWithSharedSecretEncryptions is like
+// ContractSetConfigArgs, but uses a shared secret chosen by the oracles
+// themselves through offchainreporting.RunSharedSecretCeremony, rather than
+// one chosen by the caller. sharedSecretEncryptions is the result of the
+// ceremony among parameters.Oracles, in the same order. The
+// SharedSecretEncryptionPublicKeys of oracles are ignored, since the ceremony already encrypted the shared
+// secret to them. The ceremony doesn't know the rest of the config, so its
+// encryptions can't be bound to it as in
+// ContractSetConfigArgsWithAuthenticatedSharedSecret.
+func ContractSetConfigArgsWithSharedSecretEncryptions(
+	parameters ConfigParameters,
+	sharedSecretEncryptions SharedSecretEncryptions,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+	err error,
+) {
+	publicConfig, _ := parameters.publicConfig()
+	return config.ContractSetConfigArgsWithSharedSecretEncryptions(publicConfig, sharedSecretEncryptions)
+}
+
+func splitOracleIdentities(oracles []OracleIdentity) (
+	[]config.OracleIdentity,
+	[]types.SharedSecretEncryptionPublicKey,
+) {
 	identities := []config.OracleIdentity{}
 	sharedSecretEncryptionPublicKeys := []types.SharedSecretEncryptionPublicKey{}
 	for _, oracle := range oracles {
-		S = append(S, 1)
 		identities = append(identities, config.OracleIdentity{
 			oracle.PeerID,
 			oracle.OffchainPublicKey,
@@ -63,6 +233,29 @@ func ContractSetConfigArgsForIntegrationTest(
 		})
 		sharedSecretEncryptionPublicKeys = append(sharedSecretEncryptionPublicKeys, oracle.SharedSecretEncryptionPublicKey)
 	}
+	return identities, sharedSecretEncryptionPublicKeys
+}
+
+// ContractSetConfigArgsForIntegrationTest is like ContractSetConfigArgs, but
+// uses fixed timing parameters and a fixed, publicly known shared secret. Do
+// not use it outside of tests; use ContractSetConfigArgs instead.
+func ContractSetConfigArgsForIntegrationTest(
+	oracles []OracleIdentity,
+	f int,
+	alphaPPB uint64,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+	err error,
+) {
+	S := []int{}
+	for range oracles {
+		S = append(S, 1)
+	}
+	identities, sharedSecretEncryptionPublicKeys := splitOracleIdentities(oracles)
 	sharedConfig := config.SharedConfig{
 		config.PublicConfig{
 			2 * time.Second,
@@ -70,7 +263,10 @@ func ContractSetConfigArgsForIntegrationTest(
 			1 * time.Second,
 			500 * time.Millisecond,
 			0,
+			config.HeartbeatSchedule{},
 			alphaPPB,
+			observation.RelativeDeviationRule(alphaPPB),
+			false,
 			2 * time.Second,
 			3,
 			S,
@@ -82,3 +278,80 @@ func ContractSetConfigArgsForIntegrationTest(
 	}
 	return config.XXXContractSetConfigArgsFromSharedConfig(sharedConfig, sharedSecretEncryptionPublicKeys)
 }
+
+// ConfigDiff describes how a proposed config differs from the live one
+type ConfigDiff = config.ConfigDiff
+
+// DiffContractConfigs compares a proposed config against the live one, before
+// the proposed config is submitted with setConfig. The proposed config is
+// usually assembled from the results of ContractSetConfigArgs; its
+// ConfigDigest is ignored. expectedLatency is the expected one-way latency of
+// messages between oracles; if it's positive, the proposed timing parameters
+// are checked against it.
+func DiffContractConfigs(
+	live types.ContractConfig,
+	proposed types.ContractConfig,
+	expectedLatency time.Duration,
+) (ConfigDiff, error) {
+	liveConfig, _, err := config.PublicConfigFromContractConfigUnchecked(live)
+	if err != nil {
+		return ConfigDiff{}, errors.Wrap(err, "could not decode live config")
+	}
+	proposedConfig, _, err := config.PublicConfigFromContractConfigUnchecked(proposed)
+	if err != nil {
+		return ConfigDiff{}, errors.Wrap(err, "could not decode proposed config")
+	}
+	return config.DiffPublicConfigs(liveConfig, proposedConfig, expectedLatency), nil
+}
+
+// ContractSetConfigArgsForSharedSecretRotation returns the arguments for a
+// call to setConfig which deals a fresh shared secret to the oracles of live,
+// e.g. after the SharedSecretEncryptionPublicKey of an oracle leaked. The
+// protocol parameters and oracle identities of live are kept unchanged, so no
+// oracle needs to be reconfigured. sharedSecretEncryptionPublicKeys must hold
+// the current key of each oracle of live, in oracle id order. Oracles whose key
+// leaked need to switch to a new key before the rotation.
+//
+// If live uses authenticated encryptions of the shared secret (see
+// ContractSetConfigArgsWithAuthenticatedSharedSecret), so does the result.
+// They are bound to the config following live on the same contract, so no
+// other config may be set before the rotation.
+//
+// Like any setConfig call, the rotation changes the ConfigDigest, so oracles
+// restart the protocol once the new config is on chain.
+//
+// The fresh shared secret is chosen by the caller, even if live used one
+// chosen by the oracles through offchainreporting.RunSharedSecretCeremony, so
+// the rotation gives up the guarantee that no single party knows the shared
+// secret. To keep it, run a new ceremony and pass its results with the
+// parameters of live to ContractSetConfigArgsWithSharedSecretEncryptions.
+func ContractSetConfigArgsForSharedSecretRotation(
+	live types.ContractConfig,
+	sharedSecretEncryptionPublicKeys []types.SharedSecretEncryptionPublicKey,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+	err error,
+) {
+	liveConfig, err := config.PublicConfigFromContractConfig(live)
+	if err != nil {
+		return nil, nil, 0, 0, nil, errors.Wrap(err, "could not decode live config")
+	}
+	if live.EncodedConfigVersion == config.EncodedConfigVersionWithAuthenticatedSharedSecret {
+		contractAddress, configCount, err := config.ContractAddressAndConfigCount(live)
+		if err != nil {
+			return nil, nil, 0, 0, nil, errors.Wrap(err, "could not decode live config")
+		}
+		return config.ContractSetConfigArgsWithAuthenticatedSharedSecret(
+			liveConfig,
+			contractAddress,
+			configCount+1,
+			sharedSecretEncryptionPublicKeys,
+			rand.Reader,
+		)
+	}
+	return config.ContractSetConfigArgs(liveConfig, sharedSecretEncryptionPublicKeys, rand.Reader)
+}
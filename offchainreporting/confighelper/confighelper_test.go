@@ -0,0 +1,111 @@
+package confighelper
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+func testOracles() []OracleIdentity {
+	oracles := []OracleIdentity{}
+	for i := byte(1); i <= 4; i++ {
+		var sharedSecretEncryptionPublicKey types.SharedSecretEncryptionPublicKey
+		pk, err := curve25519.X25519(bytes.Repeat([]byte{i}, curve25519.ScalarSize), curve25519.Basepoint)
+		if err != nil {
+			panic(err)
+		}
+		copy(sharedSecretEncryptionPublicKey[:], pk)
+		oracles = append(oracles, OracleIdentity{
+			types.OnChainSigningAddress{i},
+			common.Address{i},
+			types.OffchainPublicKey{i},
+			fmt.Sprintf("peer%d", i),
+			sharedSecretEncryptionPublicKey,
+		})
+	}
+	return oracles
+}
+
+// decodeTestConfig returns the PublicConfig of a config produced by
+// ContractSetConfigArgs with the given deviation parameters
+func decodeTestConfig(t *testing.T, alphaPPB uint64, deviationRule DeviationRule) (types.ContractConfig, config.PublicConfig) {
+	signers, transmitters, threshold, version, encoded, err := ContractSetConfigArgs(ConfigParameters{
+		DeltaProgress: 10 * time.Second,
+		DeltaResend:   10 * time.Second,
+		DeltaRound:    5 * time.Second,
+		DeltaGrace:    time.Second,
+		DeltaC:        time.Minute,
+		AlphaPPB:      alphaPPB,
+		DeviationRule: deviationRule,
+		DeltaStage:    5 * time.Second,
+		RMax:          10,
+		S:             []int{1, 1, 1, 1},
+		Oracles:       testOracles(),
+		F:             1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	change := types.ContractConfig{types.ConfigDigest{1}, signers, transmitters, threshold, version, encoded}
+	c, err := config.PublicConfigFromContractConfig(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return change, c
+}
+
+func TestContractSetConfigArgsDefaultsDeviationRuleToAlphaPPB(t *testing.T) {
+	const alphaPPB = 5000000
+	change, c := decodeTestConfig(t, alphaPPB, DeviationRule{})
+	if change.EncodedConfigVersion != config.EncodedConfigVersion {
+		t.Errorf("config without a deviation rule must not need extensions, got EncodedConfigVersion %d",
+			change.EncodedConfigVersion)
+	}
+	if c.DeviationRule.String() != RelativeDeviationRule(alphaPPB).String() {
+		t.Errorf("expected the rule implied by alphaPPB, got %v", c.DeviationRule)
+	}
+
+	rule := DeviationRule{DeviationModeRelative, alphaPPB, 2 * alphaPPB, nil, nil}
+	if _, c := decodeTestConfig(t, alphaPPB, rule); c.DeviationRule.String() != rule.String() {
+		t.Errorf("expected %v, got %v", rule, c.DeviationRule)
+	}
+}
+
+func TestContractSetConfigArgsForSharedSecretRotation(t *testing.T) {
+	live, liveConfig := decodeTestConfig(t, 5000000, DeviationRule{})
+	_, liveEncryptions, err := config.PublicConfigFromContractConfigUnchecked(live)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, sharedSecretEncryptionPublicKeys := splitOracleIdentities(testOracles())
+	signers, transmitters, threshold, version, encoded, err :=
+		ContractSetConfigArgsForSharedSecretRotation(live, sharedSecretEncryptionPublicKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated := types.ContractConfig{types.ConfigDigest{2}, signers, transmitters, threshold, version, encoded}
+	rotatedConfig, rotatedEncryptions, err := config.PublicConfigFromContractConfigUnchecked(rotated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != live.EncodedConfigVersion || threshold != live.Threshold ||
+		!reflect.DeepEqual(signers, live.Signers) || !reflect.DeepEqual(transmitters, live.Transmitters) {
+		t.Errorf("rotation changed the setConfig arguments besides the encoded config")
+	}
+	rotatedConfig.ConfigDigest = liveConfig.ConfigDigest
+	if !reflect.DeepEqual(rotatedConfig, liveConfig) {
+		t.Errorf("rotation changed the public config from %+v to %+v", liveConfig, rotatedConfig)
+	}
+	if rotatedEncryptions.SharedSecretHash == liveEncryptions.SharedSecretHash {
+		t.Errorf("rotation kept the shared secret")
+	}
+}
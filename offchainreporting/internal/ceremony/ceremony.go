@@ -0,0 +1,404 @@
+// Package ceremony implements a commit-reveal ceremony through which the
+// oracles of a config jointly choose the config's shared secret, so that the
+// dealer submitting the config doesn't get to choose or learn it.
+//
+// Each oracle broadcasts a commitment to a random contribution. Once an oracle
+// has received the commitments of all participants, it reveals its
+// contribution. The shared secret is a hash of all contributions, so it is
+// unpredictable as long as a single participant is honest. An oracle which
+// withholds its reveal after seeing the others' can only make the ceremony
+// fail, and not choose the secret, but it can bias the secret by deciding
+// whether the ceremony fails. The ceremony needs all participants to be
+// online.
+//
+// The SharedSecretEncryptions of the secret are derived deterministically from
+// the secret, so all participants output the same SharedSecretEncryptions, and
+// the dealer can compare the outputs of several participants rather than trust
+// a single one.
+package ceremony
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	contributionSize = 32
+
+	// The domain separators make sure that hashes computed by the ceremony
+	// cannot be confused with hashes computed elsewhere, or with each other
+	ceremonyIDDomainSeparator    = "ocr shared secret ceremony v1 id"
+	commitmentDomainSeparator    = "ocr shared secret ceremony v1 commitment"
+	sharedSecretDomainSeparator  = "ocr shared secret ceremony v1 shared secret"
+	ephemeralKeyDomainSeparator  = "ocr shared secret ceremony v1 ephemeral key"
+	defaultResendInterval        = 2 * time.Second
+	lingerResendIntervals        = 5
+	maxMessagesPerResendInterval = 3
+)
+
+// Participant is an oracle taking part in a ceremony
+type Participant struct {
+	OnChainSigningAddress           types.OnChainSigningAddress
+	PeerID                          string
+	SharedSecretEncryptionPublicKey types.SharedSecretEncryptionPublicKey
+}
+
+// CeremonyID identifies a ceremony among the given participants. It is used in
+// place of a ConfigDigest to set up the network endpoint for the ceremony, and
+// binds all messages of the ceremony. nonce must be agreed upon by all
+// participants and must not be reused.
+func CeremonyID(participants []Participant, nonce []byte) types.ConfigDigest {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(ceremonyIDDomainSeparator))
+	writeLengthPrefixed(h, nonce)
+	for _, p := range participants {
+		h.Write(p.OnChainSigningAddress[:])
+		writeLengthPrefixed(h, []byte(p.PeerID))
+		h.Write(p.SharedSecretEncryptionPublicKey[:])
+	}
+	var id types.ConfigDigest
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
+// Run takes part in the ceremony among participants, in the role of the
+// participant whose OnChainSigningAddress matches privateKeys. It returns the
+// SharedSecretEncryptions of the jointly chosen shared secret to the
+// participants' SharedSecretEncryptionPublicKeys, in the order of
+// participants.
+//
+// Run returns an error if ctx expires before all participants have revealed
+// their contributions, or if a participant misbehaves.
+func Run(
+	ctx context.Context,
+
+	bootstrappers []string,
+	logger types.Logger,
+	netEndpointFactory types.BinaryNetworkEndpointFactory,
+	nonce []byte,
+	participants []Participant,
+	privateKeys types.PrivateKeys,
+	resendInterval time.Duration,
+) (config.SharedSecretEncryptions, error) {
+	if resendInterval == 0 {
+		resendInterval = defaultResendInterval
+	}
+
+	oid := types.OracleID(-1)
+	peerIDs := []string{}
+	for i, p := range participants {
+		if p.OnChainSigningAddress == privateKeys.PublicKeyAddressOnChain() {
+			oid = types.OracleID(i)
+		}
+		peerIDs = append(peerIDs, p.PeerID)
+	}
+	if oid < 0 {
+		return config.SharedSecretEncryptions{}, errors.Errorf(
+			"could not find my OnChainSigningAddress 0x%x among the participants",
+			privateKeys.PublicKeyAddressOnChain())
+	}
+	if participants[oid].PeerID != netEndpointFactory.PeerID() {
+		return config.SharedSecretEncryptions{}, errors.Errorf(
+			"my PeerID %v doesn't match the one of my participant entry: %v",
+			netEndpointFactory.PeerID(), participants[oid].PeerID)
+	}
+
+	var contribution [contributionSize]byte
+	if _, err := io.ReadFull(rand.Reader, contribution[:]); err != nil {
+		return config.SharedSecretEncryptions{}, errors.Wrap(err, "could not generate contribution")
+	}
+
+	id := CeremonyID(participants, nonce)
+	endpoint, err := netEndpointFactory.MakeEndpoint(
+		id,
+		peerIDs,
+		bootstrappers,
+		(len(participants)-1)/3,
+		2*float64(maxMessagesPerResendInterval)/resendInterval.Seconds(),
+		2*maxMessagesPerResendInterval,
+	)
+	if err != nil {
+		return config.SharedSecretEncryptions{}, errors.Wrap(err, "could not make network endpoint")
+	}
+	if err := endpoint.Start(); err != nil {
+		return config.SharedSecretEncryptions{}, errors.Wrap(err, "could not start network endpoint")
+	}
+	defer func() {
+		if err := endpoint.Close(); err != nil {
+			logger.Error("ceremony: could not close network endpoint", types.LogFields{
+				"error": err,
+			})
+		}
+	}()
+
+	c := &ceremony{
+		id,
+		contribution,
+		endpoint,
+		logger,
+		oid,
+		participants,
+		privateKeys,
+
+		make([][]byte, len(participants)),
+		make([][]byte, len(participants)),
+		make([]*common.Hash, len(participants)),
+		false,
+		nil,
+	}
+	c.commitments[oid] = commitment(id, oid, contribution[:])
+	c.contributions[oid] = contribution[:]
+	return c.run(ctx, resendInterval)
+}
+
+type ceremony struct {
+	id           types.ConfigDigest
+	contribution [contributionSize]byte
+	endpoint     types.BinaryNetworkEndpoint
+	logger       types.Logger
+	oid          types.OracleID
+	participants []Participant
+	privateKeys  types.PrivateKeys
+
+	commitments      [][]byte
+	contributions    [][]byte
+	sharedSecretHash []*common.Hash // as reported by each participant's done message
+	revealed         bool
+	result           *config.SharedSecretEncryptions
+}
+
+func (c *ceremony) run(ctx context.Context, resendInterval time.Duration) (config.SharedSecretEncryptions, error) {
+	ticker := time.NewTicker(resendInterval)
+	defer ticker.Stop()
+
+	// Once we're done, we keep resending our messages for a while, to help
+	// participants which missed them
+	var chLinger <-chan time.Time
+
+	c.broadcast()
+	for {
+		select {
+		case msg := <-c.endpoint.Receive():
+			if msg.Sender == c.oid {
+				break
+			}
+			if !(0 <= msg.Sender && int(msg.Sender) < len(c.participants)) {
+				c.logger.Warn("ceremony: dropping message from unknown sender", types.LogFields{
+					"sender": msg.Sender,
+				})
+				break
+			}
+			if err := c.receive(msg.Sender, msg.Msg); err != nil {
+				return config.SharedSecretEncryptions{}, err
+			}
+		case <-ticker.C:
+			c.broadcast()
+		case <-chLinger:
+			return *c.result, nil
+		case <-ctx.Done():
+			if c.result != nil {
+				return *c.result, nil
+			}
+			return config.SharedSecretEncryptions{}, errors.Errorf(
+				"ceremony did not complete: %s", c.missing())
+		}
+
+		if c.result != nil && chLinger == nil {
+			c.logger.Info("ceremony: completed", types.LogFields{
+				"sharedSecretHash": c.result.SharedSecretHash,
+			})
+			chLinger = time.After(lingerResendIntervals * resendInterval)
+		}
+		if c.result != nil && c.allDone() {
+			return *c.result, nil
+		}
+
+		// ensure prompt exit
+		select {
+		case <-ctx.Done():
+			if c.result != nil {
+				return *c.result, nil
+			}
+			return config.SharedSecretEncryptions{}, errors.Errorf(
+				"ceremony did not complete: %s", c.missing())
+		default:
+		}
+	}
+}
+
+// receive processes a message from sender, and returns an error iff sender
+// provably misbehaved
+func (c *ceremony) receive(sender types.OracleID, payload []byte) error {
+	msg, err := deserialize(payload)
+	if err != nil {
+		c.logger.Warn("ceremony: dropping malformed message", types.LogFields{
+			"sender": sender,
+			"error":  err,
+		})
+		return nil
+	}
+	switch msg := msg.(type) {
+	case messageCommit:
+		if c.commitments[sender] == nil {
+			c.commitments[sender] = msg.commitment
+		} else if !bytes.Equal(c.commitments[sender], msg.commitment) {
+			return errors.Errorf("participant %d sent conflicting commitments", sender)
+		}
+		if !c.revealed && c.allCommitted() {
+			c.revealed = true
+			c.broadcast()
+		}
+	case messageReveal:
+		if c.commitments[sender] == nil {
+			// We can't check the contribution yet. The participant will resend
+			// it, and we will receive its commitment eventually.
+			return nil
+		}
+		if !bytes.Equal(c.commitments[sender], commitment(c.id, sender, msg.contribution)) {
+			return errors.Errorf("participant %d revealed a contribution which doesn't match its commitment", sender)
+		}
+		c.contributions[sender] = msg.contribution
+		if c.result == nil && c.allRevealed() {
+			if err := c.complete(); err != nil {
+				return err
+			}
+			c.broadcast()
+		}
+	case messageDone:
+		c.sharedSecretHash[sender] = &msg.sharedSecretHash
+	}
+	if c.result != nil {
+		for i, h := range c.sharedSecretHash {
+			if h != nil && *h != c.result.SharedSecretHash {
+				return errors.Errorf("participant %d arrived at a different shared secret", i)
+			}
+		}
+	}
+	return nil
+}
+
+// complete computes the shared secret and its encryptions from the
+// contributions of all participants
+func (c *ceremony) complete() error {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(sharedSecretDomainSeparator))
+	h.Write(c.id[:])
+	for _, contribution := range c.contributions {
+		h.Write(contribution)
+	}
+	var sharedSecret [config.SharedSecretSize]byte
+	copy(sharedSecret[:], h.Sum(nil))
+
+	// The ephemeral key only needs to be unpredictable to outsiders, so it may
+	// be derived from the shared secret. This makes the encryptions
+	// deterministic.
+	var sk [32]byte
+	copy(sk[:], crypto.Keccak256([]byte(ephemeralKeyDomainSeparator), sharedSecret[:]))
+
+	publicKeys := []types.SharedSecretEncryptionPublicKey{}
+	for _, p := range c.participants {
+		publicKeys = append(publicKeys, p.SharedSecretEncryptionPublicKey)
+	}
+	encryptions := config.XXXEncryptSharedSecretInternal(publicKeys, &sharedSecret, &sk)
+
+	// Check that we will be able to decrypt the shared secret once it's part
+	// of a config. This catches a wrong SharedSecretEncryptionPublicKey in our
+	// participant entry.
+	decrypted, err := encryptions.Decrypt(c.oid, c.privateKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not decrypt my own encryption of the shared secret")
+	}
+	if *decrypted != sharedSecret {
+		return errors.Errorf("my own encryption of the shared secret decrypts to something else")
+	}
+
+	c.result = &encryptions
+	c.sharedSecretHash[c.oid] = &encryptions.SharedSecretHash
+	return nil
+}
+
+// broadcast sends all messages due at our current stage of the ceremony.
+// Earlier messages are included, since other participants may have missed
+// them.
+func (c *ceremony) broadcast() {
+	c.endpoint.Broadcast(serialize(messageCommit{c.commitments[c.oid]}))
+	if c.revealed {
+		c.endpoint.Broadcast(serialize(messageReveal{c.contribution[:]}))
+	}
+	if c.result != nil {
+		c.endpoint.Broadcast(serialize(messageDone{c.result.SharedSecretHash}))
+	}
+}
+
+func (c *ceremony) allCommitted() bool {
+	for _, commitment := range c.commitments {
+		if commitment == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ceremony) allRevealed() bool {
+	for _, contribution := range c.contributions {
+		if contribution == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ceremony) allDone() bool {
+	for _, h := range c.sharedSecretHash {
+		if h == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// missing describes the participants we're still waiting for
+func (c *ceremony) missing() string {
+	uncommitted, unrevealed := []int{}, []int{}
+	for i := range c.participants {
+		if c.commitments[i] == nil {
+			uncommitted = append(uncommitted, i)
+		} else if c.contributions[i] == nil {
+			unrevealed = append(unrevealed, i)
+		}
+	}
+	if len(uncommitted) != 0 {
+		return fmt.Sprintf("no commitment from participants %v", uncommitted)
+	}
+	return fmt.Sprintf("no contribution from participants %v", unrevealed)
+}
+
+func commitment(id types.ConfigDigest, oid types.OracleID, contribution []byte) []byte {
+	var oidBytes [4]byte
+	binary.BigEndian.PutUint32(oidBytes[:], uint32(oid))
+	return crypto.Keccak256(
+		[]byte(commitmentDomainSeparator),
+		id[:],
+		oidBytes[:],
+		contribution,
+	)
+}
+
+func writeLengthPrefixed(w io.Writer, b []byte) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(b)))
+	w.Write(length[:])
+	w.Write(b)
+}
@@ -0,0 +1,330 @@
+package ceremony
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// memNetwork connects memEndpoints by peer ID. Messages to endpoints which
+// don't exist yet, or whose queue is full, are dropped, like on a real
+// network. The ceremony resends its messages anyway.
+type memNetwork struct {
+	mu        sync.Mutex
+	endpoints map[string]*memEndpoint
+}
+
+func newMemNetwork() *memNetwork {
+	return &memNetwork{endpoints: map[string]*memEndpoint{}}
+}
+
+func (n *memNetwork) deliver(to string, msg types.BinaryMessageWithSender) {
+	n.mu.Lock()
+	e, ok := n.endpoints[to]
+	n.mu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case e.chReceive <- msg:
+	default:
+	}
+}
+
+// memEndpointFactory makes the endpoints of the peer with peerID
+type memEndpointFactory struct {
+	network *memNetwork
+	peerID  string
+}
+
+var _ types.BinaryNetworkEndpointFactory = memEndpointFactory{}
+
+func (f memEndpointFactory) MakeEndpoint(
+	_ types.ConfigDigest, peerIDs []string, _ []string, _ int, _ float64, _ int,
+) (types.BinaryNetworkEndpoint, error) {
+	oid := types.OracleID(-1)
+	for i, peerID := range peerIDs {
+		if peerID == f.peerID {
+			oid = types.OracleID(i)
+		}
+	}
+	if oid < 0 {
+		return nil, fmt.Errorf("peer %v is not among %v", f.peerID, peerIDs)
+	}
+	e := &memEndpoint{f.network, peerIDs, oid, make(chan types.BinaryMessageWithSender, 1000)}
+	f.network.mu.Lock()
+	f.network.endpoints[f.peerID] = e
+	f.network.mu.Unlock()
+	return e, nil
+}
+
+func (f memEndpointFactory) PeerID() string {
+	return f.peerID
+}
+
+type memEndpoint struct {
+	network   *memNetwork
+	peerIDs   []string
+	oid       types.OracleID
+	chReceive chan types.BinaryMessageWithSender
+}
+
+var _ types.BinaryNetworkEndpoint = (*memEndpoint)(nil)
+
+func (e *memEndpoint) SendTo(payload []byte, to types.OracleID) {
+	e.network.deliver(e.peerIDs[to], types.BinaryMessageWithSender{payload, e.oid})
+}
+
+func (e *memEndpoint) Broadcast(payload []byte) {
+	for i := range e.peerIDs {
+		if types.OracleID(i) != e.oid {
+			e.SendTo(payload, types.OracleID(i))
+		}
+	}
+}
+
+func (e *memEndpoint) Receive() <-chan types.BinaryMessageWithSender {
+	return e.chReceive
+}
+
+func (e *memEndpoint) Start() error { return nil }
+func (e *memEndpoint) Close() error { return nil }
+
+// participantKeys is a types.PrivateKeys which only supports the operations
+// the ceremony needs
+type participantKeys struct {
+	types.PrivateKeys
+	address types.OnChainSigningAddress
+	scalar  [curve25519.ScalarSize]byte
+}
+
+func (k *participantKeys) PublicKeyAddressOnChain() types.OnChainSigningAddress {
+	return k.address
+}
+
+func (k *participantKeys) ConfigDiffieHellman(base *[curve25519.ScalarSize]byte) (*[curve25519.PointSize]byte, error) {
+	p, err := curve25519.X25519(k.scalar[:], base[:])
+	if err != nil {
+		return nil, err
+	}
+	var sharedPoint [curve25519.PointSize]byte
+	copy(sharedPoint[:], p)
+	return &sharedPoint, nil
+}
+
+const testResendInterval = 20 * time.Millisecond
+
+var testNonce = []byte("test nonce")
+
+// setup returns n participants and their keys
+func setup(t *testing.T, n int) ([]Participant, []*participantKeys) {
+	participants := []Participant{}
+	keys := []*participantKeys{}
+	for i := 0; i < n; i++ {
+		k := &participantKeys{address: types.OnChainSigningAddress{byte(i + 1)}}
+		if _, err := cryptorand.Read(k.scalar[:]); err != nil {
+			t.Fatal(err)
+		}
+		pk, err := curve25519.X25519(k.scalar[:], curve25519.Basepoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pkArray types.SharedSecretEncryptionPublicKey
+		copy(pkArray[:], pk)
+		participants = append(participants, Participant{k.address, fmt.Sprintf("peer%d", i), pkArray})
+		keys = append(keys, k)
+	}
+	return participants, keys
+}
+
+type runResult struct {
+	encryptions config.SharedSecretEncryptions
+	err         error
+}
+
+// runHonest runs the ceremony for the participants in oids concurrently, and
+// returns their results in the order of oids
+func runHonest(
+	ctx context.Context,
+	network *memNetwork,
+	participants []Participant,
+	keys []*participantKeys,
+	oids []int,
+) []runResult {
+	results := make([]runResult, len(oids))
+	var wg sync.WaitGroup
+	for j, oid := range oids {
+		wg.Add(1)
+		go func(j, oid int) {
+			defer wg.Done()
+			encryptions, err := Run(
+				ctx,
+				nil,
+				testlogger.Nop{},
+				memEndpointFactory{network, participants[oid].PeerID},
+				testNonce,
+				participants,
+				keys[oid],
+				testResendInterval,
+			)
+			results[j] = runResult{encryptions, err}
+		}(j, oid)
+	}
+	wg.Wait()
+	return results
+}
+
+// byzantineEndpoint returns the endpoint of the last participant, which is
+// driven by the test rather than by Run
+func byzantineEndpoint(t *testing.T, network *memNetwork, participants []Participant) types.BinaryNetworkEndpoint {
+	peerIDs := []string{}
+	for _, p := range participants {
+		peerIDs = append(peerIDs, p.PeerID)
+	}
+	e, err := memEndpointFactory{network, participants[len(participants)-1].PeerID}.MakeEndpoint(
+		CeremonyID(participants, testNonce), peerIDs, nil, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestCeremonyAllHonest(t *testing.T) {
+	const n = 7
+	participants, keys := setup(t, n)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	oids := []int{}
+	for i := 0; i < n; i++ {
+		oids = append(oids, i)
+	}
+	results := runHonest(ctx, newMemNetwork(), participants, keys, oids)
+
+	var sharedSecret *[config.SharedSecretSize]byte
+	for i, result := range results {
+		if result.err != nil {
+			t.Fatalf("participant %d failed: %v", i, result.err)
+		}
+		if !result.encryptions.Equal(results[0].encryptions) {
+			t.Errorf("participant %d output different SharedSecretEncryptions", i)
+		}
+		for j, k := range keys {
+			x, err := result.encryptions.Decrypt(types.OracleID(j), k)
+			if err != nil {
+				t.Fatalf("participant %d could not decrypt the output of participant %d: %v", j, i, err)
+			}
+			if sharedSecret != nil && *x != *sharedSecret {
+				t.Errorf("participant %d decrypted a different shared secret from the output of participant %d", j, i)
+			}
+			sharedSecret = x
+		}
+	}
+}
+
+func TestCeremonyAbortsOnRevealNotMatchingCommitment(t *testing.T) {
+	participants, keys := setup(t, 4)
+	network := newMemNetwork()
+	byzantine := byzantineEndpoint(t, network, participants)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	go func() {
+		id := CeremonyID(participants, testNonce)
+		committed := make([]byte, contributionSize)
+		revealed := make([]byte, contributionSize)
+		revealed[0] = 1
+		ticker := time.NewTicker(testResendInterval)
+		defer ticker.Stop()
+		for {
+			byzantine.Broadcast(serialize(messageCommit{commitment(id, 3, committed)}))
+			byzantine.Broadcast(serialize(messageReveal{revealed}))
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i, result := range runHonest(ctx, network, participants, keys, []int{0, 1, 2}) {
+		if result.err == nil || !strings.Contains(result.err.Error(), "doesn't match its commitment") {
+			t.Errorf("participant %d: expected the ceremony to abort because of the reveal, got %v", i, result.err)
+		}
+	}
+}
+
+func TestCeremonyAbortsOnConflictingCommitments(t *testing.T) {
+	participants, keys := setup(t, 4)
+	network := newMemNetwork()
+	byzantine := byzantineEndpoint(t, network, participants)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	go func() {
+		id := CeremonyID(participants, testNonce)
+		ticker := time.NewTicker(testResendInterval)
+		defer ticker.Stop()
+		for {
+			byzantine.Broadcast(serialize(messageCommit{commitment(id, 3, make([]byte, contributionSize))}))
+			byzantine.Broadcast(serialize(messageCommit{commitment(id, 3, []byte{1})}))
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i, result := range runHonest(ctx, network, participants, keys, []int{0, 1, 2}) {
+		if result.err == nil || !strings.Contains(result.err.Error(), "conflicting commitments") {
+			t.Errorf("participant %d: expected the ceremony to abort because of the commitments, got %v", i, result.err)
+		}
+	}
+}
+
+func TestCeremonyReportsMissingParticipantsOnTimeout(t *testing.T) {
+	for _, commits := range []bool{false, true} {
+		participants, keys := setup(t, 4)
+		network := newMemNetwork()
+		byzantine := byzantineEndpoint(t, network, participants)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*testResendInterval)
+
+		if commits {
+			// The last participant commits but never reveals
+			go func() {
+				id := CeremonyID(participants, testNonce)
+				ticker := time.NewTicker(testResendInterval)
+				defer ticker.Stop()
+				for {
+					byzantine.Broadcast(serialize(messageCommit{commitment(id, 3, make([]byte, contributionSize))}))
+					select {
+					case <-ticker.C:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		expected := "no commitment from participants [3]"
+		if commits {
+			expected = "no contribution from participants [3]"
+		}
+		for i, result := range runHonest(ctx, network, participants, keys, []int{0, 1, 2}) {
+			if result.err == nil || !strings.HasSuffix(result.err.Error(), expected) {
+				t.Errorf("participant %d: expected error ending in %q, got %v", i, expected, result.err)
+			}
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,89 @@
+package ceremony
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"google.golang.org/protobuf/proto"
+)
+
+type message interface {
+	toProtoMessage() *protobuf.CeremonyMessageWrapper
+}
+
+// messageCommit commits its sender to a contribution
+type messageCommit struct {
+	commitment []byte
+}
+
+// messageReveal reveals its sender's contribution
+type messageReveal struct {
+	contribution []byte
+}
+
+// messageDone announces that its sender has computed the shared secret
+type messageDone struct {
+	sharedSecretHash common.Hash
+}
+
+func (m messageCommit) toProtoMessage() *protobuf.CeremonyMessageWrapper {
+	return &protobuf.CeremonyMessageWrapper{
+		Msg: &protobuf.CeremonyMessageWrapper_Commit{&protobuf.CeremonyCommit{
+			Commitment: m.commitment,
+		}},
+	}
+}
+
+func (m messageReveal) toProtoMessage() *protobuf.CeremonyMessageWrapper {
+	return &protobuf.CeremonyMessageWrapper{
+		Msg: &protobuf.CeremonyMessageWrapper_Reveal{&protobuf.CeremonyReveal{
+			Contribution: m.contribution,
+		}},
+	}
+}
+
+func (m messageDone) toProtoMessage() *protobuf.CeremonyMessageWrapper {
+	return &protobuf.CeremonyMessageWrapper{
+		Msg: &protobuf.CeremonyMessageWrapper_Done{&protobuf.CeremonyDone{
+			SharedSecretHash: m.sharedSecretHash[:],
+		}},
+	}
+}
+
+func serialize(m message) []byte {
+	b, err := proto.Marshal(m.toProtoMessage())
+	if err != nil {
+		// assertion
+		panic(err)
+	}
+	return b
+}
+
+func deserialize(b []byte) (message, error) {
+	var wrapper protobuf.CeremonyMessageWrapper
+	if err := proto.Unmarshal(b, &wrapper); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal protobuf")
+	}
+	switch msg := wrapper.Msg.(type) {
+	case *protobuf.CeremonyMessageWrapper_Commit:
+		if len(msg.Commit.Commitment) != common.HashLength {
+			return nil, errors.Errorf("commitment has length %d, expected %d",
+				len(msg.Commit.Commitment), common.HashLength)
+		}
+		return messageCommit{msg.Commit.Commitment}, nil
+	case *protobuf.CeremonyMessageWrapper_Reveal:
+		if len(msg.Reveal.Contribution) != contributionSize {
+			return nil, errors.Errorf("contribution has length %d, expected %d",
+				len(msg.Reveal.Contribution), contributionSize)
+		}
+		return messageReveal{msg.Reveal.Contribution}, nil
+	case *protobuf.CeremonyMessageWrapper_Done:
+		if len(msg.Done.SharedSecretHash) != common.HashLength {
+			return nil, errors.Errorf("shared secret hash has length %d, expected %d",
+				len(msg.Done.SharedSecretHash), common.HashLength)
+		}
+		return messageDone{common.BytesToHash(msg.Done.SharedSecretHash)}, nil
+	default:
+		return nil, errors.Errorf("unknown message type %T", wrapper.Msg)
+	}
+}
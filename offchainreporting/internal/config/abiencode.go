@@ -76,3 +76,123 @@ const setConfigEncodedComponentsABI = `[
     ]
   }
 ]`
+
+// setConfigTaggedExtensionsABI specifies the serialization schema for the
+// list of extensions which follows the setConfigEncodedComponents in configs
+// of EncodedConfigVersionWithTaggedExtensions. The "name" of each component
+// must match the name of the corresponding field in taggedExtension.
+const setConfigTaggedExtensionsABI = `[
+  {
+    "name": "taggedExtensions",
+    "type": "tuple[]",
+    "components": [
+      {
+        "name": "tag",
+        "type": "uint16"
+      },
+      {
+        "name": "critical",
+        "type": "bool"
+      },
+      {
+        "name": "value",
+        "type": "bytes"
+      }
+    ]
+  }
+]`
+
+// heartbeatExtensionABI specifies the serialization schema for the value of
+// an extensionTagHeartbeat extension
+const heartbeatExtensionABI = `[
+  {
+    "name": "period",
+    "type": "int64"
+  },
+  {
+    "name": "offset",
+    "type": "int64"
+  }
+]`
+
+// deviationRuleExtensionABI specifies the serialization schema for the value
+// of an extensionTagDeviationRule extension
+const deviationRuleExtensionABI = `[
+  {
+    "name": "mode",
+    "type": "uint8"
+  },
+  {
+    "name": "upPPB",
+    "type": "uint64"
+  },
+  {
+    "name": "downPPB",
+    "type": "uint64"
+  },
+  {
+    "name": "upAbsolute",
+    "type": "int192"
+  },
+  {
+    "name": "downAbsolute",
+    "type": "int192"
+  }
+]`
+
+// setConfigAuthenticatedSharedSecretABI specifies the serialization schema for
+// configs of EncodedConfigVersionWithAuthenticatedSharedSecret. body is a
+// config of EncodedConfigVersionWithTaggedExtensions whose
+// sharedSecretEncryptions have no encryptions. contractAddress and
+// configCount name the setConfig call the config was made for, which
+// together with the rest of the config determine its ConfigDigest.
+// authenticatedEncryptions holds the AES-GCM encryption of the shared secret
+// to each oracle, followed by its tag.
+const setConfigAuthenticatedSharedSecretABI = `[
+  {
+    "name": "body",
+    "type": "bytes"
+  },
+  {
+    "name": "contractAddress",
+    "type": "address"
+  },
+  {
+    "name": "configCount",
+    "type": "uint64"
+  },
+  {
+    "name": "authenticatedEncryptions",
+    "type": "bytes32[]"
+  }
+]`
+
+// sharedSecretContextABI specifies the serialization schema of the data which
+// authenticated encryptions of the shared secret are bound to. It's the data
+// the ConfigDigest is computed from, except for the encryptions themselves.
+const sharedSecretContextABI = `[
+  {
+    "name": "contractAddress",
+    "type": "address"
+  },
+  {
+    "name": "configCount",
+    "type": "uint64"
+  },
+  {
+    "name": "signers",
+    "type": "address[]"
+  },
+  {
+    "name": "transmitters",
+    "type": "address[]"
+  },
+  {
+    "name": "threshold",
+    "type": "uint8"
+  },
+  {
+    "name": "body",
+    "type": "bytes"
+  }
+]`
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// ConfigDiff describes how a proposed config differs from the live one, and
+// what could go wrong when switching from one to the other
+type ConfigDiff struct {
+	// Parameters lists the protocol parameters which change, in the order of
+	// the fields of PublicConfig
+	Parameters []ParameterChange
+	// Oracles lists the oracles which are added, removed, moved to another
+	// oracle id, or whose identity changes. Oracles are identified by their
+	// OnChainSigningAddress.
+	Oracles []OracleChange
+	// Warnings describes risky aspects of the transition
+	Warnings []string
+}
+
+// ParameterChange describes the change of a single protocol parameter
+type ParameterChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// OracleChange describes how a single oracle is affected by a config change
+type OracleChange struct {
+	OnChainSigningAddress types.OnChainSigningAddress
+	// OldOracleID and NewOracleID are the oracle's ids in the live and the
+	// proposed config, or -1 if it isn't part of that config.
+	OldOracleID int
+	NewOracleID int
+	// ChangedFields names the fields of the oracle's identity which change
+	ChangedFields []string
+}
+
+// Added returns true iff the oracle is only part of the proposed config
+func (c OracleChange) Added() bool {
+	return c.OldOracleID < 0
+}
+
+// Removed returns true iff the oracle is only part of the live config
+func (c OracleChange) Removed() bool {
+	return c.NewOracleID < 0
+}
+
+// Round trips which need to fit into the protocol's timeouts. A round takes
+// the leader's observe-req, the followers' observations, the leader's
+// report-req and the followers' reports, and a round only makes progress once
+// the final report has been broadcast, too.
+const (
+	messageDelaysPerRound         = 4
+	messageDelaysPerProgressRound = messageDelaysPerRound + 1
+	messageDelaysPerReply         = 2
+)
+
+// DiffPublicConfigs compares the proposed config against the live one.
+// expectedLatency is the expected one-way latency of messages between
+// oracles; if it's positive, the proposed timing parameters are checked
+// against it. The ConfigDigests of the configs are ignored.
+func DiffPublicConfigs(live, proposed PublicConfig, expectedLatency time.Duration) ConfigDiff {
+	diff := ConfigDiff{[]ParameterChange{}, []OracleChange{}, []string{}}
+	warn := func(format string, a ...interface{}) {
+		diff.Warnings = append(diff.Warnings, fmt.Sprintf(format, a...))
+	}
+
+	if err := proposed.CheckParameters(); err != nil {
+		warn("oracles would reject the proposed config: %v", err)
+	}
+
+	diff.Parameters = diffParameters(live, proposed)
+	diff.Oracles = diffOracles(live.OracleIdentities, proposed.OracleIdentities)
+
+	if len(diff.Parameters) == 0 && len(diff.Oracles) == 0 {
+		warn("the proposed config has the same parameters and oracles as the live config, so it only rotates the shared secret")
+	}
+
+	removed, added := 0, 0
+	for _, change := range diff.Oracles {
+		if change.Removed() {
+			removed++
+		}
+		if change.Added() {
+			added++
+		}
+	}
+	if removed > live.F {
+		warn("%d oracles are rotated out at once, more than F (%d) of the live config", removed, live.F)
+	}
+	if added > proposed.F {
+		warn("%d oracles are added at once, more than F (%d) of the proposed config, so the "+
+			"protocol stalls if they aren't running when the config changes", added, proposed.F)
+	}
+	if proposed.F < live.F {
+		warn("F decreases from %d to %d, so fewer faulty oracles are tolerated", live.F, proposed.F)
+	}
+
+	if expectedLatency > 0 {
+		for _, check := range timingChecks(expectedLatency) {
+			if check.holds(proposed) {
+				continue
+			}
+			if check.holds(live) {
+				warn("with an expected latency of %v, the proposed config violates %s, which the live config satisfies",
+					expectedLatency, check.description)
+			} else {
+				warn("with an expected latency of %v, the proposed config violates %s, as does the live config",
+					expectedLatency, check.description)
+			}
+		}
+	}
+
+	return diff
+}
+
+func diffParameters(live, proposed PublicConfig) []ParameterChange {
+	changes := []ParameterChange{}
+	for _, p := range []struct {
+		name     string
+		old, new interface{}
+	}{
+		{"DeltaProgress", live.DeltaProgress, proposed.DeltaProgress},
+		{"DeltaResend", live.DeltaResend, proposed.DeltaResend},
+		{"DeltaRound", live.DeltaRound, proposed.DeltaRound},
+		{"DeltaGrace", live.DeltaGrace, proposed.DeltaGrace},
+		{"DeltaC", live.DeltaC, proposed.DeltaC},
+		{"Heartbeat", live.Heartbeat, proposed.Heartbeat},
+		{"AlphaPPB", live.AlphaPPB, proposed.AlphaPPB},
+		{"DeviationRule", live.DeviationRule.String(), proposed.DeviationRule.String()},
+		{"ReportReqCrossChecks", live.ReportReqCrossChecks, proposed.ReportReqCrossChecks},
+		{"DeltaStage", live.DeltaStage, proposed.DeltaStage},
+		{"RMax", live.RMax, proposed.RMax},
+		{"S", live.S, proposed.S},
+		{"N", live.N(), proposed.N()},
+		{"F", live.F, proposed.F},
+	} {
+		if !reflect.DeepEqual(p.old, p.new) {
+			changes = append(changes, ParameterChange{
+				p.name,
+				fmt.Sprintf("%+v", p.old),
+				fmt.Sprintf("%+v", p.new),
+			})
+		}
+	}
+	return changes
+}
+
+func diffOracles(live, proposed []OracleIdentity) []OracleChange {
+	changes := []OracleChange{}
+	proposedIDs := map[types.OnChainSigningAddress]int{}
+	for i, identity := range proposed {
+		proposedIDs[identity.OnChainSigningAddress] = i
+	}
+	liveIDs := map[types.OnChainSigningAddress]int{}
+	for i, identity := range live {
+		liveIDs[identity.OnChainSigningAddress] = i
+		j, ok := proposedIDs[identity.OnChainSigningAddress]
+		if !ok {
+			changes = append(changes, OracleChange{identity.OnChainSigningAddress, i, -1, nil})
+			continue
+		}
+		changed := changedIdentityFields(identity, proposed[j])
+		if i != j || len(changed) != 0 {
+			changes = append(changes, OracleChange{identity.OnChainSigningAddress, i, j, changed})
+		}
+	}
+	for j, identity := range proposed {
+		if _, ok := liveIDs[identity.OnChainSigningAddress]; !ok {
+			changes = append(changes, OracleChange{identity.OnChainSigningAddress, -1, j, nil})
+		}
+	}
+	return changes
+}
+
+func changedIdentityFields(old, new OracleIdentity) []string {
+	changed := []string{}
+	if old.PeerID != new.PeerID {
+		changed = append(changed, "PeerID")
+	}
+	if !bytes.Equal(old.OffchainPublicKey, new.OffchainPublicKey) {
+		changed = append(changed, "OffchainPublicKey")
+	}
+	if old.TransmitAddress != new.TransmitAddress {
+		changed = append(changed, "TransmitAddress")
+	}
+	return changed
+}
+
+type timingCheck struct {
+	description string
+	holds       func(c PublicConfig) bool
+}
+
+// timingChecks returns the relationships between timing parameters which must
+// hold for the protocol to make progress if messages take latency to arrive
+func timingChecks(latency time.Duration) []timingCheck {
+	return []timingCheck{
+		{
+			fmt.Sprintf("DeltaGrace + %d*latency < DeltaRound", messageDelaysPerRound),
+			func(c PublicConfig) bool {
+				return c.DeltaGrace+messageDelaysPerRound*latency < c.DeltaRound
+			},
+		},
+		{
+			fmt.Sprintf("DeltaGrace + %d*latency < DeltaProgress", messageDelaysPerProgressRound),
+			func(c PublicConfig) bool {
+				return c.DeltaGrace+messageDelaysPerProgressRound*latency < c.DeltaProgress
+			},
+		},
+		{
+			fmt.Sprintf("%d*latency < DeltaResend", messageDelaysPerReply),
+			func(c PublicConfig) bool {
+				return messageDelaysPerReply*latency < c.DeltaResend
+			},
+		},
+	}
+}
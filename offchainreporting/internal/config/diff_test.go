@@ -0,0 +1,256 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func newIdentity(i byte) OracleIdentity {
+	return OracleIdentity{
+		string([]byte{'z', i}),
+		types.OffchainPublicKey{i},
+		types.OnChainSigningAddress{i},
+		common.Address{i},
+	}
+}
+
+// checkWarnings fails unless there are as many warnings as expected, and each
+// of expected is a substring of exactly one of them
+func checkWarnings(t *testing.T, name string, warnings []string, expected []string) {
+	t.Helper()
+	if len(warnings) != len(expected) {
+		t.Errorf("%s: got warnings %q, expected %d matching %q", name, warnings, len(expected), expected)
+		return
+	}
+	for _, e := range expected {
+		matches := 0
+		for _, w := range warnings {
+			if strings.Contains(w, e) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			t.Errorf("%s: got warnings %q, expected one containing %q", name, warnings, e)
+		}
+	}
+}
+
+func TestDiffPublicConfigs(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(c *PublicConfig)
+		expectedLatency time.Duration
+		parameters      []string
+		oracles         int
+		warnings        []string
+	}{
+		{
+			"unchanged",
+			func(c *PublicConfig) {},
+			0,
+			nil,
+			0,
+			[]string{"only rotates the shared secret"},
+		},
+		{
+			"rotate out F oracles",
+			func(c *PublicConfig) {
+				c.OracleIdentities[3] = newIdentity(9)
+			},
+			0,
+			nil,
+			2,
+			nil,
+		},
+		{
+			"rotate out more than F oracles",
+			func(c *PublicConfig) {
+				c.OracleIdentities[2] = newIdentity(8)
+				c.OracleIdentities[3] = newIdentity(9)
+			},
+			0,
+			nil,
+			4,
+			[]string{
+				"2 oracles are rotated out at once, more than F (1)",
+				"2 oracles are added at once, more than F (1)",
+			},
+		},
+		{
+			"move oracle to another id",
+			func(c *PublicConfig) {
+				ids := c.OracleIdentities
+				ids[0], ids[1] = ids[1], ids[0]
+			},
+			0,
+			nil,
+			2,
+			nil,
+		},
+		{
+			"decrease F",
+			func(c *PublicConfig) { c.F = 0 },
+			0,
+			[]string{"F"},
+			0,
+			[]string{"F decreases from 1 to 0"},
+		},
+		{
+			"increase F beyond N/3",
+			func(c *PublicConfig) { c.F = 2 },
+			0,
+			[]string{"F"},
+			0,
+			[]string{"oracles would reject the proposed config: F (2) must be non-negative and less than N/3"},
+		},
+		{
+			"change S",
+			func(c *PublicConfig) { c.S = []int{2, 2} },
+			0,
+			[]string{"S"},
+			0,
+			nil,
+		},
+		{
+			"DeltaGrace not less than DeltaRound",
+			func(c *PublicConfig) { c.DeltaGrace = c.DeltaRound },
+			0,
+			[]string{"DeltaGrace"},
+			0,
+			[]string{"oracles would reject the proposed config: DeltaGrace (5s) must be less than DeltaRound (5s)"},
+		},
+		{
+			"DeltaRound not less than DeltaProgress",
+			func(c *PublicConfig) { c.DeltaRound = c.DeltaProgress },
+			0,
+			[]string{"DeltaRound"},
+			0,
+			[]string{"oracles would reject the proposed config: DeltaRound (10s) must be less than DeltaProgress (10s)"},
+		},
+		{
+			"DeltaRound too short for latency",
+			func(c *PublicConfig) { c.DeltaRound = 2 * time.Second },
+			500 * time.Millisecond,
+			[]string{"DeltaRound"},
+			0,
+			[]string{"violates DeltaGrace + 4*latency < DeltaRound, which the live config satisfies"},
+		},
+		{
+			"live config too slow as well",
+			func(c *PublicConfig) {},
+			2 * time.Second,
+			nil,
+			0,
+			[]string{
+				"only rotates the shared secret",
+				"violates DeltaGrace + 4*latency < DeltaRound, as does the live config",
+				"violates DeltaGrace + 5*latency < DeltaProgress, as does the live config",
+			},
+		},
+	}
+	for _, test := range tests {
+		live := testPublicConfig()
+		proposed := testPublicConfig()
+		test.modify(&proposed)
+		diff := DiffPublicConfigs(live, proposed, test.expectedLatency)
+
+		parameters := []string{}
+		for _, p := range diff.Parameters {
+			parameters = append(parameters, p.Name)
+		}
+		if strings.Join(parameters, ",") != strings.Join(test.parameters, ",") {
+			t.Errorf("%s: changed parameters %v, expected %v", test.name, parameters, test.parameters)
+		}
+		if len(diff.Oracles) != test.oracles {
+			t.Errorf("%s: got %d oracle changes %+v, expected %d", test.name, len(diff.Oracles), diff.Oracles, test.oracles)
+		}
+		checkWarnings(t, test.name, diff.Warnings, test.warnings)
+	}
+}
+
+func TestDiffPublicConfigsOracleChanges(t *testing.T) {
+	live := testPublicConfig()
+	proposed := testPublicConfig()
+	proposed.OracleIdentities[0], proposed.OracleIdentities[1] = proposed.OracleIdentities[1], proposed.OracleIdentities[0]
+	proposed.OracleIdentities[2].PeerID = "moved"
+	proposed.OracleIdentities[3] = newIdentity(9)
+
+	expected := map[types.OnChainSigningAddress][2]int{
+		live.OracleIdentities[0].OnChainSigningAddress: {0, 1},
+		live.OracleIdentities[1].OnChainSigningAddress: {1, 0},
+		live.OracleIdentities[2].OnChainSigningAddress: {2, 2},
+		live.OracleIdentities[3].OnChainSigningAddress: {3, -1},
+		newIdentity(9).OnChainSigningAddress:           {-1, 3},
+	}
+	diff := DiffPublicConfigs(live, proposed, 0)
+	if len(diff.Oracles) != len(expected) {
+		t.Fatalf("got oracle changes %+v, expected %d", diff.Oracles, len(expected))
+	}
+	for _, change := range diff.Oracles {
+		ids, ok := expected[change.OnChainSigningAddress]
+		if !ok || ids != [2]int{change.OldOracleID, change.NewOracleID} {
+			t.Errorf("unexpected oracle change %+v", change)
+		}
+	}
+	for _, change := range diff.Oracles {
+		if change.OldOracleID == 2 && strings.Join(change.ChangedFields, ",") != "PeerID" {
+			t.Errorf("expected only oracle 2's PeerID to change, got %v", change.ChangedFields)
+		}
+	}
+}
+
+func TestSuspiciousParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(c *PublicConfig)
+		warnings []string
+	}{
+		{
+			"sensible",
+			func(c *PublicConfig) {},
+			nil,
+		},
+		{
+			"F below what N tolerates",
+			func(c *PublicConfig) {
+				c.OracleIdentities = append(c.OracleIdentities, newIdentity(8), newIdentity(9), newIdentity(10))
+				c.S = append(c.S, 1, 1, 1)
+			},
+			[]string{"F (1) is less than the 2 faulty oracles 7 oracles could tolerate"},
+		},
+		{
+			"zero F",
+			func(c *PublicConfig) { c.F = 0 },
+			[]string{"F is zero"},
+		},
+		{
+			"too few transmitters in S",
+			func(c *PublicConfig) { c.S = []int{1} },
+			[]string{"S ([1]) schedules only 1 transmitters, which could all be faulty"},
+		},
+		{
+			"no transmitters in the first stage of S",
+			func(c *PublicConfig) { c.S = []int{0, 4} },
+			[]string{"S ([0 4]) schedules no transmitters in the first stage"},
+		},
+		{
+			"DeltaGrace close to DeltaRound",
+			func(c *PublicConfig) { c.DeltaGrace = 3 * time.Second },
+			[]string{"DeltaGrace (3s) is more than half of DeltaRound (5s)"},
+		},
+		{
+			"DeltaRound close to DeltaProgress",
+			func(c *PublicConfig) { c.DeltaRound = 6 * time.Second },
+			[]string{"DeltaProgress (10s) is less than twice DeltaRound (6s)"},
+		},
+	}
+	for _, test := range tests {
+		c := testPublicConfig()
+		test.modify(&c)
+		checkWarnings(t, test.name, c.SuspiciousParameters(), test.warnings)
+	}
+}
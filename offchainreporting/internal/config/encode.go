@@ -12,8 +12,51 @@ import (
 	"github.com/SeerLink/libocr/offchainreporting/types"
 )
 
+// EncodedConfigVersion is the version of configs which consist only of a
+// setConfigEncodedComponents
 const EncodedConfigVersion = 1
 
+// EncodedConfigVersionWithTaggedExtensions is the version of configs which
+// consist of a setConfigEncodedComponents followed by a list of
+// taggedExtensions. New config fields should be added as extensions of this
+// version, rather than as new versions.
+const EncodedConfigVersionWithTaggedExtensions = 2
+
+// EncodedConfigVersionWithAuthenticatedSharedSecret is the version of configs
+// which consist of a config of EncodedConfigVersionWithTaggedExtensions without
+// the encryptions of the shared secret, followed by the contract address and
+// config count it was made for, and authenticated encryptions of the shared
+// secret which are bound to all of these. See
+// setConfigAuthenticatedSharedSecretABI.
+const EncodedConfigVersionWithAuthenticatedSharedSecret = 3
+
+// configDecoder decodes configs of a particular EncodedConfigVersion. Configs
+// without extensions get the extensions implied by their
+// setConfigEncodedComponents.
+type configDecoder func(b []byte) (setConfigEncodedComponents, setConfigEncodedExtensions, error)
+
+// configDecoders holds the decoders of all supported EncodedConfigVersions.
+// Decoders for old versions must be kept around for as long as contracts may
+// hold configs of those versions.
+var configDecoders = map[uint64]configDecoder{
+	EncodedConfigVersion: func(b []byte) (setConfigEncodedComponents, setConfigEncodedExtensions, error) {
+		o, err := decodeContractSetConfigEncodedComponents(b)
+		if err != nil {
+			return setConfigEncodedComponents{}, setConfigEncodedExtensions{}, err
+		}
+		return o, defaultExtensions(o), nil
+	},
+	EncodedConfigVersionWithTaggedExtensions:          decodeContractSetConfigEncodedComponentsWithTaggedExtensions,
+	EncodedConfigVersionWithAuthenticatedSharedSecret: decodeContractSetConfigEncodedComponentsWithAuthenticatedSharedSecret,
+}
+
+// IsSupportedEncodedConfigVersion returns true iff configs with the given
+// EncodedConfigVersion can be decoded
+func IsSupportedEncodedConfigVersion(version uint64) bool {
+	_, ok := configDecoders[version]
+	return ok
+}
+
 // setConfigEncodedComponents contains the contents of the oracle Config objects
 // which need to be serialized
 type setConfigEncodedComponents struct {
@@ -60,7 +103,7 @@ type sseSerializationTypes struct {
 
 // encoding is the ABI schema used to encode a setConfigEncodedComponents, taken
 // from setConfigEncodedComponentsABI in ./abiencode.go (in this package directory.)
-var encoding = getEncoding()
+var encoding = getEncoding(setConfigEncodedComponentsABI)
 
 // Serialized configs must be no larger than this (arbitrary bound, to prevent
 // resource exhaustion attacks)
@@ -78,6 +121,20 @@ func (o setConfigEncodedComponents) encode() []byte {
 	return rv
 }
 
+// decodeContractConfigEncoded decodes the encoded config b according to
+// version, using the matching decoder from configDecoders
+func decodeContractConfigEncoded(
+	version uint64,
+	b []byte,
+) (setConfigEncodedComponents, setConfigEncodedExtensions, error) {
+	decode, ok := configDecoders[version]
+	if !ok {
+		return setConfigEncodedComponents{}, setConfigEncodedExtensions{},
+			errors.Errorf("unknown EncodedConfigVersion %d", version)
+	}
+	return decode(b)
+}
+
 func decodeContractSetConfigEncodedComponents(
 	b []byte,
 ) (o setConfigEncodedComponents, err error) {
@@ -152,6 +209,10 @@ func (or setConfigSerializationTypes) golangRepresentation() setConfigEncodedCom
 }
 
 func (e SharedSecretEncryptions) serializationRepresentation() sseSerializationTypes {
+	if e.IsAuthenticated() {
+		// assertion
+		panic("authenticated encryptions are not part of the setConfigEncodedComponents")
+	}
 	encs := make([][SharedSecretSize]byte, len(e.Encryptions))
 	for i, enc := range e.Encryptions {
 		encs[i] = enc
@@ -172,16 +233,17 @@ func (er sseSerializationTypes) golangRepresentation() SharedSecretEncryptions {
 		[32]byte(er.DiffieHellmanPoint),
 		er.SharedSecretHash,
 		encs,
+		nil,
 	}
 }
 
-func getEncoding() abi.Arguments {
+func getEncoding(schema string) abi.Arguments {
 	// Trick used in abi's TestPack, to parse a list of arguments: make a JSON
 	// representation of a method which has the target list as the inputs, then
 	// pull the parsed argument list out of that method.
 	aBI, err := abi.JSON(strings.NewReader(fmt.Sprintf(
 		`[{ "name" : "method", "type": "function", "inputs": %s}]`,
-		setConfigEncodedComponentsABI)))
+		schema)))
 	if err != nil {
 		panic(err)
 	}
@@ -224,7 +286,7 @@ func init() { // check that abiencode fields match those of config structs
 		panic("expecting sharedSecretEncryptions in last position, got " + essName)
 	}
 	ess := components[len(components)-1]
-	checkTupEntriesMatchStruct(*ess, SharedSecretEncryptions{})
+	checkTupEntriesMatchStruct(*ess, sseSerializationTypes{})
 }
 
 func checkFieldNamesMatch(s, t interface{}) {
@@ -244,5 +306,4 @@ func checkFieldNamesMatch(s, t interface{}) {
 
 func init() { // Check that serialization fields match those of target structs
 	checkFieldNamesMatch(setConfigEncodedComponents{}, setConfigSerializationTypes{})
-	checkFieldNamesMatch(SharedSecretEncryptions{}, sseSerializationTypes{})
 }
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+var encodingWithAuthenticatedSharedSecret = getEncoding(setConfigAuthenticatedSharedSecretABI)
+
+// configWithAuthenticatedSharedSecret is the golang representation of a config
+// of EncodedConfigVersionWithAuthenticatedSharedSecret, see
+// setConfigAuthenticatedSharedSecretABI
+type configWithAuthenticatedSharedSecret struct {
+	body            []byte
+	contractAddress common.Address
+	configCount     uint64
+	encryptions     []authenticatedEncryptedSharedSecret
+}
+
+// encode returns the serialization of c
+func (c configWithAuthenticatedSharedSecret) encode() []byte {
+	encs := make([][32]byte, len(c.encryptions))
+	for i, enc := range c.encryptions {
+		encs[i] = enc
+	}
+	rv := mustPack(encodingWithAuthenticatedSharedSecret, c.body, c.contractAddress, c.configCount, encs)
+	if len(rv) > configSizeBound {
+		panic("config serialization too large")
+	}
+	return rv
+}
+
+// splitContractSetConfigEncodedWithAuthenticatedSharedSecret returns the parts
+// of a config of EncodedConfigVersionWithAuthenticatedSharedSecret
+func splitContractSetConfigEncodedWithAuthenticatedSharedSecret(
+	b []byte,
+) (c configWithAuthenticatedSharedSecret, err error) {
+	if len(b) > configSizeBound {
+		return c, errors.Errorf(
+			"attempt to deserialize a too-long config (%d bytes)", len(b),
+		)
+	}
+	vals, err := encodingWithAuthenticatedSharedSecret.Unpack(b)
+	if err != nil {
+		return c, errors.Wrapf(err, "could not deserialize setConfig binary blob")
+	}
+	c.body = vals[0].([]byte)
+	c.contractAddress = vals[1].(common.Address)
+	c.configCount = vals[2].(uint64)
+	for _, enc := range vals[3].([][32]byte) {
+		c.encryptions = append(c.encryptions, authenticatedEncryptedSharedSecret(enc))
+	}
+	return c, nil
+}
+
+func decodeContractSetConfigEncodedComponentsWithAuthenticatedSharedSecret(
+	b []byte,
+) (o setConfigEncodedComponents, x setConfigEncodedExtensions, err error) {
+	c, err := splitContractSetConfigEncodedWithAuthenticatedSharedSecret(b)
+	if err != nil {
+		return o, x, err
+	}
+	o, x, err = decodeContractSetConfigEncodedComponentsWithTaggedExtensions(c.body)
+	if err != nil {
+		return o, x, err
+	}
+	if len(o.SharedSecretEncryptions.Encryptions) != 0 {
+		return o, x, errors.Errorf(
+			"config with authenticated shared secret encryptions must not have other encryptions, got %d",
+			len(o.SharedSecretEncryptions.Encryptions))
+	}
+	if len(c.encryptions) == 0 {
+		return o, x, errors.Errorf("config has no authenticated shared secret encryptions")
+	}
+	o.SharedSecretEncryptions.AuthenticatedEncryptions = c.encryptions
+	return o, x, nil
+}
+
+// ContractAddressAndConfigCount returns the contract address and config count
+// of the setConfig call a config of
+// EncodedConfigVersionWithAuthenticatedSharedSecret was made for. It fails if
+// they don't match the ConfigDigest of change, i.e. if the config was set on
+// another contract, or by another setConfig call.
+func ContractAddressAndConfigCount(change types.ContractConfig) (common.Address, uint64, error) {
+	if change.EncodedConfigVersion != EncodedConfigVersionWithAuthenticatedSharedSecret {
+		return common.Address{}, 0, errors.Errorf(
+			"configs of EncodedConfigVersion %d have no authenticated shared secret encryptions",
+			change.EncodedConfigVersion)
+	}
+	c, err := splitContractSetConfigEncodedWithAuthenticatedSharedSecret(change.Encoded)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	configDigest := ConfigDigest(
+		c.contractAddress,
+		c.configCount,
+		change.Signers,
+		change.Transmitters,
+		change.Threshold,
+		change.EncodedConfigVersion,
+		change.Encoded,
+	)
+	if configDigest != change.ConfigDigest {
+		return common.Address{}, 0, errors.Errorf(
+			"config was made for config count %d of contract 0x%x, which would have ConfigDigest %v, not %v",
+			c.configCount, c.contractAddress, configDigest, change.ConfigDigest)
+	}
+	return c.contractAddress, c.configCount, nil
+}
+
+// sharedSecretContextFromContractConfig returns the context the authenticated
+// encryptions of change's shared secret must be bound to
+func sharedSecretContextFromContractConfig(change types.ContractConfig) (common.Hash, error) {
+	contractAddress, configCount, err := ContractAddressAndConfigCount(change)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c, err := splitContractSetConfigEncodedWithAuthenticatedSharedSecret(change.Encoded)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return sharedSecretContext(
+		contractAddress,
+		configCount,
+		change.Signers,
+		change.Transmitters,
+		change.Threshold,
+		c.body,
+	), nil
+}
+
+func init() { // check that abiencode fields match the authenticated encryptions
+	if encodingWithAuthenticatedSharedSecret[3].Type.Elem.Size !=
+		len(authenticatedEncryptedSharedSecret{}) {
+		panic("authenticated shared secret encryptions must fill a bytes32")
+	}
+}
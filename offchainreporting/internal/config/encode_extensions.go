@@ -0,0 +1,65 @@
+package config
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+)
+
+// setConfigEncodedExtensions contains the config fields which cannot be
+// represented in a setConfigEncodedComponents. They are serialized as
+// taggedExtensions in configs of EncodedConfigVersionWithTaggedExtensions.
+type setConfigEncodedExtensions struct {
+	HeartbeatPeriod       time.Duration
+	HeartbeatOffset       time.Duration
+	DeviationMode         observation.DeviationMode
+	DeviationUpPPB        uint64
+	DeviationDownPPB      uint64
+	DeviationUpAbsolute   *big.Int
+	DeviationDownAbsolute *big.Int
+	ReportReqCrossChecks  bool
+}
+
+// defaultExtensions returns the extensions implied by a config which doesn't
+// carry any, i.e. one of EncodedConfigVersion.
+func defaultExtensions(o setConfigEncodedComponents) setConfigEncodedExtensions {
+	return makeExtensions(HeartbeatSchedule{}, observation.RelativeDeviationRule(o.AlphaPPB), false)
+}
+
+// needsExtensions returns true iff c cannot be represented without extensions
+func needsExtensions(c PublicConfig) bool {
+	return c.Heartbeat.Enabled() ||
+		!(c.DeviationRule.IsRelativeDeviationRule() && c.DeviationRule.UpPPB == c.AlphaPPB) ||
+		c.ReportReqCrossChecks
+}
+
+func makeExtensions(h HeartbeatSchedule, r observation.DeviationRule, reportReqCrossChecks bool) setConfigEncodedExtensions {
+	return setConfigEncodedExtensions{
+		h.Period,
+		h.Offset,
+		r.Mode,
+		r.UpPPB,
+		r.DownPPB,
+		r.UpAbsolute,
+		r.DownAbsolute,
+		reportReqCrossChecks,
+	}
+}
+
+func (x setConfigEncodedExtensions) heartbeatSchedule() HeartbeatSchedule {
+	return HeartbeatSchedule{
+		x.HeartbeatPeriod,
+		x.HeartbeatOffset,
+	}
+}
+
+func (x setConfigEncodedExtensions) deviationRule() observation.DeviationRule {
+	return observation.DeviationRule{
+		x.DeviationMode,
+		x.DeviationUpPPB,
+		x.DeviationDownPPB,
+		x.DeviationUpAbsolute,
+		x.DeviationDownAbsolute,
+	}
+}
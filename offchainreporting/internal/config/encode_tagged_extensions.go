@@ -0,0 +1,205 @@
+package config
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+)
+
+// taggedExtension is a single extension of a config of
+// EncodedConfigVersionWithTaggedExtensions. Value is the serialization of the
+// extension, whose format is determined by Tag.
+//
+// Oracles ignore extensions with tags they don't know, unless Critical is set,
+// in which case they reject the config. Extensions which change how oracles
+// behave must be critical, so that oracles which don't understand them don't
+// run the protocol with different parameters than everybody else. Extensions
+// which are merely informational, e.g. a feed's description, should not be
+// critical, so that they don't lock out older oracles.
+type taggedExtension struct {
+	Tag      uint16
+	Critical bool
+	Value    []byte
+}
+
+type extensionTag uint16
+
+// Tags of extensions known to this version of the code. Tags must never be
+// reused for extensions with another meaning or format.
+const (
+	_                                extensionTag = iota
+	extensionTagHeartbeat                         // HeartbeatSchedule
+	extensionTagDeviationRule                     // observation.DeviationRule
+	extensionTagReportReqCrossChecks              // PublicConfig.ReportReqCrossChecks, with an empty value
+)
+
+// extensionCodec translates between a tagged extension and the fields of
+// setConfigEncodedExtensions it carries
+type extensionCodec struct {
+	// encode returns the serialization of the extension, and false if the
+	// extension isn't needed because x has the defaults implied by o
+	encode func(o setConfigEncodedComponents, x setConfigEncodedExtensions) ([]byte, bool)
+	// decode sets the fields of x carried by the serialized extension value
+	decode func(value []byte, x *setConfigEncodedExtensions) error
+}
+
+var (
+	encodingWithTaggedExtensions = abi.Arguments{
+		encoding[0],
+		getEncoding(setConfigTaggedExtensionsABI)[0],
+	}
+	heartbeatExtensionEncoding     = getEncoding(heartbeatExtensionABI)
+	deviationRuleExtensionEncoding = getEncoding(deviationRuleExtensionABI)
+)
+
+// extensionCodecs holds the extensions this version of the code understands.
+// To add a field to the config, add an extension here, rather than a new
+// EncodedConfigVersion.
+var extensionCodecs = map[extensionTag]extensionCodec{
+	extensionTagHeartbeat: {
+		func(_ setConfigEncodedComponents, x setConfigEncodedExtensions) ([]byte, bool) {
+			if !x.heartbeatSchedule().Enabled() {
+				return nil, false
+			}
+			return mustPack(heartbeatExtensionEncoding,
+				int64(x.HeartbeatPeriod),
+				int64(x.HeartbeatOffset),
+			), true
+		},
+		func(value []byte, x *setConfigEncodedExtensions) error {
+			vals, err := heartbeatExtensionEncoding.Unpack(value)
+			if err != nil {
+				return err
+			}
+			x.HeartbeatPeriod = time.Duration(vals[0].(int64))
+			x.HeartbeatOffset = time.Duration(vals[1].(int64))
+			return nil
+		},
+	},
+	extensionTagDeviationRule: {
+		func(o setConfigEncodedComponents, x setConfigEncodedExtensions) ([]byte, bool) {
+			r := x.deviationRule()
+			if r.IsRelativeDeviationRule() && r.UpPPB == o.AlphaPPB {
+				return nil, false
+			}
+			nonNil := func(v *big.Int) *big.Int {
+				if v == nil {
+					return big.NewInt(0)
+				}
+				return v
+			}
+			return mustPack(deviationRuleExtensionEncoding,
+				uint8(r.Mode),
+				r.UpPPB,
+				r.DownPPB,
+				nonNil(r.UpAbsolute),
+				nonNil(r.DownAbsolute),
+			), true
+		},
+		func(value []byte, x *setConfigEncodedExtensions) error {
+			vals, err := deviationRuleExtensionEncoding.Unpack(value)
+			if err != nil {
+				return err
+			}
+			x.DeviationMode = observation.DeviationMode(vals[0].(uint8))
+			x.DeviationUpPPB = vals[1].(uint64)
+			x.DeviationDownPPB = vals[2].(uint64)
+			x.DeviationUpAbsolute = vals[3].(*big.Int)
+			x.DeviationDownAbsolute = vals[4].(*big.Int)
+			return nil
+		},
+	},
+	extensionTagReportReqCrossChecks: {
+		func(_ setConfigEncodedComponents, x setConfigEncodedExtensions) ([]byte, bool) {
+			return []byte{}, x.ReportReqCrossChecks
+		},
+		func(value []byte, x *setConfigEncodedExtensions) error {
+			if len(value) != 0 {
+				return errors.Errorf("expected empty value, got %d bytes", len(value))
+			}
+			x.ReportReqCrossChecks = true
+			return nil
+		},
+	},
+}
+
+// encodeWithTaggedExtensions returns a binary serialization of o followed by
+// the extensions needed to represent x, in order of their tags. All of them
+// are critical, since all known extensions affect the protocol.
+func encodeWithTaggedExtensions(o setConfigEncodedComponents, x setConfigEncodedExtensions) []byte {
+	tags := []extensionTag{}
+	for tag := range extensionCodecs {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+
+	extensions := []taggedExtension{}
+	for _, tag := range tags {
+		value, ok := extensionCodecs[tag].encode(o, x)
+		if !ok {
+			continue
+		}
+		extensions = append(extensions, taggedExtension{uint16(tag), true, value})
+	}
+
+	rv := mustPack(encodingWithTaggedExtensions, o.serializationRepresentation(), extensions)
+	if len(rv) > configSizeBound {
+		panic("config serialization too large")
+	}
+	return rv
+}
+
+func decodeContractSetConfigEncodedComponentsWithTaggedExtensions(
+	b []byte,
+) (o setConfigEncodedComponents, x setConfigEncodedExtensions, err error) {
+	if len(b) > configSizeBound {
+		return o, x, errors.Errorf(
+			"attempt to deserialize a too-long config (%d bytes)", len(b),
+		)
+	}
+	var vals []interface{}
+	if vals, err = encodingWithTaggedExtensions.Unpack(b); err != nil {
+		return o, x, errors.Wrapf(err, "could not deserialize setConfig binary blob")
+	}
+	setConfig := abi.ConvertType(vals[0], &setConfigSerializationTypes{}).(*setConfigSerializationTypes)
+	extensions := *abi.ConvertType(vals[1], &[]taggedExtension{}).(*[]taggedExtension)
+
+	o = setConfig.golangRepresentation()
+	x = defaultExtensions(o)
+	seen := map[uint16]bool{}
+	for _, extension := range extensions {
+		if seen[extension.Tag] {
+			return o, x, errors.Errorf("config has more than one extension with tag %d", extension.Tag)
+		}
+		seen[extension.Tag] = true
+		codec, ok := extensionCodecs[extensionTag(extension.Tag)]
+		if !ok {
+			if extension.Critical {
+				return o, x, errors.Errorf(
+					"config has critical extension with unknown tag %d", extension.Tag)
+			}
+			continue
+		}
+		if err := codec.decode(extension.Value, &x); err != nil {
+			return o, x, errors.Wrapf(err, "could not deserialize extension with tag %d", extension.Tag)
+		}
+	}
+	return o, x, nil
+}
+
+func mustPack(args abi.Arguments, vals ...interface{}) []byte {
+	rv, err := args.Pack(vals...)
+	if err != nil {
+		panic(err)
+	}
+	return rv
+}
+
+func init() { // check that abiencode fields match those of taggedExtension
+	elem := encodingWithTaggedExtensions[1].Type.Elem
+	checkTupEntriesMatchStruct(*elem, taggedExtension{})
+}
@@ -0,0 +1,366 @@
+package config
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+func testComponents() setConfigEncodedComponents {
+	return setConfigEncodedComponents{
+		10 * time.Second,
+		10 * time.Second,
+		5 * time.Second,
+		time.Second,
+		time.Minute,
+		10000000,
+		5 * time.Second,
+		10,
+		[]int{1, 1, 1, 1},
+		[]types.OffchainPublicKey{make([]byte, 32), make([]byte, 32), make([]byte, 32), make([]byte, 32)},
+		[]string{"a", "b", "c", "d"},
+		SharedSecretEncryptions{[32]byte{1}, [32]byte{2}, make([]encryptedSharedSecret, 4), nil},
+	}
+}
+
+func TestEncodedConfigVersions(t *testing.T) {
+	for version, supported := range map[uint64]bool{
+		0:                                        false,
+		EncodedConfigVersion:                     true,
+		EncodedConfigVersionWithTaggedExtensions: true,
+		EncodedConfigVersionWithAuthenticatedSharedSecret: true,
+		4: false,
+	} {
+		if IsSupportedEncodedConfigVersion(version) != supported {
+			t.Errorf("IsSupportedEncodedConfigVersion(%d) = %v, expected %v",
+				version, !supported, supported)
+		}
+	}
+}
+
+func TestTaggedExtensionsRoundTrip(t *testing.T) {
+	o := testComponents()
+	for _, x := range []setConfigEncodedExtensions{
+		defaultExtensions(o),
+		makeExtensions(HeartbeatSchedule{time.Hour, -time.Minute}, observation.RelativeDeviationRule(o.AlphaPPB), false),
+		makeExtensions(HeartbeatSchedule{}, observation.DeviationRule{
+			observation.DeviationModeRelativeOrAbsolute, 1, 2, big.NewInt(3), big.NewInt(4),
+		}, false),
+		makeExtensions(HeartbeatSchedule{}, observation.RelativeDeviationRule(o.AlphaPPB), true),
+	} {
+		b := encodeWithTaggedExtensions(o, x)
+		o2, x2, err := decodeContractConfigEncoded(EncodedConfigVersionWithTaggedExtensions, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !o2.SharedSecretEncryptions.Equal(o.SharedSecretEncryptions) || o2.AlphaPPB != o.AlphaPPB {
+			t.Errorf("components changed in round trip")
+		}
+		if x2.heartbeatSchedule() != x.heartbeatSchedule() {
+			t.Errorf("heartbeat %v became %v", x.heartbeatSchedule(), x2.heartbeatSchedule())
+		}
+		if x2.deviationRule().String() != x.deviationRule().String() {
+			t.Errorf("deviation rule %v became %v", x.deviationRule(), x2.deviationRule())
+		}
+		if x2.ReportReqCrossChecks != x.ReportReqCrossChecks {
+			t.Errorf("report-req cross-checks %v became %v", x.ReportReqCrossChecks, x2.ReportReqCrossChecks)
+		}
+	}
+}
+
+func TestTaggedExtensionsReportReqCrossChecksValue(t *testing.T) {
+	o := testComponents()
+	b := mustPack(encodingWithTaggedExtensions, o.serializationRepresentation(),
+		[]taggedExtension{{uint16(extensionTagReportReqCrossChecks), true, []byte{1}}})
+	if _, _, err := decodeContractConfigEncoded(EncodedConfigVersionWithTaggedExtensions, b); err == nil {
+		t.Errorf("report-req cross-checks extension with a value must be rejected")
+	}
+}
+
+func TestTaggedExtensionsUnknownTags(t *testing.T) {
+	o := testComponents()
+	for _, critical := range []bool{false, true} {
+		b := mustPack(encodingWithTaggedExtensions, o.serializationRepresentation(),
+			[]taggedExtension{{0xffff, critical, []byte{1, 2, 3}}})
+		_, x, err := decodeContractConfigEncoded(EncodedConfigVersionWithTaggedExtensions, b)
+		if critical && err == nil {
+			t.Errorf("unknown critical extension must be rejected")
+		}
+		if !critical {
+			if err != nil {
+				t.Errorf("unknown non-critical extension must be ignored, got %v", err)
+			} else if x != defaultExtensions(o) {
+				t.Errorf("unknown non-critical extension must not change the config")
+			}
+		}
+	}
+}
+
+func TestTaggedExtensionsDuplicateTag(t *testing.T) {
+	o := testComponents()
+	value, _ := extensionCodecs[extensionTagHeartbeat].encode(o,
+		makeExtensions(HeartbeatSchedule{time.Hour, 0}, observation.RelativeDeviationRule(o.AlphaPPB), false))
+	b := mustPack(encodingWithTaggedExtensions, o.serializationRepresentation(), []taggedExtension{
+		{uint16(extensionTagHeartbeat), true, value},
+		{uint16(extensionTagHeartbeat), true, value},
+	})
+	if _, _, err := decodeContractConfigEncoded(EncodedConfigVersionWithTaggedExtensions, b); err == nil {
+		t.Errorf("duplicate extensions must be rejected")
+	}
+}
+
+// configKeys is a types.PrivateKeys which only supports the operations
+// needed to decrypt the shared secret
+type configKeys struct {
+	types.PrivateKeys
+	scalar [curve25519.ScalarSize]byte
+}
+
+func newConfigKeys(t *testing.T) *configKeys {
+	k := &configKeys{}
+	if _, err := cryptorand.Read(k.scalar[:]); err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func (k *configKeys) ConfigDiffieHellman(base *[curve25519.ScalarSize]byte) (*[curve25519.PointSize]byte, error) {
+	p, err := curve25519.X25519(k.scalar[:], base[:])
+	if err != nil {
+		return nil, err
+	}
+	var sharedPoint [curve25519.PointSize]byte
+	copy(sharedPoint[:], p)
+	return &sharedPoint, nil
+}
+
+func (k *configKeys) PublicKeyConfig() [curve25519.PointSize]byte {
+	p, err := curve25519.X25519(k.scalar[:], curve25519.Basepoint)
+	if err != nil {
+		panic(err)
+	}
+	var pk [curve25519.PointSize]byte
+	copy(pk[:], p)
+	return pk
+}
+
+func testPublicConfig() PublicConfig {
+	return PublicConfig{
+		DeltaProgress:    10 * time.Second,
+		DeltaResend:      10 * time.Second,
+		DeltaRound:       5 * time.Second,
+		DeltaGrace:       time.Second,
+		DeltaC:           time.Minute,
+		AlphaPPB:         10000000,
+		DeviationRule:    observation.RelativeDeviationRule(10000000),
+		DeltaStage:       5 * time.Second,
+		RMax:             10,
+		S:                []int{1, 1, 1, 1},
+		OracleIdentities: distinctIdentities(),
+		F:                1,
+	}
+}
+
+// authenticatedTestConfig returns a config of
+// EncodedConfigVersionWithAuthenticatedSharedSecret for testPublicConfig, and
+// the keys of its oracles
+func authenticatedTestConfig(t *testing.T) (types.ContractConfig, []*configKeys) {
+	keys := []*configKeys{}
+	publicKeys := []types.SharedSecretEncryptionPublicKey{}
+	for range distinctIdentities() {
+		k := newConfigKeys(t)
+		keys = append(keys, k)
+		publicKeys = append(publicKeys, types.SharedSecretEncryptionPublicKey(k.PublicKeyConfig()))
+	}
+	signers, transmitters, threshold, version, encoded, err := ContractSetConfigArgsWithAuthenticatedSharedSecret(
+		testPublicConfig(),
+		testContractAddress,
+		testConfigCount,
+		publicKeys,
+		cryptorand.Reader,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change := types.ContractConfig{
+		types.ConfigDigest{},
+		signers,
+		transmitters,
+		threshold,
+		version,
+		encoded,
+	}
+	setOnChain(&change, testContractAddress, testConfigCount)
+	return change, keys
+}
+
+var testContractAddress = common.Address{0xc0}
+
+const testConfigCount = 7
+
+// setOnChain sets the ConfigDigest change would have as the configCount-th
+// config of contractAddress
+func setOnChain(change *types.ContractConfig, contractAddress common.Address, configCount uint64) {
+	change.ConfigDigest = ConfigDigest(
+		contractAddress,
+		configCount,
+		change.Signers,
+		change.Transmitters,
+		change.Threshold,
+		change.EncodedConfigVersion,
+		change.Encoded,
+	)
+}
+
+// decryptAuthenticatedTestConfig decrypts oid's shared secret from change with
+// k, using the encryptions of change, or encs if they're given
+func decryptAuthenticatedTestConfig(
+	t *testing.T,
+	change types.ContractConfig,
+	encs []authenticatedEncryptedSharedSecret,
+	oid types.OracleID,
+	k types.PrivateKeys,
+) (*[SharedSecretSize]byte, error) {
+	_, sse, err := PublicConfigFromContractConfigUnchecked(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encs != nil {
+		sse.AuthenticatedEncryptions = encs
+	}
+	context, err := sharedSecretContextFromContractConfig(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sse.decryptAuthenticated(oid, k, context)
+}
+
+func TestAuthenticatedSharedSecretRoundTrip(t *testing.T) {
+	change, keys := authenticatedTestConfig(t)
+	if change.EncodedConfigVersion != EncodedConfigVersionWithAuthenticatedSharedSecret {
+		t.Fatalf("expected EncodedConfigVersion %d, got %d",
+			EncodedConfigVersionWithAuthenticatedSharedSecret, change.EncodedConfigVersion)
+	}
+
+	c, sse, err := publicConfigFromContractConfig(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := testPublicConfig()
+	if c.AlphaPPB != expected.AlphaPPB || c.DeltaRound != expected.DeltaRound || c.F != expected.F ||
+		c.N() != expected.N() || c.DeviationRule.String() != expected.DeviationRule.String() {
+		t.Errorf("config changed in round trip: %+v became %+v", expected, c)
+	}
+	if !sse.IsAuthenticated() || len(sse.Encryptions) != 0 {
+		t.Errorf("expected only authenticated encryptions, got %+v", sse)
+	}
+	if _, err := sse.Decrypt(0, keys[0]); err == nil {
+		t.Errorf("authenticated encryptions must not be decryptable without their config")
+	}
+
+	var sharedSecret *[SharedSecretSize]byte
+	for i, k := range keys {
+		x, err := decryptAuthenticatedTestConfig(t, change, nil, types.OracleID(i), k)
+		if err != nil {
+			t.Fatalf("oracle %d could not decrypt the shared secret: %v", i, err)
+		}
+		if sharedSecret != nil && *x != *sharedSecret {
+			t.Errorf("oracle %d decrypted a different shared secret", i)
+		}
+		sharedSecret = x
+	}
+}
+
+func TestAuthenticatedSharedSecretSwappedOracle(t *testing.T) {
+	change, keys := authenticatedTestConfig(t)
+	_, sse, err := PublicConfigFromContractConfigUnchecked(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The encryption meant for oracle 0 is put in oracle 1's place. Oracle 0
+	// holds the right key for it, but the encryption is bound to its index.
+	encs := append([]authenticatedEncryptedSharedSecret{}, sse.AuthenticatedEncryptions...)
+	encs[1] = encs[0]
+	if _, err := decryptAuthenticatedTestConfig(t, change, encs, 1, keys[0]); err == nil {
+		t.Errorf("encryption for oracle 0 was accepted at index 1")
+	}
+	if _, err := decryptAuthenticatedTestConfig(t, change, nil, 1, keys[0]); err == nil {
+		t.Errorf("oracle 0 decrypted the encryption for oracle 1")
+	}
+}
+
+func TestAuthenticatedSharedSecretBoundToConfig(t *testing.T) {
+	// Each modification yields a config whose ConfigDigest matches, as if it
+	// had been set on chain, but whose encryptions were made for another one
+	for name, modify := range map[string]func(*types.ContractConfig, *configWithAuthenticatedSharedSecret){
+		"signers": func(c *types.ContractConfig, _ *configWithAuthenticatedSharedSecret) {
+			c.Signers = append([]common.Address{}, c.Signers...)
+			c.Signers[2] = common.Address{0xff}
+		},
+		"transmitters": func(c *types.ContractConfig, _ *configWithAuthenticatedSharedSecret) {
+			c.Transmitters = append([]common.Address{}, c.Transmitters...)
+			c.Transmitters[0], c.Transmitters[1] = c.Transmitters[1], c.Transmitters[0]
+		},
+		"threshold": func(c *types.ContractConfig, _ *configWithAuthenticatedSharedSecret) { c.Threshold++ },
+		"contractAddress": func(_ *types.ContractConfig, a *configWithAuthenticatedSharedSecret) {
+			a.contractAddress = common.Address{0xc1}
+		},
+		"configCount": func(_ *types.ContractConfig, a *configWithAuthenticatedSharedSecret) { a.configCount++ },
+	} {
+		change, keys := authenticatedTestConfig(t)
+		a, err := splitContractSetConfigEncodedWithAuthenticatedSharedSecret(change.Encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		modify(&change, &a)
+		change.Encoded = a.encode()
+		setOnChain(&change, a.contractAddress, a.configCount)
+		for i, k := range keys {
+			if _, err := decryptAuthenticatedTestConfig(t, change, nil, types.OracleID(i), k); err == nil {
+				t.Errorf("oracle %d decrypted the shared secret after changing the %s", i, name)
+			}
+		}
+	}
+}
+
+func TestAuthenticatedSharedSecretBoundToConfigDigest(t *testing.T) {
+	change, _ := authenticatedTestConfig(t)
+	if _, err := sharedSecretContextFromContractConfig(change); err != nil {
+		t.Fatal(err)
+	}
+	for name, c := range map[string]struct {
+		contractAddress common.Address
+		configCount     uint64
+	}{
+		"on another contract": {common.Address{0xc1}, testConfigCount},
+		"again":               {testContractAddress, testConfigCount + 1},
+	} {
+		replayed := change
+		setOnChain(&replayed, c.contractAddress, c.configCount)
+		if _, err := sharedSecretContextFromContractConfig(replayed); err == nil {
+			t.Errorf("encryptions were accepted in the same config set %s", name)
+		}
+	}
+}
+
+func TestAuthenticatedSharedSecretFlippedCiphertext(t *testing.T) {
+	change, keys := authenticatedTestConfig(t)
+	_, sse, err := PublicConfigFromContractConfigUnchecked(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range sse.AuthenticatedEncryptions[0] {
+		encs := append([]authenticatedEncryptedSharedSecret{}, sse.AuthenticatedEncryptions...)
+		encs[0][i] ^= 1
+		if _, err := decryptAuthenticatedTestConfig(t, change, encs, 0, keys[0]); err == nil {
+			t.Errorf("encryption with flipped byte %d was accepted", i)
+		}
+	}
+}
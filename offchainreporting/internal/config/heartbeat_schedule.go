@@ -0,0 +1,67 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HeartbeatSchedule describes reports which must happen at fixed wall-clock
+// boundaries, regardless of deviation. The boundaries are the instants
+// Offset + k*Period after the unix epoch, for all integers k. A zero Period
+// disables the schedule.
+type HeartbeatSchedule struct {
+	Period time.Duration
+	Offset time.Duration
+}
+
+// Enabled returns true iff the schedule has any boundaries
+func (h HeartbeatSchedule) Enabled() bool {
+	return h.Period != 0
+}
+
+// LatestBoundary returns the latest boundary at or before t. Must only be
+// called on enabled schedules.
+func (h HeartbeatSchedule) LatestBoundary(t time.Time) time.Time {
+	sinceFirst := t.Sub(time.Unix(0, 0).Add(h.Offset))
+	return t.Add(-(sinceFirst%h.Period + h.Period) % h.Period)
+}
+
+// Due returns true iff a boundary has passed after last and at or before now.
+func (h HeartbeatSchedule) Due(last time.Time, now time.Time) bool {
+	if !h.Enabled() {
+		return false
+	}
+	return last.Before(h.LatestBoundary(now))
+}
+
+// DueInRound returns true iff a round whose report-req arrives at now is the
+// first round after a boundary which passed after lastReport, the time of the
+// latest report on-chain. Leaders start a new round every deltaRound, so that
+// is the round within deltaRound of the boundary. The result depends only on
+// the schedule and the on-chain timestamp, which all oracles agree on, and not
+// on which reports an oracle has seen accepted. If the first round after a
+// boundary doesn't produce a report, e.g. because of a leader change, later
+// rounds aren't forced, and the boundary is only satisfied by a report due to
+// deviation or DeltaC.
+func (h HeartbeatSchedule) DueInRound(lastReport time.Time, now time.Time, deltaRound time.Duration) bool {
+	if !h.Due(lastReport, now) {
+		return false
+	}
+	return now.Before(h.LatestBoundary(now).Add(deltaRound))
+}
+
+func (h HeartbeatSchedule) check(deltaRound time.Duration) error {
+	if !h.Enabled() {
+		return nil
+	}
+	if !(deltaRound < h.Period) {
+		return errors.Errorf("Period (%v) must be zero or greater than DeltaRound (%v)",
+			h.Period, deltaRound)
+	}
+	if !(0 <= h.Offset && h.Offset < h.Period) {
+		return errors.Errorf("Offset (%v) must be non-negative and less than Period (%v)",
+			h.Offset, h.Period)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeartbeatScheduleLatestBoundary(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	tests := []struct {
+		schedule HeartbeatSchedule
+		t        string
+		expected string
+	}{
+		{HeartbeatSchedule{time.Hour, 0}, "2021-03-04T05:06:07Z", "2021-03-04T05:00:00Z"},
+		{HeartbeatSchedule{time.Hour, 0}, "2021-03-04T05:00:00Z", "2021-03-04T05:00:00Z"},
+		{HeartbeatSchedule{time.Hour, 15 * time.Minute}, "2021-03-04T05:06:07Z", "2021-03-04T04:15:00Z"},
+		{HeartbeatSchedule{time.Hour, 15 * time.Minute}, "2021-03-04T05:15:00Z", "2021-03-04T05:15:00Z"},
+		{HeartbeatSchedule{time.Hour, -15 * time.Minute}, "2021-03-04T05:06:07Z", "2021-03-04T04:45:00Z"},
+		{HeartbeatSchedule{time.Hour, -15 * time.Minute}, "2021-03-04T05:50:00Z", "2021-03-04T05:45:00Z"},
+		{HeartbeatSchedule{24 * time.Hour, 0}, "1969-12-31T23:00:00Z", "1969-12-31T00:00:00Z"},
+	}
+	for _, test := range tests {
+		got := test.schedule.LatestBoundary(at(test.t))
+		if !got.Equal(at(test.expected)) {
+			t.Errorf("%+v.LatestBoundary(%s) = %s, expected %s",
+				test.schedule, test.t, got.UTC().Format(time.RFC3339), test.expected)
+		}
+	}
+}
+
+func TestHeartbeatScheduleDue(t *testing.T) {
+	h := HeartbeatSchedule{time.Hour, -15 * time.Minute}
+	boundary := time.Date(2021, 3, 4, 4, 45, 0, 0, time.UTC)
+	tests := []struct {
+		last, now time.Time
+		due       bool
+	}{
+		{boundary.Add(-time.Second), boundary, true},
+		{boundary.Add(-time.Second), boundary.Add(-time.Nanosecond), false},
+		{boundary, boundary.Add(time.Minute), false},
+		{boundary.Add(time.Second), boundary.Add(time.Minute), false},
+		{boundary.Add(-2 * time.Hour), boundary.Add(time.Minute), true},
+	}
+	for _, test := range tests {
+		if got := h.Due(test.last, test.now); got != test.due {
+			t.Errorf("Due(%s, %s) = %v, expected %v", test.last, test.now, got, test.due)
+		}
+	}
+	if (HeartbeatSchedule{}).Due(time.Time{}, boundary) {
+		t.Errorf("disabled schedule must never be due")
+	}
+}
+
+func TestHeartbeatScheduleDueInRound(t *testing.T) {
+	h := HeartbeatSchedule{time.Hour, -15 * time.Minute}
+	boundary := time.Date(2021, 3, 4, 4, 45, 0, 0, time.UTC)
+	deltaRound := 10 * time.Second
+	lastReport := boundary.Add(-10 * time.Minute)
+	tests := []struct {
+		name            string
+		lastReport, now time.Time
+		due             bool
+	}{
+		{"before the boundary", lastReport, boundary.Add(-time.Second), false},
+		{"at the boundary", lastReport, boundary, true},
+		{"first round after the boundary", lastReport, boundary.Add(deltaRound - time.Nanosecond), true},
+		{"second round after the boundary", lastReport, boundary.Add(deltaRound), false},
+		{"later round before the next boundary", lastReport, boundary.Add(59 * time.Minute), false},
+		{"first round after the next boundary", lastReport, boundary.Add(time.Hour + time.Second), true},
+		{"report on-chain after the boundary", boundary.Add(time.Second), boundary.Add(2 * time.Second), false},
+	}
+	for _, test := range tests {
+		if got := h.DueInRound(test.lastReport, test.now, deltaRound); got != test.due {
+			t.Errorf("%s: DueInRound(%s, %s, %v) = %v, expected %v",
+				test.name, test.lastReport, test.now, deltaRound, got, test.due)
+		}
+	}
+	if (HeartbeatSchedule{}).DueInRound(lastReport, boundary, deltaRound) {
+		t.Errorf("disabled schedule must never be due")
+	}
+}
@@ -8,22 +8,26 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 )
 
 // PublicConfig is the configuration disseminated through the smart contract
 // It's public, because anybody can read it from the blockchain
 type PublicConfig struct {
-	DeltaProgress    time.Duration
-	DeltaResend      time.Duration
-	DeltaRound       time.Duration
-	DeltaGrace       time.Duration
-	DeltaC           time.Duration
-	AlphaPPB         uint64
-	DeltaStage       time.Duration
-	RMax             uint8
-	S                []int
-	OracleIdentities []OracleIdentity
+	DeltaProgress        time.Duration
+	DeltaResend          time.Duration
+	DeltaRound           time.Duration
+	DeltaGrace           time.Duration
+	DeltaC               time.Duration
+	Heartbeat            HeartbeatSchedule
+	AlphaPPB             uint64
+	DeviationRule        observation.DeviationRule
+	ReportReqCrossChecks bool // see protocol.MessageReportReqDigest
+	DeltaStage           time.Duration
+	RMax                 uint8
+	S                    []int
+	OracleIdentities     []OracleIdentity
 
 	F            int
 	ConfigDigest types.ConfigDigest
@@ -48,13 +52,39 @@ func (c *PublicConfig) CheckParameterBounds() error {
 	return nil
 }
 
+// CheckParameters returns an error if oracles would reject c. Use it to
+// check a config before proposing it.
+func (c *PublicConfig) CheckParameters() error {
+	if err := c.CheckParameterBounds(); err != nil {
+		return err
+	}
+	return checkPublicConfigParameters(*c)
+}
+
 func PublicConfigFromContractConfig(change types.ContractConfig) (PublicConfig, error) {
 	pubcon, _, err := publicConfigFromContractConfig(change)
 	return pubcon, err
 }
 
 func publicConfigFromContractConfig(change types.ContractConfig) (PublicConfig, SharedSecretEncryptions, error) {
-	oc, err := decodeContractSetConfigEncodedComponents(change.Encoded)
+	cfg, sse, err := PublicConfigFromContractConfigUnchecked(change)
+	if err != nil {
+		return PublicConfig{}, SharedSecretEncryptions{}, err
+	}
+
+	if err := checkPublicConfigParameters(cfg); err != nil {
+		return PublicConfig{}, SharedSecretEncryptions{}, err
+	}
+
+	return cfg, sse, nil
+}
+
+// PublicConfigFromContractConfigUnchecked is like PublicConfigFromContractConfig,
+// but doesn't check the config's parameters, and also returns the config's
+// SharedSecretEncryptions. It's meant for tools which inspect configs,
+// including invalid ones. Oracles must use PublicConfigFromContractConfig.
+func PublicConfigFromContractConfigUnchecked(change types.ContractConfig) (PublicConfig, SharedSecretEncryptions, error) {
+	oc, ext, err := decodeContractConfigEncoded(change.EncodedConfigVersion, change.Encoded)
 	if err != nil {
 		return PublicConfig{}, SharedSecretEncryptions{}, err
 	}
@@ -75,26 +105,70 @@ func publicConfigFromContractConfig(change types.ContractConfig) (PublicConfig,
 		})
 	}
 
-	cfg := PublicConfig{
+	return publicConfigFromComponents(oc, ext, identities, change.Threshold, change.ConfigDigest),
+		oc.SharedSecretEncryptions, nil
+}
+
+// PublicConfigFromEncodedUnchecked decodes the Encoded field of a
+// ContractConfig on its own, e.g. when only the calldata of a setConfig call
+// is at hand. The OnChainSigningAddress and TransmitAddress of all oracles are
+// unknown and left zero, as is the ConfigDigest. Like
+// PublicConfigFromContractConfigUnchecked, it doesn't check the config's
+// parameters.
+func PublicConfigFromEncodedUnchecked(
+	encodedConfigVersion uint64,
+	encoded []byte,
+	threshold uint8,
+) (PublicConfig, SharedSecretEncryptions, error) {
+	oc, ext, err := decodeContractConfigEncoded(encodedConfigVersion, encoded)
+	if err != nil {
+		return PublicConfig{}, SharedSecretEncryptions{}, err
+	}
+
+	if len(oc.PeerIDs) != len(oc.OffchainPublicKeys) {
+		return PublicConfig{}, SharedSecretEncryptions{}, errors.Errorf(
+			"peer ID list must have same length as offchain public keys list: %d ≠ %d",
+			len(oc.PeerIDs), len(oc.OffchainPublicKeys))
+	}
+
+	identities := []OracleIdentity{}
+	for i := range oc.PeerIDs {
+		identities = append(identities, OracleIdentity{
+			oc.PeerIDs[i],
+			oc.OffchainPublicKeys[i],
+			types.OnChainSigningAddress{},
+			common.Address{},
+		})
+	}
+
+	return publicConfigFromComponents(oc, ext, identities, threshold, types.ConfigDigest{}),
+		oc.SharedSecretEncryptions, nil
+}
+
+func publicConfigFromComponents(
+	oc setConfigEncodedComponents,
+	ext setConfigEncodedExtensions,
+	identities []OracleIdentity,
+	threshold uint8,
+	configDigest types.ConfigDigest,
+) PublicConfig {
+	return PublicConfig{
 		oc.DeltaProgress,
 		oc.DeltaResend,
 		oc.DeltaRound,
 		oc.DeltaGrace,
 		oc.DeltaC,
+		ext.heartbeatSchedule(),
 		oc.AlphaPPB,
+		ext.deviationRule(),
+		ext.ReportReqCrossChecks,
 		oc.DeltaStage,
 		oc.RMax,
 		oc.S,
 		identities,
-		int(change.Threshold),
-		change.ConfigDigest,
+		int(threshold),
+		configDigest,
 	}
-
-	if err := checkPublicConfigParameters(cfg); err != nil {
-		return PublicConfig{}, SharedSecretEncryptions{}, err
-	}
-
-	return cfg, oc.SharedSecretEncryptions, nil
 }
 
 func checkIdentityListsHaveTheSameLength(
@@ -110,7 +184,7 @@ func checkIdentityListsHaveTheSameLength(
 		{len(oc.PeerIDs) /*                       */, "peer ID"},
 		{len(oc.OffchainPublicKeys) /*            */, "offchain public keys"},
 		{len(change.Transmitters) /*              */, "transmitter address"},
-		{len(oc.SharedSecretEncryptions.Encryptions), "shared-secret encryptions"},
+		{oc.SharedSecretEncryptions.numEncryptions(), "shared-secret encryptions"},
 	} {
 		if identityList.length != expectedLength {
 			return errors.Errorf(errorMsg, identityList.name, identityList.length)
@@ -135,6 +209,16 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 			cfg.DeltaC)
 	}
 
+	if err := cfg.Heartbeat.check(cfg.DeltaRound); err != nil {
+		return fmt.Errorf("Heartbeat (%+v) is invalid: %v",
+			cfg.Heartbeat, err)
+	}
+
+	if err := cfg.DeviationRule.Check(); err != nil {
+		return fmt.Errorf("DeviationRule (%v) is invalid: %v",
+			cfg.DeviationRule, err)
+	}
+
 	if !(1*time.Second < cfg.DeltaStage) {
 		return fmt.Errorf("DeltaStage (%v) must be greater than 1s",
 			cfg.DeltaStage)
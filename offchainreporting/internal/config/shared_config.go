@@ -3,10 +3,13 @@ package config
 import (
 	"bytes"
 	cryptorand "crypto/rand"
+	"io"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -86,9 +89,21 @@ func SharedConfigFromContractConfig(
 		}
 	}
 
-	x, err := encSharedSecret.Decrypt(oracleID, privateKeys)
-	if err != nil {
-		return SharedConfig{}, 0, errors.Wrapf(err, "could not decrypt shared secret")
+	var x *[SharedSecretSize]byte
+	if encSharedSecret.IsAuthenticated() {
+		context, err := sharedSecretContextFromContractConfig(change)
+		if err != nil {
+			return SharedConfig{}, 0, err
+		}
+		x, err = encSharedSecret.decryptAuthenticated(oracleID, privateKeys, context)
+		if err != nil {
+			return SharedConfig{}, 0, errors.Wrapf(err, "could not decrypt shared secret")
+		}
+	} else {
+		x, err = encSharedSecret.Decrypt(oracleID, privateKeys)
+		if err != nil {
+			return SharedConfig{}, 0, errors.Wrapf(err, "could not decrypt shared secret")
+		}
 	}
 
 	return SharedConfig{
@@ -98,6 +113,203 @@ func SharedConfigFromContractConfig(
 
 }
 
+// ContractSetConfigArgs returns the arguments for a call to setConfig on the
+// OffchainAggregator which installs c. A fresh shared secret is drawn from
+// rand and encrypted to sharedSecretEncryptionPublicKeys, which must be given
+// in the same order as c.OracleIdentities. c.ConfigDigest is ignored, since
+// it's only determined once the config is set on chain.
+func ContractSetConfigArgs(
+	c PublicConfig,
+	sharedSecretEncryptionPublicKeys []types.SharedSecretEncryptionPublicKey,
+	rand io.Reader,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+	err error,
+) {
+	if err := checkContractSetConfigArgs(c, sharedSecretEncryptionPublicKeys); err != nil {
+		return nil, nil, 0, 0, nil, err
+	}
+
+	var sharedSecret [SharedSecretSize]byte
+	if _, err := io.ReadFull(rand, sharedSecret[:]); err != nil {
+		return nil, nil, 0, 0, nil, errors.Wrap(err, "could not generate shared secret")
+	}
+
+	return XXXContractSetConfigArgsFromSharedConfig(
+		SharedConfig{c, &sharedSecret},
+		sharedSecretEncryptionPublicKeys,
+	)
+}
+
+// ContractSetConfigArgsWithAuthenticatedSharedSecret is like
+// ContractSetConfigArgs, but produces a config of
+// EncodedConfigVersionWithAuthenticatedSharedSecret, whose encryptions of the
+// shared secret are bound to the config and to the oracle they're meant for.
+// Oracles which don't know this version reject such configs, so it must only
+// be used once all oracles have been upgraded.
+//
+// The encryptions are also bound to the ConfigDigest the config will have,
+// so contractAddress must be the OffchainAggregator the config is set on, and
+// configCount the count it will have there, i.e. one more than that of the
+// latest config. Oracles reject the config if it's set anywhere else.
+func ContractSetConfigArgsWithAuthenticatedSharedSecret(
+	c PublicConfig,
+	contractAddress common.Address,
+	configCount uint64,
+	sharedSecretEncryptionPublicKeys []types.SharedSecretEncryptionPublicKey,
+	rand io.Reader,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+	err error,
+) {
+	if err := checkContractSetConfigArgs(c, sharedSecretEncryptionPublicKeys); err != nil {
+		return nil, nil, 0, 0, nil, err
+	}
+
+	var sharedSecret [SharedSecretSize]byte
+	if _, err := io.ReadFull(rand, sharedSecret[:]); err != nil {
+		return nil, nil, 0, 0, nil, errors.Wrap(err, "could not generate shared secret")
+	}
+	var sk [32]byte
+	if _, err := io.ReadFull(rand, sk[:]); err != nil {
+		return nil, nil, 0, 0, nil, errors.Wrap(err, "could not produce entropy for encryption")
+	}
+	pk, err := curve25519.X25519(sk[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, 0, 0, nil, errors.Wrap(err, "could not derive ephemeral public key")
+	}
+	var pkArray [curve25519.PointSize]byte
+	copy(pkArray[:], pk)
+
+	// The encryptions are bound to the rest of the config, so the rest of the
+	// config is assembled first, with no encryptions.
+	signers, transmitters, threshold, components := contractSetConfigComponents(
+		c,
+		SharedSecretEncryptions{
+			pkArray,
+			common.BytesToHash(crypto.Keccak256(sharedSecret[:])),
+			[]encryptedSharedSecret{},
+			nil,
+		},
+	)
+	body := encodeWithTaggedExtensions(components, makeExtensions(c.Heartbeat, c.DeviationRule, c.ReportReqCrossChecks))
+	encryptions := encryptSharedSecretAuthenticated(
+		sharedSecretEncryptionPublicKeys,
+		&sharedSecret,
+		&sk,
+		sharedSecretContext(contractAddress, configCount, signers, transmitters, threshold, body),
+	)
+	return signers,
+		transmitters,
+		threshold,
+		EncodedConfigVersionWithAuthenticatedSharedSecret,
+		configWithAuthenticatedSharedSecret{body, contractAddress, configCount, encryptions}.encode(),
+		nil
+}
+
+func checkContractSetConfigArgs(
+	c PublicConfig,
+	sharedSecretEncryptionPublicKeys []types.SharedSecretEncryptionPublicKey,
+) error {
+	if err := c.CheckParameters(); err != nil {
+		return err
+	}
+	if len(sharedSecretEncryptionPublicKeys) != c.N() {
+		return errors.Errorf(
+			"need one SharedSecretEncryptionPublicKey per oracle, got %d for %d oracles",
+			len(sharedSecretEncryptionPublicKeys), c.N())
+	}
+	return checkOracleIdentitiesAreDistinct(c.OracleIdentities)
+}
+
+// ContractSetConfigArgsWithSharedSecretEncryptions is like
+// ContractSetConfigArgs, but takes the encryptions of a shared secret chosen
+// elsewhere, e.g. through a ceremony among the oracles. The encryptions must
+// be in the same order as c.OracleIdentities.
+func ContractSetConfigArgsWithSharedSecretEncryptions(
+	c PublicConfig,
+	sharedSecretEncryptions SharedSecretEncryptions,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+	err error,
+) {
+	if sharedSecretEncryptions.IsAuthenticated() {
+		return nil, nil, 0, 0, nil, errors.Errorf(
+			"authenticated shared secret encryptions are bound to the config they were made for, " +
+				"and cannot be used in another one")
+	}
+	if err := c.CheckParameters(); err != nil {
+		return nil, nil, 0, 0, nil, err
+	}
+	if len(sharedSecretEncryptions.Encryptions) != c.N() {
+		return nil, nil, 0, 0, nil, errors.Errorf(
+			"need one shared secret encryption per oracle, got %d for %d oracles",
+			len(sharedSecretEncryptions.Encryptions), c.N())
+	}
+	if err := checkOracleIdentitiesAreDistinct(c.OracleIdentities); err != nil {
+		return nil, nil, 0, 0, nil, err
+	}
+	signers, transmitters, threshold, encodedConfigVersion, encodedConfig =
+		contractSetConfigArgs(c, sharedSecretEncryptions)
+	return signers, transmitters, threshold, encodedConfigVersion, encodedConfig, nil
+}
+
+// checkOracleIdentitiesAreDistinct returns an error if two oracles share a
+// key, address, or peer ID. The contract only checks this for signing and
+// transmit addresses, but the protocol cannot tell oracles with the same
+// offchain identity apart either.
+func checkOracleIdentitiesAreDistinct(identities []OracleIdentity) error {
+	return checkOracleIdentitiesAreDistinctSkippingZeroAddresses(identities, false)
+}
+
+// checkOracleIdentitiesAreDistinctUnchecked is like
+// checkOracleIdentitiesAreDistinct, but skips zero addresses, which stand for
+// unknown ones in the results of PublicConfigFromEncodedUnchecked. It must
+// only be used on configs which are inspected rather than run or written.
+func checkOracleIdentitiesAreDistinctUnchecked(identities []OracleIdentity) error {
+	return checkOracleIdentitiesAreDistinctSkippingZeroAddresses(identities, true)
+}
+
+func checkOracleIdentitiesAreDistinctSkippingZeroAddresses(identities []OracleIdentity, skipZeroAddresses bool) error {
+	peerIDs := map[string]int{}
+	offchainPublicKeys := map[string]int{}
+	signers := map[types.OnChainSigningAddress]int{}
+	transmitters := map[common.Address]int{}
+	for i, identity := range identities {
+		if j, ok := peerIDs[identity.PeerID]; ok {
+			return errors.Errorf("oracles %d and %d have the same PeerID %v", j, i, identity.PeerID)
+		}
+		peerIDs[identity.PeerID] = i
+		if j, ok := offchainPublicKeys[string(identity.OffchainPublicKey)]; ok {
+			return errors.Errorf("oracles %d and %d have the same OffchainPublicKey %x", j, i, identity.OffchainPublicKey)
+		}
+		offchainPublicKeys[string(identity.OffchainPublicKey)] = i
+		skipSigner := skipZeroAddresses && identity.OnChainSigningAddress == (types.OnChainSigningAddress{})
+		if j, ok := signers[identity.OnChainSigningAddress]; ok && !skipSigner {
+			return errors.Errorf("oracles %d and %d have the same OnChainSigningAddress 0x%x", j, i, identity.OnChainSigningAddress)
+		}
+		signers[identity.OnChainSigningAddress] = i
+		skipTransmitter := skipZeroAddresses && identity.TransmitAddress == (common.Address{})
+		if j, ok := transmitters[identity.TransmitAddress]; ok && !skipTransmitter {
+			return errors.Errorf("oracles %d and %d have the same TransmitAddress 0x%x", j, i, identity.TransmitAddress)
+		}
+		transmitters[identity.TransmitAddress] = i
+	}
+	return nil
+}
+
 func XXXContractSetConfigArgsFromSharedConfig(
 	c SharedConfig,
 	sharedSecretEncryptionPublicKeys []types.SharedSecretEncryptionPublicKey,
@@ -108,6 +320,50 @@ func XXXContractSetConfigArgsFromSharedConfig(
 	encodedConfigVersion uint64,
 	encodedConfig []byte,
 	err error,
+) {
+	signers, transmitters, threshold, encodedConfigVersion, encodedConfig = contractSetConfigArgs(
+		c.PublicConfig,
+		XXXEncryptSharedSecret(
+			sharedSecretEncryptionPublicKeys,
+			c.SharedSecret,
+			cryptorand.Reader,
+		),
+	)
+	err = nil
+	return
+}
+
+func contractSetConfigArgs(
+	c PublicConfig,
+	sharedSecretEncryptions SharedSecretEncryptions,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	encodedConfigVersion uint64,
+	encodedConfig []byte,
+) {
+	signers, transmitters, threshold, components := contractSetConfigComponents(c, sharedSecretEncryptions)
+	// Only use extensions if we need to, so that oracles which don't know
+	// about them can still run configs that don't need them.
+	if !needsExtensions(c) {
+		encodedConfigVersion = EncodedConfigVersion
+		encodedConfig = components.encode()
+	} else {
+		encodedConfigVersion = EncodedConfigVersionWithTaggedExtensions
+		encodedConfig = encodeWithTaggedExtensions(components, makeExtensions(c.Heartbeat, c.DeviationRule, c.ReportReqCrossChecks))
+	}
+	return
+}
+
+func contractSetConfigComponents(
+	c PublicConfig,
+	sharedSecretEncryptions SharedSecretEncryptions,
+) (
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	components setConfigEncodedComponents,
 ) {
 	offChainPublicKeys := []types.OffchainPublicKey{}
 	peerIDs := []string{}
@@ -118,8 +374,7 @@ func XXXContractSetConfigArgsFromSharedConfig(
 		peerIDs = append(peerIDs, identity.PeerID)
 	}
 	threshold = uint8(c.F)
-	encodedConfigVersion = 1
-	encodedConfig = (setConfigEncodedComponents{
+	components = setConfigEncodedComponents{
 		c.DeltaProgress,
 		c.DeltaResend,
 		c.DeltaRound,
@@ -131,12 +386,7 @@ func XXXContractSetConfigArgsFromSharedConfig(
 		c.S,
 		offChainPublicKeys,
 		peerIDs,
-		XXXEncryptSharedSecret(
-			sharedSecretEncryptionPublicKeys,
-			c.SharedSecret,
-			cryptorand.Reader,
-		),
-	}).encode()
-	err = nil
+		sharedSecretEncryptions,
+	}
 	return
 }
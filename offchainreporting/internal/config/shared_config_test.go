@@ -0,0 +1,62 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func distinctIdentities() []OracleIdentity {
+	identities := []OracleIdentity{}
+	for i := byte(1); i <= 4; i++ {
+		identities = append(identities, OracleIdentity{
+			string([]byte{'a' + i}),
+			types.OffchainPublicKey{i},
+			types.OnChainSigningAddress{i},
+			common.Address{i},
+		})
+	}
+	return identities
+}
+
+func TestCheckOracleIdentitiesAreDistinctRejectsDuplicateZeroAddresses(t *testing.T) {
+	if err := checkOracleIdentitiesAreDistinct(distinctIdentities()); err != nil {
+		t.Fatalf("distinct identities were rejected: %v", err)
+	}
+
+	for name, zero := range map[string]func(*OracleIdentity){
+		"signing":  func(o *OracleIdentity) { o.OnChainSigningAddress = types.OnChainSigningAddress{} },
+		"transmit": func(o *OracleIdentity) { o.TransmitAddress = common.Address{} },
+	} {
+		identities := distinctIdentities()
+		zero(&identities[1])
+		zero(&identities[3])
+		if err := checkOracleIdentitiesAreDistinct(identities); err == nil {
+			t.Errorf("duplicate zero %s addresses were accepted", name)
+		}
+		if err := checkOracleIdentitiesAreDistinctUnchecked(identities); err != nil {
+			t.Errorf("zero %s addresses must be skipped in unchecked configs, got %v", name, err)
+		}
+	}
+
+	identities := distinctIdentities()
+	identities[3].TransmitAddress = identities[1].TransmitAddress
+	if err := checkOracleIdentitiesAreDistinctUnchecked(identities); err == nil {
+		t.Errorf("duplicate nonzero addresses were accepted in an unchecked config")
+	}
+}
+
+func TestSuspiciousParametersOfEncodedConfig(t *testing.T) {
+	c := PublicConfig{OracleIdentities: distinctIdentities()}
+	for i := range c.OracleIdentities {
+		c.OracleIdentities[i].OnChainSigningAddress = types.OnChainSigningAddress{}
+		c.OracleIdentities[i].TransmitAddress = common.Address{}
+	}
+	for _, warning := range c.SuspiciousParameters() {
+		if strings.Contains(warning, "have the same") {
+			t.Errorf("warned about unknown addresses: %v", warning)
+		}
+	}
+}
@@ -38,18 +38,47 @@ type SharedSecretEncryptions struct {
 	// 1. key := Keccak256(DH(DiffieHellmanPoint, process' secret key))[:16]
 	// 2. sharedSecret := AES128DecryptBlock(key, Encryptions[i])
 	//
-	// See Decrypt for details.
+	// See Decrypt for details. Empty in configs of
+	// EncodedConfigVersionWithAuthenticatedSharedSecret.
 	Encryptions []encryptedSharedSecret
+
+	// Authenticated encryptions of the shared secret with one entry for each
+	// oracle, used instead of Encryptions in configs of
+	// EncodedConfigVersionWithAuthenticatedSharedSecret. Each entry is bound
+	// to the config and the oracle it's meant for, see
+	// shared_secret_authenticated.go. Not part of the ABI encoding of
+	// SharedSecretEncryptions, since it follows the rest of the config.
+	AuthenticatedEncryptions []authenticatedEncryptedSharedSecret
+}
+
+// IsAuthenticated returns true iff e holds authenticated encryptions, which
+// can only be decrypted as part of the config they were made for
+func (e SharedSecretEncryptions) IsAuthenticated() bool {
+	return len(e.AuthenticatedEncryptions) != 0
+}
+
+// numEncryptions returns the number of oracles e encrypts the shared secret to
+func (e SharedSecretEncryptions) numEncryptions() int {
+	if e.IsAuthenticated() {
+		return len(e.AuthenticatedEncryptions)
+	}
+	return len(e.Encryptions)
 }
 
 func (e SharedSecretEncryptions) Equal(e2 SharedSecretEncryptions) bool {
 	if len(e.Encryptions) != len(e2.Encryptions) {
 		return false
 	}
+	if len(e.AuthenticatedEncryptions) != len(e2.AuthenticatedEncryptions) {
+		return false
+	}
 	encsEqual := true
 	for i := range e.Encryptions {
 		encsEqual = encsEqual && e.Encryptions[i] == e2.Encryptions[i]
 	}
+	for i := range e.AuthenticatedEncryptions {
+		encsEqual = encsEqual && e.AuthenticatedEncryptions[i] == e2.AuthenticatedEncryptions[i]
+	}
 	return encsEqual &&
 		e.DiffieHellmanPoint == e2.DiffieHellmanPoint &&
 		e.SharedSecretHash == e2.SharedSecretHash
@@ -77,8 +106,13 @@ func aesDecryptBlock(key, ciphertext []byte) [16]byte {
 	return plaintext
 }
 
-// Decrypt returns the sharedSecret
+// Decrypt returns the sharedSecret. Authenticated encryptions can't be
+// decrypted without the config they're part of, see
+// SharedConfigFromContractConfig.
 func (e SharedSecretEncryptions) Decrypt(oid types.OracleID, k types.PrivateKeys) (*[SharedSecretSize]byte, error) {
+	if e.IsAuthenticated() {
+		return nil, errors.New("authenticated SharedSecretEncryptions can only be decrypted along with their config")
+	}
 	if oid < 0 || len(e.Encryptions) <= int(oid) {
 		return nil, errors.New("oid out of range of SharedSecretEncryptions.Encryptions")
 	}
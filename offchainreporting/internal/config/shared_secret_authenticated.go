@@ -0,0 +1,169 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// Authenticated encryptions of the shared secret use AES-128-GCM. The
+// ciphertext of the shared secret and the tag fit into a single bytes32, so
+// they take as much space in the config as the legacy encryptions, which are
+// padded to 32 bytes by the ABI encoding.
+//
+// Each oracle's key is derived from a fresh Diffie-Hellman point, so it only
+// ever encrypts a single message, and the nonce can be fixed. The additional
+// data binds each encryption to the oracle it's meant for, and to everything
+// the ConfigDigest is computed from: the rest of the config, the contract
+// address and the config count. So an encryption cannot be replayed into
+// another config, into the same config set on another contract or set again,
+// or swapped with that of another oracle, without oracles noticing.
+const (
+	sharedSecretTagSize = 16
+
+	authenticatedSharedSecretKeyDomainSeparator = "seerlink offchain reporting v1 authenticated shared secret key"
+	sharedSecretContextDomainSeparator          = "seerlink offchain reporting v1 shared secret context"
+)
+
+type authenticatedEncryptedSharedSecret [SharedSecretSize + sharedSecretTagSize]byte
+
+var sharedSecretContextEncoding = getEncoding(sharedSecretContextABI)
+
+// sharedSecretContext returns the digest of the parts of a config of
+// EncodedConfigVersionWithAuthenticatedSharedSecret which the authenticated
+// encryptions of its shared secret are bound to. body is the config without
+// the encryptions, see setConfigAuthenticatedSharedSecretABI.
+func sharedSecretContext(
+	contractAddress common.Address,
+	configCount uint64,
+	signers []common.Address,
+	transmitters []common.Address,
+	threshold uint8,
+	body []byte,
+) common.Hash {
+	return common.BytesToHash(crypto.Keccak256(
+		[]byte(sharedSecretContextDomainSeparator),
+		mustPack(sharedSecretContextEncoding, contractAddress, configCount, signers, transmitters, threshold, body),
+	))
+}
+
+// authenticatedSharedSecretKey derives the key for the encryption of the
+// shared secret to the oracle with recipientPublicKey. Hashing both public
+// keys along with the Diffie-Hellman point ties the key to the exchange it
+// came from.
+func authenticatedSharedSecretKey(
+	dhPoint []byte,
+	ephemeralPublicKey [curve25519.PointSize]byte,
+	recipientPublicKey [curve25519.PointSize]byte,
+) []byte {
+	return crypto.Keccak256(
+		[]byte(authenticatedSharedSecretKeyDomainSeparator),
+		dhPoint,
+		ephemeralPublicKey[:],
+		recipientPublicKey[:],
+	)[:16]
+}
+
+func sharedSecretAEAD(key []byte) cipher.AEAD {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		// assertion
+		panic(fmt.Sprintf("Unexpected error during aes.NewCipher: %v", err))
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		// assertion
+		panic(fmt.Sprintf("Unexpected error during cipher.NewGCM: %v", err))
+	}
+	return aead
+}
+
+// sharedSecretAdditionalData returns the data the encryption of the shared
+// secret to oid is bound to
+func sharedSecretAdditionalData(context common.Hash, oid types.OracleID) []byte {
+	var oidBytes [4]byte
+	binary.BigEndian.PutUint32(oidBytes[:], uint32(oid))
+	return append(context.Bytes(), oidBytes[:]...)
+}
+
+// encryptSharedSecretAuthenticated encrypts sharedSecret to each of
+// publicKeys, using the ephemeral secret key sk and binding the encryptions to
+// context
+func encryptSharedSecretAuthenticated(
+	publicKeys []types.SharedSecretEncryptionPublicKey,
+	sharedSecret *[SharedSecretSize]byte,
+	sk *[32]byte,
+	context common.Hash,
+) []authenticatedEncryptedSharedSecret {
+	pk, err := curve25519.X25519(sk[:], curve25519.Basepoint)
+	if err != nil {
+		panic("while encrypting sharedSecret: " + err.Error())
+	}
+	var pkArray [curve25519.PointSize]byte
+	copy(pkArray[:], pk)
+
+	encryptions := []authenticatedEncryptedSharedSecret{}
+	for i, publicKey := range publicKeys {
+		pkBytes := [32]byte(publicKey)
+		dhPoint, err := curve25519.X25519(sk[:], pkBytes[:])
+		if err != nil {
+			panic("while encrypting sharedSecret: " + err.Error())
+		}
+		aead := sharedSecretAEAD(authenticatedSharedSecretKey(dhPoint, pkArray, pkBytes))
+
+		var encryption authenticatedEncryptedSharedSecret
+		nonce := make([]byte, aead.NonceSize())
+		sealed := aead.Seal(nil, nonce, sharedSecret[:], sharedSecretAdditionalData(context, types.OracleID(i)))
+		if len(sealed) != len(encryption) {
+			// assertion
+			panic("authenticated encryption has wrong length")
+		}
+		copy(encryption[:], sealed)
+		encryptions = append(encryptions, encryption)
+	}
+	return encryptions
+}
+
+// decryptAuthenticated returns the sharedSecret from the authenticated
+// encryptions of a config with the given context
+func (e SharedSecretEncryptions) decryptAuthenticated(
+	oid types.OracleID,
+	k types.PrivateKeys,
+	context common.Hash,
+) (*[SharedSecretSize]byte, error) {
+	if oid < 0 || len(e.AuthenticatedEncryptions) <= int(oid) {
+		return nil, errors.New("oid out of range of SharedSecretEncryptions.AuthenticatedEncryptions")
+	}
+
+	dhPoint, err := k.ConfigDiffieHellman(&e.DiffieHellmanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	aead := sharedSecretAEAD(authenticatedSharedSecretKey(dhPoint[:], e.DiffieHellmanPoint, k.PublicKeyConfig()))
+	plaintext, err := aead.Open(
+		nil,
+		make([]byte, aead.NonceSize()),
+		e.AuthenticatedEncryptions[int(oid)][:],
+		sharedSecretAdditionalData(context, oid),
+	)
+	if err != nil {
+		return nil, errors.Errorf("authenticated encryption of sharedSecret is invalid for this config and oracle")
+	}
+
+	var sharedSecret [SharedSecretSize]byte
+	copy(sharedSecret[:], plaintext)
+
+	if common.BytesToHash(crypto.Keccak256(sharedSecret[:])) != e.SharedSecretHash {
+		return nil, errors.Errorf("decrypted sharedSecret has wrong hash")
+	}
+
+	return &sharedSecret, nil
+}
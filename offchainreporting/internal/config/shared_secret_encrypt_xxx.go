@@ -46,6 +46,7 @@ func XXXEncryptSharedSecretInternal(
 		pkArray,
 		common.BytesToHash(crypto.Keccak256(sharedSecret[:])),
 		encryptedSharedSecrets,
+		nil,
 	}
 }
 
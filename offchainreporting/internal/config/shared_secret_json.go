@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// sharedSecretEncryptionsJSON is the JSON representation of a
+// SharedSecretEncryptions, with all binary fields in hex
+type sharedSecretEncryptionsJSON struct {
+	DiffieHellmanPoint hexutil.Bytes   `json:"diffieHellmanPoint"`
+	SharedSecretHash   common.Hash     `json:"sharedSecretHash"`
+	Encryptions        []hexutil.Bytes `json:"encryptions"`
+	// Only present for authenticated encryptions
+	AuthenticatedEncryptions []hexutil.Bytes `json:"authenticatedEncryptions,omitempty"`
+}
+
+func (e SharedSecretEncryptions) MarshalJSON() ([]byte, error) {
+	encs := []hexutil.Bytes{}
+	for _, enc := range e.Encryptions {
+		enc := enc
+		encs = append(encs, enc[:])
+	}
+	var authenticatedEncs []hexutil.Bytes
+	for _, enc := range e.AuthenticatedEncryptions {
+		enc := enc
+		authenticatedEncs = append(authenticatedEncs, enc[:])
+	}
+	return json.Marshal(sharedSecretEncryptionsJSON{
+		e.DiffieHellmanPoint[:],
+		e.SharedSecretHash,
+		encs,
+		authenticatedEncs,
+	})
+}
+
+func (e *SharedSecretEncryptions) UnmarshalJSON(b []byte) error {
+	var ej sharedSecretEncryptionsJSON
+	if err := json.Unmarshal(b, &ej); err != nil {
+		return err
+	}
+	var rv SharedSecretEncryptions
+	if len(ej.DiffieHellmanPoint) != len(rv.DiffieHellmanPoint) {
+		return errors.Errorf("diffieHellmanPoint must be %d bytes, got %d",
+			len(rv.DiffieHellmanPoint), len(ej.DiffieHellmanPoint))
+	}
+	copy(rv.DiffieHellmanPoint[:], ej.DiffieHellmanPoint)
+	rv.SharedSecretHash = ej.SharedSecretHash
+	rv.Encryptions = []encryptedSharedSecret{}
+	for i, enc := range ej.Encryptions {
+		var ess encryptedSharedSecret
+		if len(enc) != len(ess) {
+			return errors.Errorf("encryption %d must be %d bytes, got %d", i, len(ess), len(enc))
+		}
+		copy(ess[:], enc)
+		rv.Encryptions = append(rv.Encryptions, ess)
+	}
+	for i, enc := range ej.AuthenticatedEncryptions {
+		var aess authenticatedEncryptedSharedSecret
+		if len(enc) != len(aess) {
+			return errors.Errorf("authenticated encryption %d must be %d bytes, got %d", i, len(aess), len(enc))
+		}
+		copy(aess[:], enc)
+		rv.AuthenticatedEncryptions = append(rv.AuthenticatedEncryptions, aess)
+	}
+	*e = rv
+	return nil
+}
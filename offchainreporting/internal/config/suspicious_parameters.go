@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+)
+
+// SuspiciousParameters returns human-readable descriptions of the parameters
+// of c which oracles accept, but which are likely a mistake or make the
+// protocol needlessly costly or fragile. An empty result doesn't mean that c
+// is valid; use CheckParameters for that.
+func (c *PublicConfig) SuspiciousParameters() []string {
+	warnings := []string{}
+	warn := func(format string, a ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, a...))
+	}
+
+	if c.DeltaC == 0 {
+		warn("DeltaC is zero, so a report will be transmitted every round")
+	} else if c.DeltaC < 1*time.Minute {
+		warn("DeltaC (%v) is less than a minute, so reports will be transmitted at least that often even if the value doesn't change", c.DeltaC)
+	}
+
+	if c.Heartbeat.Enabled() && c.DeltaC != 0 && c.Heartbeat.Period < c.DeltaC {
+		warn("Heartbeat.Period (%v) is less than DeltaC (%v), so DeltaC has no effect", c.Heartbeat.Period, c.DeltaC)
+	}
+
+	if c.DeviationRule.Mode != observation.DeviationModeAbsolute {
+		if c.DeviationRule.UpPPB == 0 || c.DeviationRule.DownPPB == 0 {
+			warn("relative deviation threshold of %v is zero, so every change of the value will be reported", c.DeviationRule)
+		}
+		const tenPercentPPB = 100 * 1000 * 1000
+		if c.DeviationRule.UpPPB > tenPercentPPB || c.DeviationRule.DownPPB > tenPercentPPB {
+			warn("relative deviation threshold of %v exceeds 10%%", c.DeviationRule)
+		}
+	}
+
+	if c.DeltaProgress < 2*c.DeltaRound {
+		warn("DeltaProgress (%v) is less than twice DeltaRound (%v), so leaders may be replaced before they complete a round",
+			c.DeltaProgress, c.DeltaRound)
+	}
+
+	if c.DeltaResend > c.DeltaProgress {
+		warn("DeltaResend (%v) is greater than DeltaProgress (%v), so lost messages may not be resent before the leader is replaced",
+			c.DeltaResend, c.DeltaProgress)
+	}
+
+	if c.DeltaGrace > c.DeltaRound/2 {
+		warn("DeltaGrace (%v) is more than half of DeltaRound (%v)", c.DeltaGrace, c.DeltaRound)
+	}
+
+	if c.RMax == 1 {
+		warn("RMax is 1, so every round starts a new epoch with a new leader")
+	}
+
+	if c.F == 0 && c.N() > 1 {
+		warn("F is zero, so the protocol tolerates no faulty oracles")
+	} else if maxF := (c.N() - 1) / 3; c.F < maxF {
+		warn("F (%v) is less than the %v faulty oracles %v oracles could tolerate", c.F, maxF, c.N())
+	}
+
+	transmitters := 0
+	for _, s := range c.S {
+		transmitters += s
+	}
+	if transmitters == 0 {
+		warn("S (%v) schedules no transmitters, so no report will ever be transmitted", c.S)
+	} else if transmitters <= c.F {
+		warn("S (%v) schedules only %v transmitters, which could all be faulty", c.S, transmitters)
+	}
+	if len(c.S) > 0 && c.S[0] == 0 {
+		warn("S (%v) schedules no transmitters in the first stage, which delays every transmission by DeltaStage (%v)",
+			c.S, c.DeltaStage)
+	}
+
+	// c may come from PublicConfigFromEncodedUnchecked, whose addresses are
+	// unknown
+	if err := checkOracleIdentitiesAreDistinctUnchecked(c.OracleIdentities); err != nil {
+		warn("%v", err)
+	}
+
+	return warnings
+}
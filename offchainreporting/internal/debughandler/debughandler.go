@@ -0,0 +1,227 @@
+// Package debughandler serves the status of an oracle or bootstrap node over
+// HTTP, for liveness and readiness probes and for debugging.
+//
+// The handlers serve the following paths:
+//
+//   - /healthz: 200 if the node responds to status requests, 503 otherwise
+//   - /readyz: 200 if the node is ready to participate in the protocol, 503
+//     otherwise
+//   - /status: a JSON snapshot of the node's status
+//
+// Use http.StripPrefix to mount them under a prefix.
+package debughandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// probeTimeout bounds how long the handlers wait for a status snapshot
+const probeTimeout = 5 * time.Second
+
+// NewOracleHandler returns a handler which serves the status returned by
+// status. An oracle is ready once it runs the protocol for a config, and, if
+// its network endpoint reports connectivity, is connected to at least 2f other
+// oracles.
+func NewOracleHandler(status func(context.Context) (types.OracleStatus, error)) http.Handler {
+	return newHandler(func(ctx context.Context) (interface{}, error) {
+		s, err := status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return oracleStatusView(s), nil
+	}, func(ctx context.Context) error {
+		s, err := status(ctx)
+		if err != nil {
+			return err
+		}
+		if !s.Running {
+			return fmt.Errorf("not running the protocol")
+		}
+		if s.Network != nil {
+			connected := 0
+			for id, p := range s.Network.Peers {
+				if types.OracleID(id) != s.OracleID && p.Connected {
+					connected++
+				}
+			}
+			if connected < 2*s.F {
+				return fmt.Errorf("connected to %d other oracles, need at least %d",
+					connected, 2*s.F)
+			}
+		}
+		return nil
+	})
+}
+
+// NewBootstrapNodeHandler returns a handler which serves the status returned
+// by status. A bootstrap node is ready once it runs a bootstrapper for a
+// config.
+func NewBootstrapNodeHandler(status func(context.Context) (types.BootstrapNodeStatus, error)) http.Handler {
+	return newHandler(func(ctx context.Context) (interface{}, error) {
+		s, err := status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return bootstrapNodeStatusView(s), nil
+	}, func(ctx context.Context) error {
+		s, err := status(ctx)
+		if err != nil {
+			return err
+		}
+		if !s.Running {
+			return fmt.Errorf("not running a bootstrapper")
+		}
+		return nil
+	})
+}
+
+func newHandler(
+	status func(context.Context) (interface{}, error),
+	ready func(context.Context) error,
+) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+		defer cancel()
+		if _, err := status(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+		defer cancel()
+		if err := ready(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+		defer cancel()
+		view, err := status(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(view)
+	})
+	return mux
+}
+
+// The views below determine the JSON representation of the status, e.g. so
+// that config digests are rendered in hex.
+
+type oracleView struct {
+	Running              bool                      `json:"running"`
+	ConfigDigest         string                    `json:"configDigest,omitempty"`
+	OracleID             types.OracleID            `json:"oracleID"`
+	F                    int                       `json:"f"`
+	Epoch                uint32                    `json:"epoch"`
+	Leader               types.OracleID            `json:"leader"`
+	NewEpochs            []uint32                  `json:"newEpochs"`
+	Round                uint8                     `json:"round"`
+	Phase                string                    `json:"phase"`
+	LeaderPhase          string                    `json:"leaderPhase,omitempty"`
+	PendingTransmissions []pendingTransmissionView `json:"pendingTransmissions"`
+	RecentRounds         []roundOutcomeView        `json:"recentRounds"`
+	Network              *networkView              `json:"network,omitempty"`
+}
+
+type pendingTransmissionView struct {
+	Epoch  uint32    `json:"epoch"`
+	Round  uint8     `json:"round"`
+	Time   time.Time `json:"time"`
+	Median string    `json:"median"`
+}
+
+type roundOutcomeView struct {
+	Epoch   uint32    `json:"epoch"`
+	Round   uint8     `json:"round"`
+	Time    time.Time `json:"time"`
+	Outcome string    `json:"outcome"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+type bootstrapNodeView struct {
+	Running      bool         `json:"running"`
+	ConfigDigest string       `json:"configDigest,omitempty"`
+	Network      *networkView `json:"network,omitempty"`
+}
+
+type networkView struct {
+	Peers            []peerView `json:"peers"`
+	RoutingTableSize int        `json:"routingTableSize"`
+}
+
+type peerView struct {
+	PeerID    string `json:"peerID"`
+	Connected bool   `json:"connected"`
+}
+
+func oracleStatusView(s types.OracleStatus) oracleView {
+	if !s.Running {
+		return oracleView{}
+	}
+	pending := []pendingTransmissionView{}
+	for _, p := range s.PendingTransmissions {
+		median := ""
+		if p.Median != nil {
+			median = (*big.Int)(p.Median).String()
+		}
+		pending = append(pending, pendingTransmissionView{p.Epoch, p.Round, p.Time, median})
+	}
+	recent := []roundOutcomeView{}
+	for _, r := range s.RecentRounds {
+		recent = append(recent, roundOutcomeView{r.Epoch, r.Round, r.Time, string(r.Outcome), r.Reason})
+	}
+	return oracleView{
+		s.Running,
+		s.ConfigDigest.Hex(),
+		s.OracleID,
+		s.F,
+		s.Epoch,
+		s.Leader,
+		s.NewEpochs,
+		s.Round,
+		s.Phase,
+		s.LeaderPhase,
+		pending,
+		recent,
+		networkStatusView(s.Network),
+	}
+}
+
+func bootstrapNodeStatusView(s types.BootstrapNodeStatus) bootstrapNodeView {
+	if !s.Running {
+		return bootstrapNodeView{}
+	}
+	return bootstrapNodeView{
+		s.Running,
+		s.ConfigDigest.Hex(),
+		networkStatusView(s.Network),
+	}
+}
+
+func networkStatusView(s *types.NetworkStatus) *networkView {
+	if s == nil {
+		return nil
+	}
+	peers := []peerView{}
+	for _, p := range s.Peers {
+		peers = append(peers, peerView{p.PeerID, p.Connected})
+	}
+	return &networkView{peers, s.RoutingTableSize}
+}
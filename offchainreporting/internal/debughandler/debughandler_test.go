@@ -0,0 +1,180 @@
+package debughandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func get(ctx context.Context, handler http.Handler, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// fakeOracle mimics the lifecycle of offchainreporting.Oracle.Status
+type fakeOracle struct {
+	started, closed bool
+	status          types.OracleStatus
+}
+
+func (o *fakeOracle) Status(ctx context.Context) (types.OracleStatus, error) {
+	if !o.started {
+		return types.OracleStatus{}, errors.New("oracle hasn't been started")
+	}
+	if o.closed {
+		return types.OracleStatus{}, errors.New("oracle has been closed")
+	}
+	return o.status, nil
+}
+
+func runningStatus() types.OracleStatus {
+	return types.OracleStatus{
+		Running:      true,
+		ConfigDigest: types.ConfigDigest{0xab, 0xcd},
+		OracleID:     1,
+		F:            1,
+		Epoch:        3,
+		Leader:       2,
+		NewEpochs:    []uint32{3, 3, 3, 2},
+		Round:        4,
+		Phase:        "report",
+		PendingTransmissions: []types.PendingTransmissionStatus{
+			{3, 2, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), big.NewInt(-42)},
+		},
+		RecentRounds: []types.RoundOutcome{
+			{3, 1, time.Date(2021, 3, 4, 5, 6, 0, 0, time.UTC), types.TransmissionOutcomeNotTransmitted, "no deviation"},
+		},
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	o := &fakeOracle{}
+	handler := NewOracleHandler(o.Status)
+	if code := get(context.Background(), handler, "/healthz").Code; code != http.StatusServiceUnavailable {
+		t.Errorf("/healthz before start returned %d, expected %d", code, http.StatusServiceUnavailable)
+	}
+	// An oracle without a config is alive, although not ready
+	o.started = true
+	if code := get(context.Background(), handler, "/healthz").Code; code != http.StatusOK {
+		t.Errorf("/healthz after start returned %d, expected %d", code, http.StatusOK)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	o := &fakeOracle{}
+	handler := NewOracleHandler(o.Status)
+	check := func(stage string, expected int) {
+		t.Helper()
+		rec := get(context.Background(), handler, "/readyz")
+		if rec.Code != expected {
+			t.Errorf("/readyz %s returned %d (%q), expected %d", stage, rec.Code, rec.Body.String(), expected)
+		}
+	}
+
+	check("before start", http.StatusServiceUnavailable)
+	o.started = true
+	check("before a config was found", http.StatusServiceUnavailable)
+	o.status = runningStatus()
+	check("after start", http.StatusOK)
+
+	o.status.Network = &types.NetworkStatus{[]types.PeerStatus{
+		{"peer0", true},
+		{"peer1", true}, // ourselves, doesn't count
+		{"peer2", false},
+		{"peer3", false},
+	}, 0}
+	check("while connected to fewer than 2f other oracles", http.StatusServiceUnavailable)
+	o.status.Network.Peers[2].Connected = true
+	check("while connected to 2f other oracles", http.StatusOK)
+
+	o.closed = true
+	check("after close", http.StatusServiceUnavailable)
+}
+
+func TestStatusJSON(t *testing.T) {
+	o := &fakeOracle{true, false, runningStatus()}
+	rec := get(context.Background(), NewOracleHandler(o.Status), "/status")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/status returned %d, expected %d", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("/status has Content-Type %q, expected application/json", ct)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"running":      true,
+		"configDigest": "abcd0000000000000000000000000000",
+		"oracleID":     1.0,
+		"f":            1.0,
+		"epoch":        3.0,
+		"leader":       2.0,
+		"newEpochs":    []interface{}{3.0, 3.0, 3.0, 2.0},
+		"round":        4.0,
+		"phase":        "report",
+		"pendingTransmissions": []interface{}{map[string]interface{}{
+			"epoch":  3.0,
+			"round":  2.0,
+			"time":   "2021-03-04T05:06:07Z",
+			"median": "-42",
+		}},
+		"recentRounds": []interface{}{map[string]interface{}{
+			"epoch":   3.0,
+			"round":   1.0,
+			"time":    "2021-03-04T05:06:00Z",
+			"outcome": "not transmitted",
+			"reason":  "no deviation",
+		}},
+	}
+	gotJSON, _ := json.Marshal(got)
+	expectedJSON, _ := json.Marshal(expected)
+	if string(gotJSON) != string(expectedJSON) {
+		t.Errorf("/status returned\n%s\nexpected\n%s", gotJSON, expectedJSON)
+	}
+
+	// A stopped oracle only reports that it isn't running
+	o.status = types.OracleStatus{}
+	rec = get(context.Background(), NewOracleHandler(o.Status), "/status")
+	got = nil
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["running"] != false || got["configDigest"] != nil || got["network"] != nil {
+		t.Errorf("/status of a stopped oracle returned %s", rec.Body.String())
+	}
+}
+
+func TestStatusErrors(t *testing.T) {
+	failing := NewOracleHandler(func(context.Context) (types.OracleStatus, error) {
+		return types.OracleStatus{}, errors.New("status failed")
+	})
+	// Like offchainreporting.Oracle.Status, blocks until ctx is done if the
+	// oracle doesn't respond
+	hanging := NewOracleHandler(func(ctx context.Context) (types.OracleStatus, error) {
+		<-ctx.Done()
+		return types.OracleStatus{}, ctx.Err()
+	})
+	for _, path := range []string{"/healthz", "/readyz", "/status"} {
+		if code := get(context.Background(), failing, path).Code; code == http.StatusOK {
+			t.Errorf("%s returned %d although status failed", path, code)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		rec := get(ctx, hanging, path)
+		cancel()
+		if rec.Code == http.StatusOK {
+			t.Errorf("%s returned %d although status timed out", path, rec.Code)
+		}
+	}
+}
@@ -1,46 +1,251 @@
 package managed
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"time"
 
 	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/internal/shim"
+	"github.com/SeerLink/libocr/offchainreporting/metrics"
+	"github.com/SeerLink/libocr/offchainreporting/telemetry"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 	"google.golang.org/protobuf/proto"
 )
 
-// forwardTelemetry receives monitoring events on chTelemetry, serializes them, and forwards
-// them to monitoringEndpoint
+const (
+	telemetryQueueCapacity        = 100
+	defaultTelemetryBatchSize     = 100
+	defaultTelemetryBatchInterval = 1 * time.Second
+)
+
+func newTelemetryDroppedCounter(m types.Metrics) types.Counter {
+	return metrics.OrNop(m).Counter(types.MetricOpts{
+		Name: "ocr_telemetry_dropped_total",
+		Help: "Number of telemetry events dropped because the monitoring endpoint didn't keep up",
+	})
+}
+
+// forwardTelemetry receives monitoring events from queue, serializes them,
+// and forwards them to monitoringEndpoint. If monitoringEndpoint implements
+// types.MonitoringEndpointBatcher, events are sent in batches. Whenever
+// queue has dropped events since the last check, which happens at least
+// once per batch interval, the total number of dropped events is reported to
+// monitoringEndpoint and counted in droppedCounter. If
+// localConfig.SignTelemetry is set, everything sent is wrapped in an envelope
+// signed with privateKeys. Signed reports of dropped events are attributed to
+// the source of the latest event, so they are held back until the first event
+// arrives.
 func forwardTelemetry(
 	ctx context.Context,
 
+	droppedCounter types.Counter,
+	localConfig types.LocalConfig,
 	logger types.Logger,
 	monitoringEndpoint types.MonitoringEndpoint,
+	privateKeys types.PrivateKeys,
 
-	chTelemetry <-chan *protobuf.TelemetryWrapper,
+	queue shim.TelemetryQueue,
 ) {
+	batchSize := localConfig.TelemetryBatchSize
+	if batchSize == 0 {
+		batchSize = defaultTelemetryBatchSize
+	}
+	batchInterval := localConfig.TelemetryBatchInterval
+	if batchInterval == 0 {
+		batchInterval = defaultTelemetryBatchInterval
+	}
+	batcher, _ := monitoringEndpoint.(types.MonitoringEndpointBatcher)
+
+	f := telemetryForwarder{
+		batcher,
+		droppedCounter,
+		localConfig,
+		logger,
+		monitoringEndpoint,
+		privateKeys,
+		queue,
+		nil,
+		shim.TelemetrySource{},
+		false,
+		0,
+		0,
+	}
+
+	ticker := time.NewTicker(batchInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
-		case t, ok := <-chTelemetry:
+		case event, ok := <-queue.Chan():
 			if !ok {
 				// This isn't supposed to happen, but we still handle this case gracefully,
 				// just in case...
-				logger.Error("forwardTelemetry: chTelemetry closed unexpectedly. exiting", nil)
+				logger.Error("forwardTelemetry: telemetry channel closed unexpectedly. exiting", nil)
 				return
 			}
-			bin, err := proto.Marshal(t)
-			if err != nil {
-				logger.Error("forwardTelemetry: failed to Marshal protobuf", types.LogFields{
-					"proto": t,
-					"error": err,
-				})
+			if batcher == nil {
+				f.setSource(event.Source)
+				f.sendLog(event.Telemetry)
 				break
 			}
-			if monitoringEndpoint != nil {
-				monitoringEndpoint.SendLog(bin)
+			// Batches are signed as a whole, so all events in a batch must come
+			// from the same source
+			if event.Source != f.source {
+				f.flush()
+			}
+			f.setSource(event.Source)
+			f.batch = append(f.batch, event.Telemetry)
+			if len(f.batch) >= batchSize {
+				f.flush()
 			}
+		case <-ticker.C:
+			f.flush()
 		case <-ctx.Done():
+			f.flush()
 			logger.Info("forwardTelemetry: exiting", nil)
 			return
 		}
 	}
 }
+
+type telemetryForwarder struct {
+	batcher            types.MonitoringEndpointBatcher
+	droppedCounter     types.Counter
+	localConfig        types.LocalConfig
+	logger             types.Logger
+	monitoringEndpoint types.MonitoringEndpoint
+	privateKeys        types.PrivateKeys
+	queue              shim.TelemetryQueue
+
+	batch []*protobuf.TelemetryWrapper
+	// source is the source of the events in batch, or of the last event sent
+	// if not batching. Telemetry without source of its own, such as reports of
+	// dropped events, is attributed to it.
+	source shim.TelemetrySource
+	// sourceKnown is set once the first event arrived. Until then, source is
+	// the zero value.
+	sourceKnown bool
+	// countedDropped is the number of dropped events counted in droppedCounter
+	countedDropped uint64
+	// reportedDropped is the number of dropped events last reported to
+	// monitoringEndpoint, when not batching
+	reportedDropped uint64
+}
+
+func (f *telemetryForwarder) setSource(source shim.TelemetrySource) {
+	f.source = source
+	f.sourceKnown = true
+}
+
+// checkDropped counts the events dropped since the last check. When not
+// batching, it also reports the total to monitoringEndpoint if it changed.
+// When batching, the total is included in the next batch instead.
+func (f *telemetryForwarder) checkDropped() {
+	dropped := f.queue.Dropped()
+	if dropped != f.countedDropped {
+		f.droppedCounter.Add(float64(dropped - f.countedDropped))
+		f.logger.Warn("forwardTelemetry: dropped telemetry because the queue was full", types.LogFields{
+			"droppedSinceLastCheck": dropped - f.countedDropped,
+			"droppedTotal":          dropped,
+		})
+		f.countedDropped = dropped
+	}
+	if f.batcher != nil || dropped == f.reportedDropped {
+		return
+	}
+	if f.localConfig.SignTelemetry && !f.sourceKnown {
+		// A report signed for the zero source couldn't be verified
+		return
+	}
+	f.sendLog(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_Dropped{&protobuf.TelemetryDropped{
+			Count: dropped,
+		}},
+	})
+	f.reportedDropped = dropped
+}
+
+func (f *telemetryForwarder) flush() {
+	f.checkDropped()
+	if f.batcher == nil || len(f.batch) == 0 {
+		return
+	}
+	f.sendLogBatch(&protobuf.TelemetryBatch{
+		Telemetry: f.batch,
+		Dropped:   f.countedDropped,
+	})
+	f.batch = nil
+}
+
+func (f *telemetryForwarder) sendLog(t *protobuf.TelemetryWrapper) {
+	bin, err := proto.Marshal(t)
+	if err != nil {
+		f.logger.Error("forwardTelemetry: failed to Marshal protobuf", types.LogFields{
+			"proto": t,
+			"error": err,
+		})
+		return
+	}
+	bin, ok := f.seal(false, bin)
+	if !ok {
+		return
+	}
+	if f.monitoringEndpoint != nil {
+		f.monitoringEndpoint.SendLog(bin)
+	}
+}
+
+func (f *telemetryForwarder) sendLogBatch(batch *protobuf.TelemetryBatch) {
+	bin, err := proto.Marshal(batch)
+	if err != nil {
+		f.logger.Error("forwardTelemetry: failed to Marshal protobuf", types.LogFields{
+			"batchSize": len(batch.Telemetry),
+			"error":     err,
+		})
+		return
+	}
+	bin, ok := f.seal(true, bin)
+	if !ok {
+		return
+	}
+	compress := f.localConfig.TelemetryCompression
+	if compress {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(bin); err != nil {
+			f.logger.Error("forwardTelemetry: failed to compress batch", types.LogFields{"error": err})
+			return
+		}
+		if err := w.Close(); err != nil {
+			f.logger.Error("forwardTelemetry: failed to compress batch", types.LogFields{"error": err})
+			return
+		}
+		bin = buf.Bytes()
+	}
+	f.batcher.SendLogBatch(bin, compress)
+}
+
+// seal wraps payload in a signed envelope if signing is enabled. The envelope
+// is signed before compression, so that signatures don't depend on the
+// compressor.
+func (f *telemetryForwarder) seal(batch bool, payload []byte) ([]byte, bool) {
+	if !f.localConfig.SignTelemetry {
+		return payload, true
+	}
+	envelope, err := telemetry.Sign(
+		f.privateKeys.SignOffChain,
+		f.source.ConfigDigest,
+		f.source.OracleID,
+		batch,
+		payload,
+	)
+	if err != nil {
+		f.logger.Error("forwardTelemetry: failed to sign telemetry", types.LogFields{
+			"error": err,
+		})
+		return nil, false
+	}
+	return envelope, true
+}
@@ -0,0 +1,122 @@
+package managed
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/internal/shim"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/telemetry"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"google.golang.org/protobuf/proto"
+)
+
+type countingCounter struct{ total float64 }
+
+func (c *countingCounter) Inc(...string) { c.total++ }
+
+func (c *countingCounter) Add(delta float64, _ ...string) { c.total += delta }
+
+type collectingEndpoint struct{ logs [][]byte }
+
+func (e *collectingEndpoint) SendLog(log []byte) { e.logs = append(e.logs, log) }
+
+// offchainSigner implements the offchain half of types.PrivateKeys
+type offchainSigner struct {
+	types.PrivateKeys
+	key ed25519.PrivateKey
+}
+
+func (s offchainSigner) SignOffChain(msg []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, msg), nil
+}
+
+func TestForwarderHoldsSignedDroppedReportUntilSourceIsKnown(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter := &countingCounter{}
+	endpoint := &collectingEndpoint{}
+	// A queue without capacity drops everything nobody is waiting for
+	queue := shim.NewTelemetryQueue(0)
+	f := telemetryForwarder{
+		nil,
+		counter,
+		types.LocalConfig{SignTelemetry: true},
+		testlogger.Nop{},
+		endpoint,
+		offchainSigner{nil, privateKey},
+		queue,
+		nil,
+		shim.TelemetrySource{},
+		false,
+		0,
+		0,
+	}
+
+	queue.Enqueue(&protobuf.TelemetryWrapper{})
+	f.checkDropped()
+	if counter.total != 1 {
+		t.Errorf("counted %v dropped events, expected 1", counter.total)
+	}
+	if len(endpoint.logs) != 0 {
+		t.Fatalf("sent a dropped report before the source was known")
+	}
+
+	source := shim.TelemetrySource{types.ConfigDigest{1, 2, 3}, 2}
+	f.setSource(source)
+	f.checkDropped()
+	if counter.total != 1 {
+		t.Errorf("counted %v dropped events, expected them to be counted only once", counter.total)
+	}
+	if len(endpoint.logs) != 1 {
+		t.Fatalf("sent %d logs, expected the held dropped report", len(endpoint.logs))
+	}
+
+	publicKeys := []types.OffchainPublicKey{nil, nil, types.OffchainPublicKey(publicKey)}
+	e, err := telemetry.DecodeAndVerify(endpoint.logs[0], publicKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ConfigDigest != source.ConfigDigest || e.OracleID != source.OracleID {
+		t.Errorf("dropped report attributed to %v, oracle %d", e.ConfigDigest, e.OracleID)
+	}
+	var wrapper protobuf.TelemetryWrapper
+	if err := proto.Unmarshal(e.Payload, &wrapper); err != nil {
+		t.Fatal(err)
+	}
+	if dropped := wrapper.GetDropped(); dropped == nil || dropped.Count != 1 {
+		t.Errorf("dropped report is %v, expected a count of 1", wrapper.String())
+	}
+
+	f.checkDropped()
+	if len(endpoint.logs) != 1 {
+		t.Errorf("reported an unchanged number of dropped events again")
+	}
+}
+
+func TestForwarderReportsUnsignedDropsWithoutSource(t *testing.T) {
+	endpoint := &collectingEndpoint{}
+	queue := shim.NewTelemetryQueue(0)
+	f := telemetryForwarder{
+		nil,
+		&countingCounter{},
+		types.LocalConfig{},
+		testlogger.Nop{},
+		endpoint,
+		nil,
+		queue,
+		nil,
+		shim.TelemetrySource{},
+		false,
+		0,
+		0,
+	}
+	queue.Enqueue(&protobuf.TelemetryWrapper{})
+	f.checkDropped()
+	if len(endpoint.logs) != 1 {
+		t.Errorf("sent %d logs, expected the dropped report", len(endpoint.logs))
+	}
+}
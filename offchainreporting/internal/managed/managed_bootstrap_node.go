@@ -15,6 +15,7 @@ func RunManagedBootstrapNode(
 
 	bootstrapperFactory types.BootstrapperFactory,
 	bootstrappers []string,
+	chStatusRequests <-chan BootstrapNodeStatusRequest,
 	contractConfigTracker types.ContractConfigTracker,
 	database types.Database,
 	localConfig types.LocalConfig,
@@ -25,6 +26,7 @@ func RunManagedBootstrapNode(
 
 		bootstrapperFactory: bootstrapperFactory,
 		bootstrappers:       bootstrappers,
+		chStatusRequests:    chStatusRequests,
 		configTracker:       contractConfigTracker,
 		database:            database,
 		localConfig:         localConfig,
@@ -38,6 +40,7 @@ type managedBootstrapNodeState struct {
 
 	bootstrappers       []string
 	bootstrapperFactory types.BootstrapperFactory
+	chStatusRequests    <-chan BootstrapNodeStatusRequest
 	configTracker       types.ContractConfigTracker
 	database            types.Database
 	localConfig         types.LocalConfig
@@ -83,6 +86,8 @@ func (mb *managedBootstrapNodeState) run() {
 				"newConfigDigest": cc.ConfigDigest.Hex(),
 			})
 			mb.configChanged(cc)
+		case req := <-mb.chStatusRequests:
+			req.Response <- mb.status()
 		case <-mb.ctx.Done():
 			mb.logger.Debug("ManagedBootstrapNode: winding down ", nil)
 			mb.closeBootstrapper()
@@ -93,6 +98,28 @@ func (mb *managedBootstrapNodeState) run() {
 	}
 }
 
+// BootstrapNodeStatusRequest asks a managed bootstrap node for a snapshot of
+// its state. The node sends the snapshot on Response, which must be buffered.
+type BootstrapNodeStatusRequest struct {
+	Response chan<- types.BootstrapNodeStatus
+}
+
+func (mb *managedBootstrapNodeState) status() types.BootstrapNodeStatus {
+	if mb.bootstrapper == nil {
+		return types.BootstrapNodeStatus{}
+	}
+	var network *types.NetworkStatus
+	if reporter, ok := mb.bootstrapper.(types.NetworkStatusReporter); ok {
+		networkStatus := reporter.NetworkStatus()
+		network = &networkStatus
+	}
+	return types.BootstrapNodeStatus{
+		true,
+		mb.config.ConfigDigest,
+		network,
+	}
+}
+
 func (mb *managedBootstrapNodeState) closeBootstrapper() {
 	if mb.bootstrapper != nil {
 		err := mb.bootstrapper.Close()
@@ -3,12 +3,14 @@ package managed
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/SeerLink/libocr/clock"
 	"github.com/SeerLink/libocr/offchainreporting/internal/config"
 	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
-	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
 	"github.com/SeerLink/libocr/offchainreporting/internal/shim"
+	"github.com/SeerLink/libocr/offchainreporting/internal/trace"
 	"github.com/SeerLink/libocr/offchainreporting/loghelper"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 	"github.com/SeerLink/libocr/subprocesses"
@@ -21,29 +23,46 @@ func RunManagedOracle(
 	ctx context.Context,
 
 	bootstrappers []string,
+	chStatusRequests <-chan protocol.StatusRequest,
 	configTracker types.ContractConfigTracker,
 	contractTransmitter types.ContractTransmitter,
 	database types.Database,
 	datasource types.DataSource,
+	evidence *shim.EvidenceStore,
 	localConfig types.LocalConfig,
 	logger types.Logger,
+	metrics types.Metrics,
 	monitoringEndpoint types.MonitoringEndpoint,
 	netEndpointFactory types.BinaryNetworkEndpointFactory,
 	privateKeys types.PrivateKeys,
+	spanExporter types.SpanExporter,
+	messageTraceWriter io.Writer,
 ) {
+	var messageTraceRecorder *trace.Recorder
+	if messageTraceWriter != nil {
+		messageTraceRecorder = trace.NewRecorder(messageTraceWriter, clock.Real, logger)
+	}
 	mo := managedOracleState{
 		ctx: ctx,
 
 		bootstrappers:       bootstrappers,
+		chStatusRequests:    chStatusRequests,
 		configTracker:       configTracker,
 		contractTransmitter: contractTransmitter,
-		database:            database,
+		database:            newMetricsDatabase(database, metrics),
 		datasource:          datasource,
+		evidence:            evidence,
 		localConfig:         localConfig,
 		logger:              logger,
+		metrics:             protocol.NewMetrics(metrics),
+		telemetryDropped:    newTelemetryDroppedCounter(metrics),
+		telemetry:           shim.NewTelemetryQueue(telemetryQueueCapacity),
 		monitoringEndpoint:  monitoringEndpoint,
 		netEndpointFactory:  netEndpointFactory,
 		privateKeys:         privateKeys,
+		spanExporter:        spanExporter,
+
+		messageTraceRecorder: messageTraceRecorder,
 	}
 	mo.run()
 }
@@ -52,25 +71,37 @@ type managedOracleState struct {
 	ctx context.Context
 
 	bootstrappers       []string
+	chStatusRequests    <-chan protocol.StatusRequest
 	config              config.SharedConfig
 	configTracker       types.ContractConfigTracker
 	contractTransmitter types.ContractTransmitter
 	database            types.Database
 	datasource          types.DataSource
+	evidence            *shim.EvidenceStore
 	localConfig         types.LocalConfig
 	logger              types.Logger
+	metrics             *protocol.Metrics
 	monitoringEndpoint  types.MonitoringEndpoint
 	netEndpointFactory  types.BinaryNetworkEndpointFactory
 	privateKeys         types.PrivateKeys
+	spanExporter        types.SpanExporter
 
-	chTelemetry        chan<- *protobuf.TelemetryWrapper
+	messageTraceRecorder *trace.Recorder
+
+	binNetEndpoint     types.BinaryNetworkEndpoint
+	chProtocolStatus   chan protocol.StatusRequest
 	netEndpoint        *shim.SerializingEndpoint
 	oracleCancel       context.CancelFunc
+	oracleCtx          context.Context
 	oracleSubprocesses subprocesses.Subprocesses
 	otherSubprocesses  subprocesses.Subprocesses
+	telemetry          shim.TelemetryQueue
+	telemetryDropped   types.Counter
 }
 
 func (mo *managedOracleState) run() {
+	mo.chProtocolStatus = make(chan protocol.StatusRequest)
+
 	// Restore config from database, so that we can run even if the ethereum node
 	// isn't working.
 	{
@@ -91,10 +122,16 @@ func (mo *managedOracleState) run() {
 		}
 	}
 
-	chTelemetry := make(chan *protobuf.TelemetryWrapper, 100)
-	mo.chTelemetry = chTelemetry
 	mo.otherSubprocesses.Go(func() {
-		forwardTelemetry(mo.ctx, mo.logger, mo.monitoringEndpoint, chTelemetry)
+		forwardTelemetry(
+			mo.ctx,
+			mo.telemetryDropped,
+			mo.localConfig,
+			mo.logger,
+			mo.monitoringEndpoint,
+			mo.privateKeys,
+			mo.telemetry,
+		)
 	})
 
 	chNewConfig := make(chan types.ContractConfig, 5)
@@ -114,10 +151,15 @@ func (mo *managedOracleState) run() {
 				"newConfigDigest": change.ConfigDigest.Hex(),
 			})
 			mo.configChanged(change)
+		case req := <-mo.chStatusRequests:
+			mo.forwardStatusRequest(req)
 		case <-mo.ctx.Done():
 			mo.logger.Info("ManagedOracle: winding down", nil)
 			mo.closeOracle()
 			mo.otherSubprocesses.Wait()
+			if mo.messageTraceRecorder != nil {
+				mo.messageTraceRecorder.Close()
+			}
 			mo.logger.Info("ManagedOracle: exiting", nil)
 			return // Exit ManagedOracle event loop altogether
 		}
@@ -136,13 +178,37 @@ func (mo *managedOracleState) closeOracle() {
 			// nothing to be done about it, let's try to carry on.
 		}
 		mo.oracleCancel = nil
+		mo.oracleCtx = nil
+		mo.binNetEndpoint = nil
 		mo.netEndpoint = nil
 	}
 }
 
+// logIfSharedSecretRotation logs config changes which only deal a new shared
+// secret, as made by confighelper.ContractSetConfigArgsForSharedSecretRotation
+func (mo *managedOracleState) logIfSharedSecretRotation(previousConfig config.SharedConfig) {
+	if previousConfig.SharedSecret == nil {
+		return
+	}
+	diff := config.DiffPublicConfigs(previousConfig.PublicConfig, mo.config.PublicConfig, 0)
+	if len(diff.Parameters) != 0 || len(diff.Oracles) != 0 {
+		return
+	}
+	fields := types.LogFields{
+		"previousConfigDigest": fmt.Sprintf("%x", previousConfig.ConfigDigest),
+		"configDigest":         fmt.Sprintf("%x", mo.config.ConfigDigest),
+	}
+	if *previousConfig.SharedSecret == *mo.config.SharedSecret {
+		mo.logger.Warn("ManagedOracle: config change keeps parameters, oracles, and shared secret unchanged", fields)
+		return
+	}
+	mo.logger.Info("ManagedOracle: config change rotates the shared secret", fields)
+}
+
 func (mo *managedOracleState) configChanged(contractConfig types.ContractConfig) {
 	// Cease any operation from earlier configs
 	mo.closeOracle()
+	previousConfig := mo.config
 
 	// Decode contractConfig
 	var err error
@@ -159,6 +225,7 @@ func (mo *managedOracleState) configChanged(contractConfig types.ContractConfig)
 		})
 		return
 	}
+	mo.logIfSharedSecretRotation(previousConfig)
 
 	// Run with new config
 	peerIDs := []string{}
@@ -173,7 +240,7 @@ func (mo *managedOracleState) configChanged(contractConfig types.ContractConfig)
 
 	binNetEndpoint, err := mo.netEndpointFactory.MakeEndpoint(mo.config.ConfigDigest, peerIDs,
 		mo.bootstrappers, mo.config.F, computeTokenBucketRefillRate(mo.config.PublicConfig),
-		computeTokenBucketSize())
+		computeTokenBucketSize(mo.config.PublicConfig))
 	if err != nil {
 		mo.logger.Error("ManagedOracle: error during MakeEndpoint", types.LogFields{
 			"error":         err,
@@ -184,8 +251,10 @@ func (mo *managedOracleState) configChanged(contractConfig types.ContractConfig)
 		return
 	}
 
+	telemetry := mo.telemetry.WithSource(shim.TelemetrySource{mo.config.ConfigDigest, oid})
+
 	netEndpoint := shim.NewSerializingEndpoint(
-		mo.chTelemetry,
+		telemetry,
 		mo.config.ConfigDigest,
 		binNetEndpoint,
 		childLogger,
@@ -199,13 +268,24 @@ func (mo *managedOracleState) configChanged(contractConfig types.ContractConfig)
 		return
 	}
 
+	mo.binNetEndpoint = binNetEndpoint
 	mo.netEndpoint = netEndpoint
 	oracleCtx, oracleCancel := context.WithCancel(mo.ctx)
 	mo.oracleCancel = oracleCancel
+	mo.oracleCtx = oracleCtx
+	protocolClock := clock.Real
+	var protocolEndpoint protocol.NetworkEndpoint = mo.netEndpoint
+	if mo.messageTraceRecorder != nil {
+		mo.messageTraceRecorder.Start(mo.config.ConfigDigest, oid)
+		protocolClock = mo.messageTraceRecorder.Clock(protocolClock)
+		protocolEndpoint = mo.messageTraceRecorder.Endpoint(oracleCtx, protocolEndpoint)
+	}
 	mo.oracleSubprocesses.Go(func() {
 		defer oracleCancel()
 		protocol.RunOracle(
 			oracleCtx,
+			mo.chProtocolStatus,
+			protocolClock,
 			mo.config,
 			mo.contractTransmitter,
 			mo.database,
@@ -214,8 +294,10 @@ func (mo *managedOracleState) configChanged(contractConfig types.ContractConfig)
 			mo.privateKeys,
 			mo.localConfig,
 			childLogger,
-			mo.netEndpoint,
-			shim.MakeTelemetrySender(mo.chTelemetry),
+			mo.metrics,
+			protocolEndpoint,
+			mo.spanExporter,
+			shim.MakeTelemetrySender(protocolClock, mo.evidence, childLogger, telemetry),
 		)
 	})
 
@@ -230,14 +312,57 @@ func (mo *managedOracleState) configChanged(contractConfig types.ContractConfig)
 	}
 }
 
+// forwardStatusRequest passes req on to the protocol instance for the current
+// config, and adds the status of the network endpoint to its response. If no
+// instance is running, or the instance is closed before it responds, the
+// response indicates that the oracle isn't running.
+func (mo *managedOracleState) forwardStatusRequest(req protocol.StatusRequest) {
+	if mo.oracleCtx == nil {
+		req.Response <- types.OracleStatus{}
+		return
+	}
+	oracleCtx := mo.oracleCtx
+	reporter, _ := mo.binNetEndpoint.(types.NetworkStatusReporter)
+	mo.otherSubprocesses.Go(func() {
+		chResponse := make(chan types.OracleStatus, 1)
+		select {
+		case mo.chProtocolStatus <- protocol.StatusRequest{req.Ctx, chResponse}:
+		case <-oracleCtx.Done():
+			req.Response <- types.OracleStatus{}
+			return
+		case <-req.Ctx.Done():
+			return
+		}
+		select {
+		case status := <-chResponse:
+			if reporter != nil {
+				networkStatus := reporter.NetworkStatus()
+				status.Network = &networkStatus
+			}
+			req.Response <- status
+		case <-oracleCtx.Done():
+			req.Response <- types.OracleStatus{}
+		case <-req.Ctx.Done():
+		}
+	})
+}
+
 func computeTokenBucketRefillRate(cfg config.PublicConfig) float64 {
-	return (1.0*float64(time.Second)/float64(cfg.DeltaResend) +
+	rate := 1.0*float64(time.Second)/float64(cfg.DeltaResend) +
 		1.0*float64(time.Second)/float64(cfg.DeltaProgress) +
 		1.0*float64(time.Second)/float64(cfg.DeltaRound) +
 		3.0*float64(time.Second)/float64(cfg.DeltaRound) +
-		2.0*float64(time.Second)/float64(cfg.DeltaRound)) * 2.0
+		2.0*float64(time.Second)/float64(cfg.DeltaRound)
+	if cfg.ReportReqCrossChecks {
+		// report-req digest
+		rate += 1.0 * float64(time.Second) / float64(cfg.DeltaRound)
+	}
+	return rate * 2.0
 }
 
-func computeTokenBucketSize() int {
+func computeTokenBucketSize(cfg config.PublicConfig) int {
+	if cfg.ReportReqCrossChecks {
+		return (2 + 7) * 2
+	}
 	return (2 + 6) * 2
 }
@@ -0,0 +1,30 @@
+package managed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+)
+
+func TestTokenBucketOnlyCoversCrossChecksIfEnabled(t *testing.T) {
+	cfg := config.PublicConfig{
+		DeltaProgress: 10 * time.Second,
+		DeltaResend:   10 * time.Second,
+		DeltaRound:    time.Second,
+	}
+	if rate := computeTokenBucketRefillRate(cfg); rate != (0.1+0.1+1+3+2)*2 {
+		t.Errorf("refill rate is %v without cross-checks", rate)
+	}
+	if size := computeTokenBucketSize(cfg); size != (2+6)*2 {
+		t.Errorf("bucket size is %v without cross-checks", size)
+	}
+
+	cfg.ReportReqCrossChecks = true
+	if rate := computeTokenBucketRefillRate(cfg); rate != (0.1+0.1+1+3+2+1)*2 {
+		t.Errorf("refill rate is %v with cross-checks", rate)
+	}
+	if size := computeTokenBucketSize(cfg); size != (2+7)*2 {
+		t.Errorf("bucket size is %v with cross-checks", size)
+	}
+}
@@ -0,0 +1,99 @@
+package managed
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/metrics"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// metricsDatabase wraps a types.Database, recording the latency and errors of
+// each call
+type metricsDatabase struct {
+	database types.Database
+	latency  types.Histogram
+	errors   types.Counter
+}
+
+var _ types.Database = metricsDatabase{}
+
+func newMetricsDatabase(database types.Database, m types.Metrics) metricsDatabase {
+	m = metrics.OrNop(m)
+	return metricsDatabase{
+		database,
+		m.Histogram(types.MetricOpts{
+			Name:       "ocr_database_call_duration_seconds",
+			Help:       "Latency of Database calls, by method",
+			LabelNames: []string{"method"},
+		}, metrics.LatencyBuckets),
+		m.Counter(types.MetricOpts{
+			Name:       "ocr_database_errors_total",
+			Help:       "Number of failed Database calls, by method",
+			LabelNames: []string{"method"},
+		}),
+	}
+}
+
+func (db metricsDatabase) record(method string, start time.Time, err error) {
+	db.latency.Observe(time.Since(start).Seconds(), method)
+	if err != nil {
+		db.errors.Inc(method)
+	}
+}
+
+func (db metricsDatabase) ReadState(ctx context.Context, configDigest types.ConfigDigest) (*types.PersistentState, error) {
+	start := time.Now()
+	state, err := db.database.ReadState(ctx, configDigest)
+	db.record("ReadState", start, err)
+	return state, err
+}
+
+func (db metricsDatabase) WriteState(ctx context.Context, configDigest types.ConfigDigest, state types.PersistentState) error {
+	start := time.Now()
+	err := db.database.WriteState(ctx, configDigest, state)
+	db.record("WriteState", start, err)
+	return err
+}
+
+func (db metricsDatabase) ReadConfig(ctx context.Context) (*types.ContractConfig, error) {
+	start := time.Now()
+	config, err := db.database.ReadConfig(ctx)
+	db.record("ReadConfig", start, err)
+	return config, err
+}
+
+func (db metricsDatabase) WriteConfig(ctx context.Context, config types.ContractConfig) error {
+	start := time.Now()
+	err := db.database.WriteConfig(ctx, config)
+	db.record("WriteConfig", start, err)
+	return err
+}
+
+func (db metricsDatabase) StorePendingTransmission(ctx context.Context, key types.PendingTransmissionKey, transmission types.PendingTransmission) error {
+	start := time.Now()
+	err := db.database.StorePendingTransmission(ctx, key, transmission)
+	db.record("StorePendingTransmission", start, err)
+	return err
+}
+
+func (db metricsDatabase) PendingTransmissionsWithConfigDigest(ctx context.Context, configDigest types.ConfigDigest) (map[types.PendingTransmissionKey]types.PendingTransmission, error) {
+	start := time.Now()
+	pending, err := db.database.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+	db.record("PendingTransmissionsWithConfigDigest", start, err)
+	return pending, err
+}
+
+func (db metricsDatabase) DeletePendingTransmission(ctx context.Context, key types.PendingTransmissionKey) error {
+	start := time.Now()
+	err := db.database.DeletePendingTransmission(ctx, key)
+	db.record("DeletePendingTransmission", start, err)
+	return err
+}
+
+func (db metricsDatabase) DeletePendingTransmissionsOlderThan(ctx context.Context, t time.Time) error {
+	start := time.Now()
+	err := db.database.DeletePendingTransmissionsOlderThan(ctx, t)
+	db.record("DeletePendingTransmissionsOlderThan", start, err)
+	return err
+}
@@ -146,7 +146,7 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 		})
 		return nil, true
 	}
-	if contractConfig.EncodedConfigVersion != config.EncodedConfigVersion {
+	if !config.IsSupportedEncodedConfigVersion(contractConfig.EncodedConfigVersion) {
 		state.logger.Error("TrackConfig: received config change with unknown EncodedConfigVersion",
 			types.LogFields{"versionReceived": contractConfig.EncodedConfigVersion})
 		return nil, false
@@ -84,6 +84,35 @@ func (aos AttributedObservations) OnChainReport(repctx ReportContext) ([]byte, e
 	return reportTypes.Pack(repctx.DomainSeparationTag(), observers, aos.onChainObservations())
 }
 
+// ParseOnChainReport returns the ReportContext of an on-chain report, as
+// returned by OnChainReport. It lets signers tell which round they are asked
+// to sign a report for. Reports which OnChainReport wouldn't have produced,
+// e.g. because they aren't canonically encoded, are rejected, so that each
+// report has a single serialization.
+func ParseOnChainReport(report []byte) (ReportContext, error) {
+	vals, err := reportTypes.Unpack(report)
+	if err != nil {
+		return ReportContext{}, errors.Wrap(err, "could not decode on-chain report")
+	}
+	tag := DomainSeparationTag(vals[0].([32]byte))
+	repctx, ok := tag.ReportContext()
+	if !ok {
+		return ReportContext{}, errors.New("on-chain report has a malformed report context")
+	}
+	// int192 values are sign-extended to 256 bits, but the decoder doesn't
+	// check the extension
+	for _, v := range vals[2].([]*big.Int) {
+		if _, err := observation.MakeObservation(v); err != nil {
+			return ReportContext{}, errors.Wrap(err, "on-chain report has an invalid observation")
+		}
+	}
+	reencoded, err := reportTypes.Pack(vals...)
+	if err != nil || !bytes.Equal(report, reencoded) {
+		return ReportContext{}, errors.New("on-chain report isn't canonically encoded")
+	}
+	return repctx, nil
+}
+
 // AttestedReportOne is the collated report oracles sign off on, after they've
 // verified the individual signatures in a report-req sent by the current leader
 type AttestedReportOne struct {
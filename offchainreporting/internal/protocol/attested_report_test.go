@@ -0,0 +1,78 @@
+package protocol
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func testOnChainReport(t *testing.T, repctx ReportContext) []byte {
+	var aos AttributedObservations
+	for i, v := range []int64{-5, 10, 20} {
+		o, err := observation.MakeObservation(big.NewInt(v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		aos = append(aos, AttributedObservation{o, types.OracleID(i)})
+	}
+	report, err := aos.OnChainReport(repctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestParseOnChainReport(t *testing.T) {
+	repctx := ReportContext{types.ConfigDigest{1, 2, 3}, 7, 9}
+	report := testOnChainReport(t, repctx)
+	parsed, err := ParseOnChainReport(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(repctx) {
+		t.Errorf("parsed report context %v, expected %v", parsed, repctx)
+	}
+}
+
+func TestParseOnChainReportRejectsNonCanonicalReports(t *testing.T) {
+	report := testOnChainReport(t, ReportContext{types.ConfigDigest{1, 2, 3}, 7, 9})
+	mutate := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte{}, report...))
+	}
+	for name, b := range map[string][]byte{
+		"empty":     {},
+		"trailing":  mutate(func(b []byte) []byte { return append(b, 0) }),
+		"truncated": mutate(func(b []byte) []byte { return b[:len(b)-1] }),
+		// the domain separation tag is the first word
+		"padding": mutate(func(b []byte) []byte { b[0] = 1; return b }),
+		// the second word is the observers, the third the offset of the
+		// observations, which must be 0x60
+		"offset": mutate(func(b []byte) []byte { b[3*32-1] = 0x80; return append(b, make([]byte, 32)...) }),
+		// the fourth word is the length of the observations, whose
+		// high-order bytes must be zero
+		"length": mutate(func(b []byte) []byte { b[3*32] = 1; return b }),
+		// the observations follow, as int192s sign-extended to 256 bits. The
+		// first one is negative, the second one positive.
+		"negative observation": mutate(func(b []byte) []byte { b[4*32] = 0; return b }),
+		"positive observation": mutate(func(b []byte) []byte { b[5*32] = 0xff; return b }),
+	} {
+		if _, err := ParseOnChainReport(b); err == nil {
+			t.Errorf("%s: non-canonical report was accepted", name)
+		}
+	}
+}
+
+func TestParseOnChainReportRejectsForeignMessages(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	report := testOnChainReport(t, ReportContext{types.ConfigDigest{1, 2, 3}, 7, 9})
+	for i := 0; i < 1000; i++ {
+		b := make([]byte, rng.Intn(2*len(report)))
+		rng.Read(b)
+		if _, err := ParseOnChainReport(b); err == nil {
+			t.Errorf("random message %x was accepted", b)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package protocol
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+///////////////////////////////////////////////////////////
+// Equivocation detection
+//
+// The leader's report-reqs are not signed, so a leader can send different
+// report-reqs to different followers without any follower noticing. To detect
+// this, followers broadcast a digest of the report-req they accepted. A
+// follower receiving a digest which doesn't match its own report-req responds
+// with its report-req, so that each side can compare the two. Since the
+// observations in report-reqs are signed, two report-reqs carrying different
+// observations by the same observer prove that the observer equivocated.
+//
+// Two different report-reqs don't prove that the leader equivocated, though:
+// report-reqs aren't signed by the leader, so a byzantine follower can make one
+// up. Differing report-reqs are therefore only logged, and never recorded as
+// evidence against the leader.
+//
+// Cross-checks are only performed if the config enables them with
+// ReportReqCrossChecks, since oracles running older versions of this library
+// can't deserialize the messages involved.
+///////////////////////////////////////////////////////////
+
+// ReportReqDigest commits to the contents of a MessageReportReq
+type ReportReqDigest [32]byte
+
+const reportReqDigestDomainSeparator = "ocr report-req digest\x00"
+
+func (msg MessageReportReq) digest() ReportReqDigest {
+	h := sha256.New()
+	var buf [4]byte
+	h.Write([]byte(reportReqDigestDomainSeparator))
+	binary.BigEndian.PutUint32(buf[:], msg.Epoch)
+	h.Write(buf[:])
+	h.Write([]byte{msg.Round})
+	for _, aso := range msg.AttributedSignedObservations {
+		binary.BigEndian.PutUint32(buf[:], uint32(aso.Observer))
+		h.Write(buf[:])
+		h.Write(aso.SignedObservation.Observation.Marshal())
+		binary.BigEndian.PutUint32(buf[:], uint32(len(aso.SignedObservation.Signature)))
+		h.Write(buf[:])
+		h.Write(aso.SignedObservation.Signature)
+	}
+	var d ReportReqDigest
+	h.Sum(d[:0])
+	return d
+}
+
+// resetCrossCheck clears the follower's cross-checking state for a new round
+func (repgen *reportGenerationState) resetCrossCheck() {
+	repgen.followerState.reportReq = nil
+	repgen.followerState.reportReqDigest = ReportReqDigest{}
+	repgen.followerState.receivedReportReqDigests = make([]*ReportReqDigest, repgen.config.N())
+	repgen.followerState.sentReportReqConflict = make([]bool, repgen.config.N())
+	repgen.followerState.receivedReportReqConflict = make([]bool, repgen.config.N())
+	repgen.followerState.reportedObserverEquivocation = make([]bool, repgen.config.N())
+}
+
+// crossCheckReportReq is called when the follower has accepted msg. It
+// broadcasts msg's digest, and compares it to the digests other followers
+// have sent so far.
+func (repgen *reportGenerationState) crossCheckReportReq(msg MessageReportReq) {
+	if !repgen.config.ReportReqCrossChecks {
+		return
+	}
+	d := msg.digest()
+	repgen.followerState.reportReq = &msg
+	repgen.followerState.reportReqDigest = d
+	repgen.netSender.Broadcast(MessageReportReqDigest{repgen.e, msg.Round, d})
+	for j, other := range repgen.followerState.receivedReportReqDigests {
+		if other != nil && *other != d {
+			repgen.sendReportReqConflict(types.OracleID(j))
+		}
+	}
+}
+
+// messageReportReqDigest is called when the oracle receives the digest of the
+// report-req another follower accepted
+func (repgen *reportGenerationState) messageReportReqDigest(msg MessageReportReqDigest, sender types.OracleID) {
+	if !repgen.config.ReportReqCrossChecks {
+		repgen.logger.Warn("messageReportReqDigest: dropping digest, cross-checks are disabled by the config", types.LogFields{
+			"sender": sender})
+		return
+	}
+	if msg.Epoch != repgen.e || msg.Round != repgen.followerState.r {
+		repgen.logger.Debug("messageReportReqDigest: dropping digest from wrong epoch or round", types.LogFields{
+			"round": repgen.followerState.r, "msgEpoch": msg.Epoch, "msgRound": msg.Round, "sender": sender})
+		return
+	}
+	if repgen.followerState.receivedReportReqDigests[sender] != nil {
+		repgen.logger.Warn("messageReportReqDigest: dropping extra digest", types.LogFields{
+			"round": repgen.followerState.r, "sender": sender})
+		return
+	}
+	repgen.followerState.receivedReportReqDigests[sender] = &msg.Digest
+	if repgen.followerState.reportReq != nil && repgen.followerState.reportReqDigest != msg.Digest {
+		repgen.sendReportReqConflict(sender)
+	}
+}
+
+func (repgen *reportGenerationState) sendReportReqConflict(to types.OracleID) {
+	if repgen.followerState.sentReportReqConflict[to] {
+		return
+	}
+	repgen.followerState.sentReportReqConflict[to] = true
+	repgen.logger.Warn("another follower accepted a different report-req than we did", types.LogFields{
+		"round": repgen.followerState.r, "follower": to})
+	repgen.netSender.SendTo(MessageReportReqConflict{*repgen.followerState.reportReq}, to)
+}
+
+// messageReportReqConflict is called when another follower responds to our
+// digest with the report-req it accepted. If that report-req is valid and
+// differs from ours, any observer whose signed observations differ between the
+// two equivocated. Either the leader or the sender may have equivocated as
+// well, but since report-reqs aren't signed, we can't tell which.
+func (repgen *reportGenerationState) messageReportReqConflict(msg MessageReportReqConflict, sender types.OracleID) {
+	if !repgen.config.ReportReqCrossChecks {
+		repgen.logger.Warn("messageReportReqConflict: dropping conflict, cross-checks are disabled by the config", types.LogFields{
+			"sender": sender})
+		return
+	}
+	theirs := msg.ReportReq
+	if theirs.Epoch != repgen.e || theirs.Round != repgen.followerState.r {
+		repgen.logger.Debug("messageReportReqConflict: dropping conflict from wrong epoch or round", types.LogFields{
+			"round": repgen.followerState.r, "msgEpoch": theirs.Epoch, "msgRound": theirs.Round, "sender": sender})
+		return
+	}
+	if repgen.followerState.reportReq == nil {
+		repgen.logger.Debug("messageReportReqConflict: dropping conflict, no report-req accepted yet", types.LogFields{
+			"round": repgen.followerState.r, "sender": sender})
+		return
+	}
+	if repgen.followerState.receivedReportReqConflict[sender] {
+		repgen.logger.Warn("messageReportReqConflict: dropping extra conflict", types.LogFields{
+			"round": repgen.followerState.r, "sender": sender})
+		return
+	}
+	repgen.followerState.receivedReportReqConflict[sender] = true
+
+	ours := *repgen.followerState.reportReq
+	if theirs.digest() == repgen.followerState.reportReqDigest {
+		repgen.logger.Warn("messageReportReqConflict: dropping conflict carrying our own report-req", types.LogFields{
+			"round": repgen.followerState.r, "sender": sender})
+		return
+	}
+	// Signature failures are counted separately, since this report-req didn't
+	// come from the leader
+	if err := repgen.verifyReportReq(theirs, "reportReqConflict"); err != nil {
+		// The leader's report-req would have been rejected by the sender, so the
+		// sender made this one up
+		repgen.recordMisbehavior("reportReqConflict", theirs.Round, sender, msg, nil, err)
+		return
+	}
+
+	repgen.logger.Warn("followers accepted different report-reqs, either the leader or the follower equivocated", types.LogFields{
+		"round": repgen.followerState.r, "leader": repgen.l, "follower": sender})
+
+	theirObservations := map[types.OracleID]SignedObservation{}
+	for _, aso := range theirs.AttributedSignedObservations {
+		theirObservations[aso.Observer] = aso.SignedObservation
+	}
+	for _, aso := range ours.AttributedSignedObservations {
+		so, ok := theirObservations[aso.Observer]
+		if !ok || so.Observation.Equal(aso.SignedObservation.Observation) ||
+			repgen.followerState.reportedObserverEquivocation[aso.Observer] {
+			continue
+		}
+		repgen.followerState.reportedObserverEquivocation[aso.Observer] = true
+		repgen.recordObserverEquivocation(aso.Observer,
+			MessageObserve{ours.Epoch, ours.Round, aso.SignedObservation},
+			MessageObserve{theirs.Epoch, theirs.Round, so})
+	}
+}
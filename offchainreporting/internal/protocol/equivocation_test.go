@@ -0,0 +1,172 @@
+package protocol
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/metrics"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// collectingSender records the messages sent through it
+type collectingSender struct{ sent []Message }
+
+func (s *collectingSender) SendTo(msg Message, _ types.OracleID) { s.sent = append(s.sent, msg) }
+
+func (s *collectingSender) Broadcast(msg Message) { s.sent = append(s.sent, msg) }
+
+// evidenceCollector records misbehavior evidence, and panics on all other
+// telemetry
+type evidenceCollector struct {
+	TelemetrySender
+	evidence []MisbehaviorEvidence
+}
+
+func (c *evidenceCollector) MisbehaviorDetected(evidence MisbehaviorEvidence) {
+	c.evidence = append(c.evidence, evidence)
+}
+
+// crossCheckFixture is a follower in round 1 of epoch 1 of a config with
+// four oracles, led by oracle 3
+type crossCheckFixture struct {
+	repgen    *reportGenerationState
+	keys      []ed25519.PrivateKey
+	sender    *collectingSender
+	collector *evidenceCollector
+}
+
+func newCrossCheckFixture(t *testing.T, crossChecks bool) crossCheckFixture {
+	const n = 4
+	keys := make([]ed25519.PrivateKey, n)
+	identities := make([]config.OracleIdentity, n)
+	for i := range keys {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = privateKey
+		identities[i].OffchainPublicKey = types.OffchainPublicKey(publicKey)
+	}
+	sender := &collectingSender{}
+	collector := &evidenceCollector{}
+	repgen := &reportGenerationState{
+		clock: clock.NewVirtual(time.Unix(0, 0)),
+		config: config.SharedConfig{PublicConfig: config.PublicConfig{
+			ReportReqCrossChecks: crossChecks,
+			OracleIdentities:     identities,
+			F:                    1,
+			ConfigDigest:         types.ConfigDigest{1},
+		}},
+		e:               1,
+		id:              0,
+		l:               3,
+		logger:          testlogger.Nop{},
+		metrics:         NewMetrics(nil),
+		netSender:       sender,
+		telemetrySender: collector,
+	}
+	repgen.followerState.r = 1
+	repgen.resetCrossCheck()
+	return crossCheckFixture{repgen, keys, sender, collector}
+}
+
+// reportReq returns a report-req for the fixture's round carrying the given
+// observations, signed by the oracles whose ids are the keys of values
+func (f crossCheckFixture) reportReq(t *testing.T, values map[types.OracleID]int64) MessageReportReq {
+	var asos []AttributedSignedObservation
+	for observer, value := range values {
+		o, err := observation.MakeObservation(big.NewInt(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := f.keys[observer]
+		so, err := MakeSignedObservation(o, f.repgen.followerReportContext(), func(msg []byte) ([]byte, error) {
+			return ed25519.Sign(key, msg), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		asos = append(asos, AttributedSignedObservation{so, observer})
+	}
+	sort.Slice(asos, func(i, j int) bool {
+		return asos[i].SignedObservation.Observation.Less(asos[j].SignedObservation.Observation)
+	})
+	return MessageReportReq{f.repgen.e, f.repgen.followerState.r, asos}
+}
+
+func TestReportReqConflictOnlyRecordsSelfAuthenticatingEvidence(t *testing.T) {
+	f := newCrossCheckFixture(t, true)
+	ours := f.reportReq(t, map[types.OracleID]int64{0: 10, 1: 20, 2: 30})
+	theirs := f.reportReq(t, map[types.OracleID]int64{0: 10, 1: 25, 2: 30})
+
+	f.repgen.crossCheckReportReq(ours)
+	if len(f.sender.sent) != 1 {
+		t.Fatalf("sent %v, expected the digest of our report-req", f.sender.sent)
+	}
+	if _, ok := f.sender.sent[0].(MessageReportReqDigest); !ok {
+		t.Fatalf("sent %T, expected MessageReportReqDigest", f.sender.sent[0])
+	}
+
+	// Whoever sends us theirs may have made it up, since report-reqs aren't
+	// signed by the leader. Only oracle 1's two signed observations prove
+	// anything.
+	f.repgen.messageReportReqConflict(MessageReportReqConflict{theirs}, 2)
+	if len(f.collector.evidence) != 1 {
+		t.Fatalf("recorded %v, expected only the observer's equivocation", f.collector.evidence)
+	}
+	e := f.collector.evidence[0]
+	if e.Check != "observerEquivocation" || e.Sender != 1 || !e.SelfAuthenticating {
+		t.Errorf("recorded %v, expected oracle 1's equivocation", e)
+	}
+}
+
+func TestInvalidReportReqConflictIsNotCountedAgainstLeader(t *testing.T) {
+	f := newCrossCheckFixture(t, true)
+	registry := metrics.NewRegistry()
+	f.repgen.metrics = NewMetrics(registry)
+	ours := f.reportReq(t, map[types.OracleID]int64{0: 10, 1: 20, 2: 30})
+	theirs := f.reportReq(t, map[types.OracleID]int64{0: 10, 1: 25, 2: 30})
+	sig := &theirs.AttributedSignedObservations[1].SignedObservation.Signature
+	*sig = append([]byte{}, *sig...)
+	(*sig)[0] ^= 1
+
+	f.repgen.crossCheckReportReq(ours)
+	f.repgen.messageReportReqConflict(MessageReportReqConflict{theirs}, 2)
+
+	var buf bytes.Buffer
+	if err := registry.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	exposition := buf.String()
+	if !strings.Contains(exposition, `ocr_signature_verification_failures_total{message="reportReqConflict"} 1`) {
+		t.Errorf("invalid signature in report-req conflict wasn't counted:\n%s", exposition)
+	}
+	if strings.Contains(exposition, `message="reportReq"`) {
+		t.Errorf("invalid signature in report-req conflict was counted against the leader:\n%s", exposition)
+	}
+}
+
+func TestReportReqCrossChecksDisabledByConfig(t *testing.T) {
+	f := newCrossCheckFixture(t, false)
+	ours := f.reportReq(t, map[types.OracleID]int64{0: 10, 1: 20, 2: 30})
+	theirs := f.reportReq(t, map[types.OracleID]int64{0: 10, 1: 25, 2: 30})
+
+	f.repgen.crossCheckReportReq(ours)
+	f.repgen.messageReportReqDigest(MessageReportReqDigest{1, 1, theirs.digest()}, 2)
+	f.repgen.messageReportReqConflict(MessageReportReqConflict{theirs}, 2)
+	if len(f.sender.sent) != 0 {
+		t.Errorf("sent %v, although the config disables cross-checks", f.sender.sent)
+	}
+	if len(f.collector.evidence) != 0 {
+		t.Errorf("recorded %v, although the config disables cross-checks", f.collector.evidence)
+	}
+}
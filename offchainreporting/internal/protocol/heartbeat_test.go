@@ -0,0 +1,130 @@
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// latestTransmissionTransmitter is a types.ContractTransmitter which only
+// reports its latest transmission
+type latestTransmissionTransmitter struct {
+	types.ContractTransmitter
+	answer    int64
+	timestamp time.Time
+}
+
+func (t latestTransmissionTransmitter) LatestTransmissionDetails(context.Context) (
+	types.ConfigDigest, uint32, uint8, types.Observation, time.Time, error,
+) {
+	return types.ConfigDigest{}, 1, 1, big.NewInt(t.answer), t.timestamp, nil
+}
+
+// The configs in these tests have an hourly heartbeat with a boundary at
+// heartbeatBoundary, and thresholds which the observed values never exceed
+var heartbeatBoundary = time.Unix(0, 0).Add(10 * time.Hour)
+
+func heartbeatConfig() config.SharedConfig {
+	return config.SharedConfig{PublicConfig: config.PublicConfig{
+		DeltaRound:    10 * time.Second,
+		DeltaC:        24 * time.Hour,
+		Heartbeat:     config.HeartbeatSchedule{Period: time.Hour},
+		AlphaPPB:      10000000,
+		DeviationRule: observation.RelativeDeviationRule(10000000),
+		ConfigDigest:  types.ConfigDigest{1},
+	}}
+}
+
+func heartbeatObservation(t *testing.T, v int64) observation.Observation {
+	o, err := observation.MakeObservation(big.NewInt(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestShouldReportForcesDueHeartbeat(t *testing.T) {
+	newRepgen := func(e uint32, now time.Time) *reportGenerationState {
+		return &reportGenerationState{
+			ctx:                 context.Background(),
+			clock:               clock.NewVirtual(now),
+			config:              heartbeatConfig(),
+			contractTransmitter: latestTransmissionTransmitter{nil, 100, heartbeatBoundary.Add(-10 * time.Minute)},
+			e:                   e,
+			logger:              testlogger.Nop{},
+			metrics:             NewMetrics(nil),
+			telemetrySender:     NopTelemetrySender{},
+		}
+	}
+	observations := []AttributedSignedObservation{}
+	for i := 0; i < 3; i++ {
+		observations = append(observations, AttributedSignedObservation{
+			SignedObservation{heartbeatObservation(t, 100), nil},
+			types.OracleID(i),
+		})
+	}
+
+	repgen := newRepgen(1, heartbeatBoundary.Add(time.Second))
+	if !repgen.shouldReport(observations) {
+		t.Errorf("must report in the first round after a heartbeat boundary")
+	}
+	repgen.config.Heartbeat = config.HeartbeatSchedule{}
+	if repgen.shouldReport(observations) {
+		t.Errorf("must not report without a heartbeat, deviation or DeltaC timeout")
+	}
+
+	// The report of the first round isn't on-chain yet. Later rounds must not
+	// be forced, whether or not that report was made, since oracles can't agree
+	// on that.
+	secondRound := heartbeatBoundary.Add(heartbeatConfig().DeltaRound + time.Second)
+	if newRepgen(1, secondRound).shouldReport(observations) {
+		t.Errorf("must not report in the second round after a heartbeat boundary")
+	}
+	if newRepgen(2, secondRound).shouldReport(observations) {
+		t.Errorf("must not report in the second round after a heartbeat boundary, even in a new epoch")
+	}
+}
+
+func TestShouldTransmitForcesDueHeartbeat(t *testing.T) {
+	report := AttestedReportMany{}
+	for i := 0; i < 3; i++ {
+		report.AttributedObservations = append(report.AttributedObservations, AttributedObservation{
+			heartbeatObservation(t, 100),
+			types.OracleID(i),
+		})
+	}
+	newTransmission := func(heartbeat config.HeartbeatSchedule) *transmissionState {
+		cfg := heartbeatConfig()
+		cfg.Heartbeat = heartbeat
+		return &transmissionState{
+			config:           cfg,
+			clock:            clock.NewVirtual(heartbeatBoundary.Add(time.Second)),
+			logger:           testlogger.Nop{},
+			latestEpochRound: EpochRound{1, 2},
+			latestMedian:     heartbeatObservation(t, 100),
+		}
+	}
+	// The contract is behind the latest report accepted for transmission, and
+	// the new report doesn't deviate from it
+	ev := EventTransmit{1, 3, report}
+	contractEpochRound := EpochRound{1, 1}
+	contractTimestamp := heartbeatBoundary.Add(-20 * time.Minute)
+
+	hourly := heartbeatConfig().Heartbeat
+	if !newTransmission(hourly).shouldTransmit(ev, contractEpochRound, contractTimestamp) {
+		t.Errorf("must transmit the first report after a heartbeat boundary")
+	}
+	if newTransmission(config.HeartbeatSchedule{}).shouldTransmit(ev, contractEpochRound, contractTimestamp) {
+		t.Errorf("must not transmit without a heartbeat or deviation while a report is pending")
+	}
+	if newTransmission(hourly).shouldTransmit(ev, contractEpochRound, heartbeatBoundary) {
+		t.Errorf("must not transmit once a report from the boundary is on-chain")
+	}
+}
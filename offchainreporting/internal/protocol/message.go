@@ -251,3 +251,41 @@ var _ EventToTransmission = (*EventTransmit)(nil) // implements EventToTransmiss
 func (ev EventTransmit) processTransmission(t *transmissionState) {
 	t.eventTransmit(ev)
 }
+
+// MessageReportReqDigest is broadcast by followers when they accept a
+// MessageReportReq. It allows them to cross-check that the leader sent the
+// same report-req to all of them. It's only sent if the config enables
+// ReportReqCrossChecks.
+type MessageReportReqDigest struct {
+	Epoch  uint32
+	Round  uint8
+	Digest ReportReqDigest
+}
+
+var _ MessageToReportGeneration = (*MessageReportReqDigest)(nil)
+
+func (msg MessageReportReqDigest) process(o *oracleState, sender types.OracleID) {
+	o.chNetToReportGeneration <- MessageToReportGenerationWithSender{msg, sender}
+}
+
+func (msg MessageReportReqDigest) processReportGeneration(repgen *reportGenerationState, sender types.OracleID) {
+	repgen.messageReportReqDigest(msg, sender)
+}
+
+// MessageReportReqConflict is sent in response to a MessageReportReqDigest
+// which doesn't match the report-req the local oracle accepted in the same
+// round. It carries that report-req, so that the receiver can compare the two
+// and collect evidence of equivocation.
+type MessageReportReqConflict struct {
+	ReportReq MessageReportReq
+}
+
+var _ MessageToReportGeneration = (*MessageReportReqConflict)(nil)
+
+func (msg MessageReportReqConflict) process(o *oracleState, sender types.OracleID) {
+	o.chNetToReportGeneration <- MessageToReportGenerationWithSender{msg, sender}
+}
+
+func (msg MessageReportReqConflict) processReportGeneration(repgen *reportGenerationState, sender types.OracleID) {
+	repgen.messageReportReqConflict(msg, sender)
+}
@@ -0,0 +1,72 @@
+package protocol
+
+import (
+	"github.com/SeerLink/libocr/offchainreporting/metrics"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// Metrics holds the metrics reported by the protocol
+type Metrics struct {
+	roundsStarted                 types.Counter
+	roundsCompleted               types.Counter
+	epochChanges                  types.Counter
+	epoch                         types.Gauge
+	reportsTransmitted            types.Counter
+	reportsSkipped                types.Counter
+	transmissionFailures          types.Counter
+	dataSourceLatency             types.Histogram
+	dataSourceErrors              types.Counter
+	signatureVerificationFailures types.Counter
+}
+
+// NewMetrics creates the protocol's metrics in m. m may be nil, in which case
+// all metrics are discarded.
+func NewMetrics(m types.Metrics) *Metrics {
+	m = metrics.OrNop(m)
+	return &Metrics{
+		m.Counter(types.MetricOpts{
+			Name: "ocr_rounds_started_total",
+			Help: "Number of rounds this oracle has started as a follower",
+		}),
+		m.Counter(types.MetricOpts{
+			Name: "ocr_rounds_completed_total",
+			Help: "Number of rounds this oracle has completed as a follower",
+		}),
+		m.Counter(types.MetricOpts{
+			Name: "ocr_epoch_changes_total",
+			Help: "Number of times the pacemaker moved to a new epoch",
+		}),
+		m.Gauge(types.MetricOpts{
+			Name: "ocr_epoch",
+			Help: "Current epoch of the pacemaker",
+		}),
+		m.Counter(types.MetricOpts{
+			Name: "ocr_reports_transmitted_total",
+			Help: "Number of reports this oracle has transmitted to the contract",
+		}),
+		m.Counter(types.MetricOpts{
+			Name:       "ocr_reports_skipped_total",
+			Help:       "Number of reports this oracle decided not to transmit, by reason",
+			LabelNames: []string{"reason"},
+		}),
+		m.Counter(types.MetricOpts{
+			Name:       "ocr_report_transmission_failures_total",
+			Help:       "Number of reports this oracle failed to transmit, by reason",
+			LabelNames: []string{"reason"},
+		}),
+		m.Histogram(types.MetricOpts{
+			Name: "ocr_datasource_observe_duration_seconds",
+			Help: "Latency of DataSource.Observe calls",
+		}, metrics.LatencyBuckets),
+		m.Counter(types.MetricOpts{
+			Name:       "ocr_datasource_errors_total",
+			Help:       "Number of failed DataSource.Observe calls, by reason",
+			LabelNames: []string{"reason"},
+		}),
+		m.Counter(types.MetricOpts{
+			Name:       "ocr_signature_verification_failures_total",
+			Help:       "Number of messages dropped because of an invalid signature, by message type",
+			LabelNames: []string{"message"},
+		}),
+	}
+}
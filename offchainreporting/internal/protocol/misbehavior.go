@@ -0,0 +1,72 @@
+package protocol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// MisbehaviorEvidence records a message from another oracle which failed
+// verification, or a pair of conflicting messages showing that an oracle
+// equivocated.
+type MisbehaviorEvidence struct {
+	Time         time.Time
+	ConfigDigest types.ConfigDigest
+	Epoch        uint32
+	Round        uint8
+	Sender       types.OracleID
+	// Check names the verification that failed, e.g. "reportReq"
+	Check  string
+	Reason string
+	Msg    Message
+	// ConflictingMsg is only set for equivocation, and conflicts with Msg
+	ConflictingMsg Message
+	// SelfAuthenticating is set iff, together with the config, the signatures
+	// contained in Msg and ConflictingMsg prove the misbehavior to third
+	// parties. OCR messages don't carry a signature by their sender, so a
+	// message failing verification only proves anything to those who trust
+	// us that we received it. Two validly signed, conflicting observations
+	// prove that their observer equivocated, though.
+	SelfAuthenticating bool
+}
+
+// recordMisbehavior reports that msg from sender failed check with err.
+// conflictingMsg is nil unless check is for equivocation. This isn't
+// self-authenticating evidence.
+func (repgen *reportGenerationState) recordMisbehavior(
+	check string, round uint8, sender types.OracleID, msg Message, conflictingMsg Message, err error,
+) {
+	repgen.telemetrySender.MisbehaviorDetected(MisbehaviorEvidence{
+		repgen.clock.Now(),
+		repgen.config.ConfigDigest,
+		repgen.e,
+		round,
+		sender,
+		check,
+		err.Error(),
+		msg,
+		conflictingMsg,
+		false,
+	})
+}
+
+// recordObserverEquivocation reports that observer signed the two different
+// observations carried by msg and conflictingMsg, for the same round. Their
+// signatures must have been verified.
+func (repgen *reportGenerationState) recordObserverEquivocation(
+	observer types.OracleID, msg MessageObserve, conflictingMsg MessageObserve,
+) {
+	repgen.telemetrySender.MisbehaviorDetected(MisbehaviorEvidence{
+		repgen.clock.Now(),
+		repgen.config.ConfigDigest,
+		msg.Epoch,
+		msg.Round,
+		observer,
+		"observerEquivocation",
+		fmt.Sprintf("oracle %d signed two different observations in the same round", observer),
+		msg,
+		conflictingMsg,
+		true,
+	})
+}
@@ -0,0 +1,357 @@
+package protocol
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// LinkConditions describes how a (directed) link between two oracles
+// mistreats the messages sent over it
+type LinkConditions struct {
+	// Probability with which a message is dropped
+	DropProbability float64
+	// Probability with which a message is delivered twice
+	DuplicateProbability float64
+	// Each copy of a message is delayed by a duration drawn uniformly from
+	// [MinDelay, MaxDelay]. Messages on a link are reordered whenever their
+	// delays differ by more than the time between their sending.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// Partition splits the oracles into groups which can't reach each other.
+// Oracles not listed in any group form one more group.
+type Partition struct {
+	// Start of the partition, relative to the creation of the network
+	Start time.Duration
+	// Duration after which the partition heals. Zero means never.
+	Duration time.Duration
+	Groups   [][]types.OracleID
+}
+
+// scheduledPartition is a Partition which may have been healed early
+type scheduledPartition struct {
+	Partition
+	healed bool
+	// Time at which the partition was healed, relative to the creation of the
+	// network. Only meaningful if healed is set.
+	healedAt time.Duration
+}
+
+func (p scheduledPartition) activeAt(elapsed time.Duration) bool {
+	if p.healed && p.healedAt <= elapsed {
+		return false
+	}
+	return p.Start <= elapsed && (p.Duration == 0 || elapsed < p.Start+p.Duration)
+}
+
+func (p Partition) group(id types.OracleID) int {
+	for i, group := range p.Groups {
+		for _, member := range group {
+			if member == id {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (p Partition) separates(a, b types.OracleID) bool {
+	return p.group(a) != p.group(b)
+}
+
+// ConditionedNetwork is an in-memory implementation of the Network interface
+// which, unlike SimpleNetwork, subjects messages to the LinkConditions of
+// their link and to Partitions. It never blocks the sender: Messages for an
+// oracle whose channel is full are dropped, like on a congested network.
+// Messages an oracle sends to itself are always delivered immediately.
+// Delayed messages are delivered in order of their due time, and messages
+// which fall due at the same time are delivered in the order they were sent.
+//
+// Randomness is drawn from a source seeded with the seed passed to
+// NewConditionedNetwork.
+type ConditionedNetwork struct {
+	clock  clock.Clock
+	chs    []chan MessageWithSender
+	start  time.Time
+	chDone chan struct{}
+	// chWake tells the delivery goroutine that the earliest due delivery
+	// changed
+	chWake chan struct{}
+
+	closeOnce sync.Once
+
+	mutex      sync.Mutex
+	rng        *rand.Rand
+	conditions LinkConditions
+	links      map[[2]types.OracleID]LinkConditions
+	partitions []scheduledPartition
+	dropped    uint64
+	deliveries deliveryHeap
+	seq        uint64
+}
+
+// NewConditionedNetwork returns a ConditionedNetwork for n oracles, whose
+// links all start out with conditions.
+func NewConditionedNetwork(n int, clock clock.Clock, seed int64, conditions LinkConditions) *ConditionedNetwork {
+	net := ConditionedNetwork{
+		clock:      clock,
+		start:      clock.Now(),
+		chDone:     make(chan struct{}),
+		chWake:     make(chan struct{}, 1),
+		rng:        rand.New(rand.NewSource(seed)),
+		conditions: conditions,
+		links:      map[[2]types.OracleID]LinkConditions{},
+	}
+	for i := 0; i < n; i++ {
+		net.chs = append(net.chs, make(chan MessageWithSender, 100))
+	}
+	go net.runDeliveries()
+	return &net
+}
+
+// SetLinkConditions replaces the conditions of the link from "from" to "to"
+func (net *ConditionedNetwork) SetLinkConditions(from, to types.OracleID, conditions LinkConditions) {
+	net.mutex.Lock()
+	defer net.mutex.Unlock()
+	net.links[[2]types.OracleID{from, to}] = conditions
+}
+
+// SchedulePartition adds p to the partitions of the network. Messages sent
+// while any partition separating their sender and receiver is active are
+// dropped.
+func (net *ConditionedNetwork) SchedulePartition(p Partition) {
+	net.mutex.Lock()
+	defer net.mutex.Unlock()
+	net.partitions = append(net.partitions, scheduledPartition{p, false, 0})
+}
+
+// Heal ends all partitions which are currently active. Partitions scheduled
+// to start later are unaffected.
+func (net *ConditionedNetwork) Heal() {
+	net.mutex.Lock()
+	defer net.mutex.Unlock()
+	elapsed := net.clock.Now().Sub(net.start)
+	for i, p := range net.partitions {
+		if p.activeAt(elapsed) {
+			net.partitions[i].healed = true
+			net.partitions[i].healedAt = elapsed
+		}
+	}
+}
+
+// Dropped returns the number of messages dropped so far, because of link
+// conditions, partitions, or full channels
+func (net *ConditionedNetwork) Dropped() uint64 {
+	net.mutex.Lock()
+	defer net.mutex.Unlock()
+	return net.dropped
+}
+
+// Close discards all messages which haven't been delivered yet, and stops the
+// goroutine delivering delayed messages. Messages sent after Close are
+// dropped. Can be called multiple times.
+func (net *ConditionedNetwork) Close() {
+	net.closeOnce.Do(func() { close(net.chDone) })
+}
+
+// Endpoint returns the interface for oracle id's networking facilities
+func (net *ConditionedNetwork) Endpoint(id types.OracleID) (NetworkEndpoint, error) {
+	if !(0 <= int(id) && int(id) < len(net.chs)) {
+		return nil, fmt.Errorf("oracle id %v out of range", id)
+	}
+	return conditionedNetworkEndpoint{net, id}, nil
+}
+
+// delays returns the delays with which each copy of a message from "from" to
+// "to" is delivered. It returns nil if the message is dropped.
+func (net *ConditionedNetwork) delays(from, to types.OracleID) []time.Duration {
+	net.mutex.Lock()
+	defer net.mutex.Unlock()
+
+	elapsed := net.clock.Now().Sub(net.start)
+	for _, p := range net.partitions {
+		if p.activeAt(elapsed) && p.separates(from, to) {
+			net.dropped++
+			return nil
+		}
+	}
+
+	conditions, ok := net.links[[2]types.OracleID{from, to}]
+	if !ok {
+		conditions = net.conditions
+	}
+	if net.rng.Float64() < conditions.DropProbability {
+		net.dropped++
+		return nil
+	}
+	copies := 1
+	if net.rng.Float64() < conditions.DuplicateProbability {
+		copies++
+	}
+	delays := make([]time.Duration, copies)
+	for i := range delays {
+		delays[i] = conditions.MinDelay
+		if spread := conditions.MaxDelay - conditions.MinDelay; spread > 0 {
+			delays[i] += time.Duration(net.rng.Int63n(int64(spread) + 1))
+		}
+	}
+	return delays
+}
+
+func (net *ConditionedNetwork) send(msg MessageWithSender, to types.OracleID) {
+	if msg.Sender == to {
+		net.deliver(msg, to)
+		return
+	}
+	for _, delay := range net.delays(msg.Sender, to) {
+		if delay <= 0 {
+			net.deliver(msg, to)
+			continue
+		}
+		net.schedule(msg, to, delay)
+	}
+}
+
+// schedule queues msg for delivery to "to" once delay has elapsed
+func (net *ConditionedNetwork) schedule(msg MessageWithSender, to types.OracleID, delay time.Duration) {
+	net.mutex.Lock()
+	heap.Push(&net.deliveries, delivery{net.clock.Now().Add(delay), net.seq, msg, to})
+	net.seq++
+	isEarliest := net.deliveries[0].seq == net.seq-1
+	net.mutex.Unlock()
+	if isEarliest {
+		select {
+		case net.chWake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// runDeliveries delivers the queued messages as they fall due, until the
+// network is closed
+func (net *ConditionedNetwork) runDeliveries() {
+	for {
+		var timer clock.Timer
+		var chTimer <-chan time.Time
+		net.mutex.Lock()
+		if len(net.deliveries) != 0 {
+			timer = net.clock.NewTimer(net.deliveries[0].at.Sub(net.clock.Now()))
+			chTimer = timer.C()
+		}
+		net.mutex.Unlock()
+
+		select {
+		case <-chTimer:
+			for _, d := range net.dueDeliveries() {
+				net.deliver(d.msg, d.to)
+			}
+		case <-net.chWake:
+		case <-net.chDone:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		// ensure prompt exit
+		select {
+		case <-net.chDone:
+			return
+		default:
+		}
+	}
+}
+
+// dueDeliveries removes the deliveries which are due from the queue, and
+// returns them in the order in which they must be delivered
+func (net *ConditionedNetwork) dueDeliveries() []delivery {
+	net.mutex.Lock()
+	defer net.mutex.Unlock()
+	now := net.clock.Now()
+	var due []delivery
+	for len(net.deliveries) != 0 && !net.deliveries[0].at.After(now) {
+		due = append(due, heap.Pop(&net.deliveries).(delivery))
+	}
+	return due
+}
+
+func (net *ConditionedNetwork) deliver(msg MessageWithSender, to types.OracleID) {
+	select {
+	case <-net.chDone:
+		return
+	default:
+	}
+	select {
+	case net.chs[to] <- msg:
+	default:
+		net.mutex.Lock()
+		net.dropped++
+		net.mutex.Unlock()
+	}
+}
+
+// delivery is a message queued for delivery at a later time
+type delivery struct {
+	at  time.Time
+	seq uint64
+	msg MessageWithSender
+	to  types.OracleID
+}
+
+// deliveryHeap implements heap.Interface, ordered by at and seq
+type deliveryHeap []delivery
+
+func (h deliveryHeap) Len() int { return len(h) }
+
+func (h deliveryHeap) Less(i, j int) bool {
+	if !h[i].at.Equal(h[j].at) {
+		return h[i].at.Before(h[j].at)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h deliveryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *deliveryHeap) Push(x interface{}) { *h = append(*h, x.(delivery)) }
+
+func (h *deliveryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// conditionedNetworkEndpoint is the NetworkEndpoint of an oracle on a
+// ConditionedNetwork
+type conditionedNetworkEndpoint struct {
+	net *ConditionedNetwork
+	id  types.OracleID
+}
+
+var _ NetworkEndpoint = conditionedNetworkEndpoint{}
+
+func (end conditionedNetworkEndpoint) SendTo(msg Message, to types.OracleID) {
+	end.net.send(MessageWithSender{msg, end.id}, to)
+}
+
+func (end conditionedNetworkEndpoint) Broadcast(msg Message) {
+	for to := range end.net.chs {
+		end.net.send(MessageWithSender{msg, end.id}, types.OracleID(to))
+	}
+}
+
+func (end conditionedNetworkEndpoint) Receive() <-chan MessageWithSender {
+	return end.net.chs[end.id]
+}
+
+func (conditionedNetworkEndpoint) Start() error { return nil }
+
+func (conditionedNetworkEndpoint) Close() error { return nil }
@@ -0,0 +1,130 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func conditionedEndpoints(t *testing.T, net *ConditionedNetwork, n int) []NetworkEndpoint {
+	endpoints := make([]NetworkEndpoint, n)
+	for i := range endpoints {
+		endpoint, err := net.Endpoint(types.OracleID(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		endpoints[i] = endpoint
+	}
+	return endpoints
+}
+
+// receiveEpochs returns the epochs of the MessageNewEpochs received on end,
+// waiting at most timeout for each
+func receiveEpochs(end NetworkEndpoint, count int, timeout time.Duration) []uint32 {
+	var epochs []uint32
+	for len(epochs) < count {
+		select {
+		case msg := <-end.Receive():
+			epochs = append(epochs, msg.Msg.(MessageNewEpoch).Epoch)
+		case <-time.After(timeout):
+			return epochs
+		}
+	}
+	return epochs
+}
+
+func TestConditionedNetworkPreservesOrderOfEqualDelays(t *testing.T) {
+	v := clock.NewVirtual(time.Unix(0, 0))
+	net := NewConditionedNetwork(2, v, 1, LinkConditions{0, 0, time.Second, time.Second})
+	defer net.Close()
+	endpoints := conditionedEndpoints(t, net, 2)
+
+	const count = 50
+	for i := 0; i < count; i++ {
+		endpoints[0].SendTo(MessageNewEpoch{uint32(i)}, 1)
+	}
+	v.Advance(time.Second)
+	epochs := receiveEpochs(endpoints[1], count, time.Second)
+	if len(epochs) != count {
+		t.Fatalf("received %d messages, expected %d", len(epochs), count)
+	}
+	for i, epoch := range epochs {
+		if epoch != uint32(i) {
+			t.Fatalf("received messages in order %v", epochs)
+		}
+	}
+}
+
+func TestConditionedNetworkDeliversByDueTime(t *testing.T) {
+	v := clock.NewVirtual(time.Unix(0, 0))
+	net := NewConditionedNetwork(2, v, 1, LinkConditions{})
+	defer net.Close()
+	endpoints := conditionedEndpoints(t, net, 2)
+
+	net.SetLinkConditions(0, 1, LinkConditions{0, 0, 2 * time.Second, 2 * time.Second})
+	endpoints[0].SendTo(MessageNewEpoch{1}, 1)
+	net.SetLinkConditions(0, 1, LinkConditions{0, 0, time.Second, time.Second})
+	endpoints[0].SendTo(MessageNewEpoch{2}, 1)
+
+	v.Advance(time.Second)
+	if epochs := receiveEpochs(endpoints[1], 1, time.Second); len(epochs) != 1 || epochs[0] != 2 {
+		t.Fatalf("received %v after 1s, expected the message with the shorter delay", epochs)
+	}
+	if epochs := receiveEpochs(endpoints[1], 1, 10*time.Millisecond); len(epochs) != 0 {
+		t.Fatalf("received %v before the message was due", epochs)
+	}
+	v.Advance(time.Second)
+	if epochs := receiveEpochs(endpoints[1], 1, time.Second); len(epochs) != 1 || epochs[0] != 1 {
+		t.Fatalf("received %v after 2s, expected the message with the longer delay", epochs)
+	}
+}
+
+func TestConditionedNetworkHealAtPartitionStart(t *testing.T) {
+	v := clock.NewVirtual(time.Unix(0, 0))
+	net := NewConditionedNetwork(2, v, 1, LinkConditions{})
+	defer net.Close()
+	endpoints := conditionedEndpoints(t, net, 2)
+
+	net.SchedulePartition(Partition{time.Second, 0, [][]types.OracleID{{0}}})
+	v.Advance(time.Second)
+	endpoints[0].SendTo(MessageNewEpoch{1}, 1)
+	if net.Dropped() != 1 {
+		t.Fatalf("partition didn't drop message")
+	}
+
+	// Healing at the instant the partition starts must end it, rather than
+	// leave it active forever
+	net.Heal()
+	endpoints[0].SendTo(MessageNewEpoch{2}, 1)
+	v.Advance(time.Hour)
+	endpoints[0].SendTo(MessageNewEpoch{3}, 1)
+	if epochs := receiveEpochs(endpoints[1], 2, time.Second); len(epochs) != 2 {
+		t.Fatalf("received %v after healing the partition", epochs)
+	}
+	if net.Dropped() != 1 {
+		t.Fatalf("dropped %d messages, expected 1", net.Dropped())
+	}
+}
+
+func TestConditionedNetworkHealLeavesLaterPartitions(t *testing.T) {
+	v := clock.NewVirtual(time.Unix(0, 0))
+	net := NewConditionedNetwork(2, v, 1, LinkConditions{})
+	defer net.Close()
+	endpoints := conditionedEndpoints(t, net, 2)
+
+	net.SchedulePartition(Partition{0, 0, [][]types.OracleID{{0}}})
+	net.SchedulePartition(Partition{time.Minute, time.Minute, [][]types.OracleID{{0}}})
+	net.Heal()
+	endpoints[0].SendTo(MessageNewEpoch{1}, 1)
+	v.Advance(time.Minute)
+	endpoints[0].SendTo(MessageNewEpoch{2}, 1)
+	v.Advance(time.Minute)
+	endpoints[0].SendTo(MessageNewEpoch{3}, 1)
+
+	epochs := receiveEpochs(endpoints[1], 2, time.Second)
+	if len(epochs) != 2 || epochs[0] != 1 || epochs[1] != 3 {
+		t.Fatalf("received %v, expected [1 3]", epochs)
+	}
+}
@@ -53,6 +53,26 @@ func signedObservationWireMessage(repctx ReportContext, observation observation.
 	return append(tag[:], observation.Marshal()...)
 }
 
+// ParseSignedObservationWireMessage is the inverse of the message a
+// SignedObservation's signature is on. It lets signers tell which round they
+// are asked to sign an observation for.
+func ParseSignedObservationWireMessage(msg []byte) (ReportContext, observation.Observation, error) {
+	var tag DomainSeparationTag
+	if len(msg) < len(tag) {
+		return ReportContext{}, observation.Observation{}, errors.New("message is too short for a signed observation")
+	}
+	copy(tag[:], msg)
+	repctx, ok := tag.ReportContext()
+	if !ok {
+		return ReportContext{}, observation.Observation{}, errors.New("message doesn't start with a DomainSeparationTag")
+	}
+	obs, err := observation.UnmarshalObservation(msg[len(tag):])
+	if err != nil {
+		return ReportContext{}, observation.Observation{}, err
+	}
+	return repctx, obs, nil
+}
+
 type AttributedSignedObservation struct {
 	SignedObservation SignedObservation
 	Observer          types.OracleID
@@ -0,0 +1,187 @@
+package observation
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// DeviationMode determines how the relative and absolute thresholds of a
+// DeviationRule are combined.
+type DeviationMode uint8
+
+const (
+	// DeviationModeRelative only considers the relative change. Any move away
+	// from zero is significant. This is the rule implied by a bare AlphaPPB.
+	DeviationModeRelative DeviationMode = iota
+	// DeviationModeAbsolute only considers the absolute change. Useful for
+	// values which hover around zero.
+	DeviationModeAbsolute
+	// DeviationModeRelativeOrAbsolute deviates if either threshold is
+	// exceeded. Moves away from zero only consider the absolute threshold, so
+	// that values near zero aren't reported on every change.
+	DeviationModeRelativeOrAbsolute
+	// DeviationModeRelativeAndAbsolute deviates only if both thresholds are
+	// exceeded. Moves away from zero only consider the absolute threshold.
+	DeviationModeRelativeAndAbsolute
+	deviationModeEnd // must be last
+)
+
+var englishDeviationMode = map[DeviationMode]string{
+	DeviationModeRelative:            "relative",
+	DeviationModeAbsolute:            "absolute",
+	DeviationModeRelativeOrAbsolute:  "relativeOrAbsolute",
+	DeviationModeRelativeAndAbsolute: "relativeAndAbsolute",
+}
+
+func (m DeviationMode) String() string {
+	if s, ok := englishDeviationMode[m]; ok {
+		return s
+	}
+	return fmt.Sprintf("DeviationMode(%d)", uint8(m))
+}
+
+// DeviationRule decides whether a new value differs enough from an old value
+// to be worth reporting. Thresholds are given separately for upward moves
+// (new > old) and downward moves (new < old).
+type DeviationRule struct {
+	Mode DeviationMode
+	// Relative thresholds, in parts per billion of the old value
+	UpPPB   uint64
+	DownPPB uint64
+	// Absolute thresholds, in the same units as the observations. nil is
+	// treated as zero.
+	UpAbsolute   *big.Int
+	DownAbsolute *big.Int
+}
+
+// RelativeDeviationRule returns the symmetric relative rule with the given
+// threshold, which is what the legacy AlphaPPB parameter expresses.
+func RelativeDeviationRule(thresholdPPB uint64) DeviationRule {
+	return DeviationRule{
+		DeviationModeRelative,
+		thresholdPPB,
+		thresholdPPB,
+		nil,
+		nil,
+	}
+}
+
+// IsRelativeDeviationRule returns true iff r is equivalent to
+// RelativeDeviationRule(r.UpPPB).
+func (r DeviationRule) IsRelativeDeviationRule() bool {
+	return r.Mode == DeviationModeRelative && r.UpPPB == r.DownPPB
+}
+
+func (r DeviationRule) upAbsolute() *big.Int {
+	if r.UpAbsolute == nil {
+		return i(0)
+	}
+	return r.UpAbsolute
+}
+
+func (r DeviationRule) downAbsolute() *big.Int {
+	if r.DownAbsolute == nil {
+		return i(0)
+	}
+	return r.DownAbsolute
+}
+
+// Check returns an error if r cannot be used to compare observations.
+func (r DeviationRule) Check() error {
+	if !(r.Mode < deviationModeEnd) {
+		return errors.Errorf("unknown deviation mode %v", r.Mode)
+	}
+	for _, threshold := range []struct {
+		value *big.Int
+		name  string
+	}{
+		{r.upAbsolute(), "upward absolute threshold"},
+		{r.downAbsolute(), "downward absolute threshold"},
+	} {
+		if threshold.value.Sign() < 0 {
+			return errors.Errorf("%s must be non-negative, got %v",
+				threshold.name, threshold.value)
+		}
+		if threshold.value.Cmp(MaxObservation) > 0 {
+			return errors.Errorf("%s must not exceed %v, got %v",
+				threshold.name, MaxObservation, threshold.value)
+		}
+	}
+	return nil
+}
+
+func (r DeviationRule) String() string {
+	return fmt.Sprintf("DeviationRule{Mode: %v, UpPPB: %d, DownPPB: %d, UpAbsolute: %v, DownAbsolute: %v}",
+		r.Mode, r.UpPPB, r.DownPPB, r.upAbsolute(), r.downAbsolute())
+}
+
+// Deviates returns true iff o, the new value, deviates from old according to
+// rule.
+func (o Observation) Deviates(old Observation, rule DeviationRule) bool {
+	up := o.v.Cmp(old.v) > 0
+	relativeThreshold, absoluteThreshold := rule.DownPPB, rule.downAbsolute()
+	if up {
+		relativeThreshold, absoluteThreshold = rule.UpPPB, rule.upAbsolute()
+	}
+
+	// Any move away from zero is a relative deviation, so the hybrid modes
+	// only consider the absolute threshold for such moves
+	fromZero := old.v.Sign() == 0
+
+	switch rule.Mode {
+	case DeviationModeRelative:
+		return o.deviatesRelative(old, relativeThreshold)
+	case DeviationModeAbsolute:
+		return o.deviatesAbsolute(old, absoluteThreshold)
+	case DeviationModeRelativeOrAbsolute:
+		return (!fromZero && o.deviatesRelative(old, relativeThreshold)) ||
+			o.deviatesAbsolute(old, absoluteThreshold)
+	case DeviationModeRelativeAndAbsolute:
+		return (fromZero || o.deviatesRelative(old, relativeThreshold)) &&
+			o.deviatesAbsolute(old, absoluteThreshold)
+	}
+	// Unreachable for checked rules. Err on the side of reporting.
+	return true
+}
+
+// DeviatesFromTransmitted returns true iff o, the median of a new report,
+// deviates from latest, the median of the latest transmitted report,
+// according to rule.
+//
+// For rules equivalent to the legacy AlphaPPB, the change is measured
+// relative to o rather than to latest, as transmission always has, so that
+// existing feeds transmit exactly when they used to. Other rules need to know
+// the direction of the move, and measure relative to latest like Deviates.
+func (o Observation) DeviatesFromTransmitted(latest Observation, rule DeviationRule) bool {
+	if rule.IsRelativeDeviationRule() {
+		return latest.deviatesRelative(o, rule.UpPPB)
+	}
+	return o.Deviates(latest, rule)
+}
+
+func (o Observation) deviatesRelative(old Observation, thresholdPPB uint64) bool {
+	if old.v.Cmp(i(0)) == 0 {
+		if o.v.Cmp(i(0)) == 0 {
+			return false // Both values are zero; no deviation
+		}
+		return true // Any deviation from 0 is significant
+	}
+	// ||o.v - old.v|| / ||old.v||, approximated by a float
+	change := &big.Rat{}
+	change.SetFrac(i(0).Sub(o.v, old.v), old.v)
+	change.Abs(change)
+	threshold := &big.Rat{}
+	threshold.SetFrac(
+		(&big.Int{}).SetUint64(thresholdPPB),
+		(&big.Int{}).SetUint64(1000000000),
+	)
+	return change.Cmp(threshold) > 0
+}
+
+func (o Observation) deviatesAbsolute(old Observation, threshold *big.Int) bool {
+	change := i(0).Sub(o.v, old.v)
+	change.Abs(change)
+	return change.Cmp(threshold) > 0
+}
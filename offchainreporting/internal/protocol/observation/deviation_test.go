@@ -0,0 +1,116 @@
+package observation
+
+import (
+	"math/big"
+	"testing"
+)
+
+func obs(v int64) Observation { return Observation{big.NewInt(v)} }
+
+func TestDeviates(t *testing.T) {
+	const percent = 10000000 // in PPB
+	tests := []struct {
+		name     string
+		rule     DeviationRule
+		old, new int64
+		deviates bool
+	}{
+		{"relative: up, above threshold", RelativeDeviationRule(10 * percent), 100, 111, true},
+		{"relative: up, at threshold", RelativeDeviationRule(10 * percent), 100, 110, false},
+		{"relative: down, above threshold", RelativeDeviationRule(10 * percent), 100, 89, true},
+		{"relative: down, below threshold", RelativeDeviationRule(10 * percent), 100, 91, false},
+		{"relative: from zero", RelativeDeviationRule(10 * percent), 0, 1, true},
+		{"relative: zero to zero", RelativeDeviationRule(10 * percent), 0, 0, false},
+		{"relative: asymmetric up", DeviationRule{DeviationModeRelative, 5 * percent, 20 * percent, nil, nil}, 100, 106, true},
+		{"relative: asymmetric down", DeviationRule{DeviationModeRelative, 5 * percent, 20 * percent, nil, nil}, 100, 90, false},
+
+		{"absolute: above threshold", DeviationRule{DeviationModeAbsolute, 0, 0, big.NewInt(5), big.NewInt(5)}, 0, 6, true},
+		{"absolute: at threshold", DeviationRule{DeviationModeAbsolute, 0, 0, big.NewInt(5), big.NewInt(5)}, 0, -5, false},
+		{"absolute: nil threshold is zero", DeviationRule{DeviationModeAbsolute, 0, 0, nil, nil}, 7, 8, true},
+		{"absolute: ignores relative change", DeviationRule{DeviationModeAbsolute, 0, 0, big.NewInt(5), big.NewInt(5)}, 1, 4, false},
+		{"absolute: asymmetric up", DeviationRule{DeviationModeAbsolute, 0, 0, big.NewInt(1), big.NewInt(10)}, 50, 52, true},
+		{"absolute: asymmetric down", DeviationRule{DeviationModeAbsolute, 0, 0, big.NewInt(1), big.NewInt(10)}, 50, 48, false},
+
+		{"or: only relative exceeded", DeviationRule{DeviationModeRelativeOrAbsolute, 10 * percent, 10 * percent, big.NewInt(100), big.NewInt(100)}, 10, 20, true},
+		{"or: only absolute exceeded", DeviationRule{DeviationModeRelativeOrAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 1000, 1010, true},
+		{"or: neither exceeded", DeviationRule{DeviationModeRelativeOrAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 1000, 1004, false},
+		{"or: from zero, below absolute threshold", DeviationRule{DeviationModeRelativeOrAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 0, 3, false},
+		{"or: from zero, above absolute threshold", DeviationRule{DeviationModeRelativeOrAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 0, -6, true},
+		{"or: to zero, relative exceeded", DeviationRule{DeviationModeRelativeOrAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 3, 0, true},
+
+		{"and: only relative exceeded", DeviationRule{DeviationModeRelativeAndAbsolute, 10 * percent, 10 * percent, big.NewInt(100), big.NewInt(100)}, 10, 20, false},
+		{"and: only absolute exceeded", DeviationRule{DeviationModeRelativeAndAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 1000, 1010, false},
+		{"and: both exceeded", DeviationRule{DeviationModeRelativeAndAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 10, 20, true},
+		{"and: from zero, below absolute threshold", DeviationRule{DeviationModeRelativeAndAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 0, 3, false},
+		{"and: from zero, above absolute threshold", DeviationRule{DeviationModeRelativeAndAbsolute, 10 * percent, 10 * percent, big.NewInt(5), big.NewInt(5)}, 0, 6, true},
+	}
+	for _, test := range tests {
+		if err := test.rule.Check(); err != nil {
+			t.Fatalf("%s: invalid rule: %v", test.name, err)
+		}
+		if got := obs(test.new).Deviates(obs(test.old), test.rule); got != test.deviates {
+			t.Errorf("%s: %v.Deviates(%v) = %v, expected %v",
+				test.name, test.new, test.old, got, test.deviates)
+		}
+	}
+}
+
+// legacyDeviates is the deviation check transmission used before
+// DeviationRules, which measures the change relative to the new value
+func legacyDeviates(latest, report Observation, thresholdPPB uint64) bool {
+	if report.v.Sign() == 0 {
+		return latest.v.Sign() != 0
+	}
+	change := new(big.Rat).SetFrac(new(big.Int).Sub(latest.v, report.v), report.v)
+	change.Abs(change)
+	return change.Cmp(new(big.Rat).SetFrac(new(big.Int).SetUint64(thresholdPPB), big.NewInt(1000000000))) > 0
+}
+
+func TestDeviatesFromTransmittedLegacy(t *testing.T) {
+	const alpha = 95000000 // 9.5%
+	rule := RelativeDeviationRule(alpha)
+
+	// 100 -> 110 is a 10% change relative to the latest value, but only
+	// 9.09% relative to the report's. The legacy rule doesn't transmit.
+	if obs(110).DeviatesFromTransmitted(obs(100), rule) {
+		t.Errorf("legacy rule must measure the change relative to the report's median")
+	}
+	if !obs(110).Deviates(obs(100), rule) {
+		t.Errorf("Deviates must measure the change relative to the old value")
+	}
+
+	for _, c := range []struct{ latest, report int64 }{
+		{100, 110}, {100, 111}, {110, 100}, {0, 0}, {0, 5}, {5, 0}, {-100, -110}, {-100, 100},
+	} {
+		got := obs(c.report).DeviatesFromTransmitted(obs(c.latest), rule)
+		expected := legacyDeviates(obs(c.latest), obs(c.report), alpha)
+		if got != expected {
+			t.Errorf("latest %d, report %d: DeviatesFromTransmitted = %v, legacy = %v",
+				c.latest, c.report, got, expected)
+		}
+	}
+}
+
+func TestDeviatesFromTransmittedAsymmetric(t *testing.T) {
+	// Non-legacy rules measure relative to the latest transmitted value, and
+	// pick the threshold by the direction of the move
+	rule := DeviationRule{DeviationModeRelative, 5000000, 50000000, nil, nil}
+	if !obs(106).DeviatesFromTransmitted(obs(100), rule) {
+		t.Errorf("6%% up must exceed the 0.5%% upward threshold")
+	}
+	if obs(97).DeviatesFromTransmitted(obs(100), rule) {
+		t.Errorf("3%% down must not exceed the 5%% downward threshold")
+	}
+}
+
+func TestDeviationRuleCheck(t *testing.T) {
+	for _, rule := range []DeviationRule{
+		{deviationModeEnd, 0, 0, nil, nil},
+		{DeviationModeAbsolute, 0, 0, big.NewInt(-1), nil},
+		{DeviationModeAbsolute, 0, 0, nil, new(big.Int).Add(MaxObservation, big.NewInt(1))},
+	} {
+		if rule.Check() == nil {
+			t.Errorf("%v must be invalid", rule)
+		}
+	}
+}
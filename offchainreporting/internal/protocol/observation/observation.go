@@ -56,25 +56,6 @@ func (o Observation) IsMissingValue() bool { return o.v == nil }
 
 func (o Observation) GoEthereumValue() *big.Int { return o.v }
 
-func (o Observation) Deviates(old Observation, thresholdPPB uint64) bool {
-	if old.v.Cmp(i(0)) == 0 {
-		if o.v.Cmp(i(0)) == 0 {
-			return false // Both values are zero; no deviation
-		}
-		return true // Any deviation from 0 is significant
-	}
-	// ||o.v - old.v|| / ||old.v||, approximated by a float
-	change := &big.Rat{}
-	change.SetFrac(i(0).Sub(o.v, old.v), old.v)
-	change.Abs(change)
-	threshold := &big.Rat{}
-	threshold.SetFrac(
-		(&big.Int{}).SetUint64(thresholdPPB),
-		(&big.Int{}).SetUint64(1000000000),
-	)
-	return change.Cmp(threshold) > 0
-}
-
 // Bytes returns the twos-complement representation of o
 //
 // This panics on OOB values, because MakeObservation and UnmarshalObservation
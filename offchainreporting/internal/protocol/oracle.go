@@ -3,6 +3,7 @@ package protocol
 import (
 	"context"
 
+	"github.com/SeerLink/libocr/clock"
 	"github.com/SeerLink/libocr/offchainreporting/internal/config"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 	"github.com/SeerLink/libocr/subprocesses"
@@ -16,6 +17,8 @@ import (
 func RunOracle(
 	ctx context.Context,
 
+	chStatusRequests <-chan StatusRequest,
+	clock clock.Clock,
 	config config.SharedConfig,
 	contractTransmitter types.ContractTransmitter,
 	database types.Database,
@@ -24,12 +27,16 @@ func RunOracle(
 	keys types.PrivateKeys,
 	localConfig types.LocalConfig,
 	logger types.Logger,
+	metrics *Metrics,
 	netEndpoint NetworkEndpoint,
+	spanExporter types.SpanExporter,
 	telemetrySender TelemetrySender,
 ) {
 	o := oracleState{
 		ctx: ctx,
 
+		chStatusRequests:    chStatusRequests,
+		clock:               clock,
 		Config:              config,
 		contractTransmitter: contractTransmitter,
 		database:            database,
@@ -37,8 +44,10 @@ func RunOracle(
 		id:                  id,
 		localConfig:         localConfig,
 		logger:              logger,
+		metrics:             metrics,
 		netEndpoint:         netEndpoint,
 		PrivateKeys:         keys,
+		spanExporter:        spanExporter,
 		telemetrySender:     telemetrySender,
 	}
 	o.run()
@@ -47,6 +56,8 @@ func RunOracle(
 type oracleState struct {
 	ctx context.Context
 
+	chStatusRequests    <-chan StatusRequest
+	clock               clock.Clock
 	Config              config.SharedConfig
 	contractTransmitter types.ContractTransmitter
 	database            types.Database
@@ -54,15 +65,20 @@ type oracleState struct {
 	id                  types.OracleID
 	localConfig         types.LocalConfig
 	logger              types.Logger
+	metrics             *Metrics
 	netEndpoint         NetworkEndpoint
 	PrivateKeys         types.PrivateKeys
+	spanExporter        types.SpanExporter
 	telemetrySender     TelemetrySender
 
-	chNetToPacemaker        chan<- MessageToPacemakerWithSender
-	chNetToReportGeneration chan<- MessageToReportGenerationWithSender
-	childCancel             context.CancelFunc
-	childCtx                context.Context
-	subprocesses            subprocesses.Subprocesses
+	chNetToPacemaker           chan<- MessageToPacemakerWithSender
+	chNetToReportGeneration    chan<- MessageToReportGenerationWithSender
+	chStatusToPacemaker        chan<- chan<- pacemakerStatus
+	chStatusToReportGeneration chan<- chan<- reportGenerationStatus
+	chStatusToTransmission     chan<- chan<- transmissionStatus
+	childCancel                context.CancelFunc
+	childCtx                   context.Context
+	subprocesses               subprocesses.Subprocesses
 }
 
 // run ensures safe shutdown of the Oracle's "child routines",
@@ -81,6 +97,10 @@ type oracleState struct {
 //
 // All channels are unbuffered.
 //
+// In addition, the Oracle asks Pacemaker, ReportGeneration and Transmission
+// for their status upon each StatusRequest. Every instance of ReportGeneration
+// listens on the same status channel.
+//
 // Once o.ctx.Done() is closed, the Oracle runloop will enter the
 // corresponding select case and no longer forward network messages
 // to Pacemaker and ReportGeneration. It will then cancel o.childCtx,
@@ -104,6 +124,17 @@ func (o *oracleState) run() {
 
 	chReportGenerationToTransmission := make(chan EventToTransmission)
 
+	chStatusToPacemaker := make(chan chan<- pacemakerStatus)
+	o.chStatusToPacemaker = chStatusToPacemaker
+
+	chStatusToReportGeneration := make(chan chan<- reportGenerationStatus)
+	o.chStatusToReportGeneration = chStatusToReportGeneration
+
+	chStatusToTransmission := make(chan chan<- transmissionStatus)
+	o.chStatusToTransmission = chStatusToTransmission
+
+	o.subprocesses.Clock = o.clock
+
 	o.childCtx, o.childCancel = context.WithCancel(context.Background())
 	defer o.childCancel()
 
@@ -115,6 +146,9 @@ func (o *oracleState) run() {
 			chNetToPacemaker,
 			chNetToReportGeneration,
 			chReportGenerationToTransmission,
+			chStatusToPacemaker,
+			chStatusToReportGeneration,
+			o.clock,
 			o.Config,
 			o.contractTransmitter,
 			o.database,
@@ -122,8 +156,10 @@ func (o *oracleState) run() {
 			o.id,
 			o.localConfig,
 			o.logger,
+			o.metrics,
 			o.netEndpoint,
 			o.PrivateKeys,
+			o.spanExporter,
 			o.telemetrySender,
 		)
 	})
@@ -134,10 +170,15 @@ func (o *oracleState) run() {
 
 			o.Config,
 			chReportGenerationToTransmission,
+			chStatusToTransmission,
+			o.clock,
 			o.database,
 			o.id,
 			o.localConfig,
 			o.logger,
+			o.metrics,
+			o.spanExporter,
+			o.telemetrySender,
 			o.contractTransmitter,
 		)
 	})
@@ -146,10 +187,21 @@ func (o *oracleState) run() {
 
 	chDone := o.ctx.Done()
 	for {
+		clock.Block(o.clock)
 		select {
 		case msg := <-chNet:
+			clock.Unblock(o.clock)
+			clock.Woken(o.clock)
+			// wakes up the subprotocol the message is forwarded to
+			clock.Wake(o.clock)
 			msg.Msg.process(o, msg.Sender)
+		case req := <-o.chStatusRequests:
+			clock.Unblock(o.clock)
+			o.subprocesses.Go(func() {
+				o.collectStatus(req)
+			})
 		case <-chDone:
+			clock.Unblock(o.clock)
 		}
 
 		// ensure prompt exit
@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
-	"time"
 
+	"github.com/SeerLink/libocr/clock"
 	"github.com/SeerLink/libocr/offchainreporting/internal/config"
 	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/persist"
 	"github.com/SeerLink/libocr/offchainreporting/types"
@@ -28,6 +28,9 @@ func RunPacemaker(
 	chNetToPacemaker <-chan MessageToPacemakerWithSender,
 	chNetToReportGeneration <-chan MessageToReportGenerationWithSender,
 	chReportGenerationToTransmission chan<- EventToTransmission,
+	chStatusToPacemaker <-chan chan<- pacemakerStatus,
+	chStatusToReportGeneration <-chan chan<- reportGenerationStatus,
+	clock clock.Clock,
 	config config.SharedConfig,
 	contractTransmitter types.ContractTransmitter,
 	database types.Database,
@@ -35,8 +38,10 @@ func RunPacemaker(
 	id types.OracleID,
 	localConfig types.LocalConfig,
 	logger types.Logger,
+	metrics *Metrics,
 	netSender NetworkSender,
 	privateKeys types.PrivateKeys,
+	spanExporter types.SpanExporter,
 	telemetrySender TelemetrySender,
 ) {
 	pace := pacemakerState{
@@ -46,6 +51,9 @@ func RunPacemaker(
 		chNetToPacemaker:                 chNetToPacemaker,
 		chNetToReportGeneration:          chNetToReportGeneration,
 		chReportGenerationToTransmission: chReportGenerationToTransmission,
+		chStatusToPacemaker:              chStatusToPacemaker,
+		chStatusToReportGeneration:       chStatusToReportGeneration,
+		clock:                            clock,
 		config:                           config,
 		contractTransmitter:              contractTransmitter,
 		database:                         database,
@@ -53,8 +61,10 @@ func RunPacemaker(
 		id:                               id,
 		localConfig:                      localConfig,
 		logger:                           logger,
+		metrics:                          metrics,
 		netSender:                        netSender,
 		privateKeys:                      privateKeys,
+		spanExporter:                     spanExporter,
 		telemetrySender:                  telemetrySender,
 
 		newepoch: make([]uint32, config.N()),
@@ -70,6 +80,9 @@ type pacemakerState struct {
 	chNetToReportGeneration          <-chan MessageToReportGenerationWithSender
 	chReportGenerationToPacemaker    <-chan EventToPacemaker
 	chReportGenerationToTransmission chan<- EventToTransmission
+	chStatusToPacemaker              <-chan chan<- pacemakerStatus
+	chStatusToReportGeneration       <-chan chan<- reportGenerationStatus
+	clock                            clock.Clock
 	config                           config.SharedConfig
 	contractTransmitter              types.ContractTransmitter
 	database                         types.Database
@@ -77,8 +90,10 @@ type pacemakerState struct {
 	id                               types.OracleID
 	localConfig                      types.LocalConfig
 	logger                           types.Logger
+	metrics                          *Metrics
 	netSender                        NetworkSender
 	privateKeys                      types.PrivateKeys
+	spanExporter                     types.SpanExporter
 	telemetrySender                  TelemetrySender
 
 	cancelReportGeneration context.CancelFunc
@@ -102,11 +117,11 @@ type pacemakerState struct {
 	// tResend is a timeout used by the leader-election protocol to
 	// periodically resend the latest Newepoch message in order to
 	// guard against unreliable network conditions
-	tResend <-chan time.Time
+	tResend timer
 
 	// tProgress is a timeout used by the leader-election protocol to track
 	// whether the current leader is making adequate progress.
-	tProgress <-chan time.Time
+	tProgress timer
 }
 
 func (pace *pacemakerState) run() {
@@ -121,6 +136,7 @@ func (pace *pacemakerState) run() {
 			persist.Persist(
 				pace.ctx,
 				chPersist,
+				pace.clock,
 				pace.config.ConfigDigest,
 				pace.database,
 				pace.localConfig.DatabaseTimeout,
@@ -143,7 +159,7 @@ func (pace *pacemakerState) run() {
 
 	pace.spawnReportGeneration()
 
-	pace.tProgress = time.After(pace.config.DeltaProgress)
+	pace.tProgress.restart(pace.clock, pace.config.DeltaProgress)
 
 	pace.sendNewepoch(pace.ne)
 
@@ -155,21 +171,36 @@ func (pace *pacemakerState) run() {
 
 	// Event Loop
 	for {
+		clock.Block(pace.clock)
 		select {
 		case msg := <-pace.chNetToPacemaker:
+			clock.Unblock(pace.clock)
+			clock.Woken(pace.clock)
 			msg.msg.processPacemaker(pace, msg.sender)
 		case ev := <-pace.chReportGenerationToPacemaker:
+			clock.Unblock(pace.clock)
+			clock.Woken(pace.clock)
 			ev.processPacemaker(pace)
-		case <-pace.tResend:
+		case ch := <-pace.chStatusToPacemaker:
+			clock.Unblock(pace.clock)
+			ch <- pace.status()
+		case <-pace.tResend.C():
+			clock.Unblock(pace.clock)
+			clock.Woken(pace.clock)
 			pace.eventTResendTimeout()
-		case <-pace.tProgress:
+		case <-pace.tProgress.C():
+			clock.Unblock(pace.clock)
+			clock.Woken(pace.clock)
 			pace.eventTProgressTimeout()
 		case <-chDone:
+			clock.Unblock(pace.clock)
 		}
 
 		// ensure prompt exit
 		select {
 		case <-chDone:
+			pace.tResend.stop()
+			pace.tProgress.stop()
 			pace.logger.Info("Pacemaker: exiting", nil)
 			return
 		default:
@@ -299,9 +330,11 @@ func (pace *pacemakerState) persist() {
 		append([]uint32{}, pace.newepoch...),
 	}
 
+	clock.Wake(pace.clock)
 	select {
 	case pace.chPersist <- state:
 	default:
+		clock.Woken(pace.clock)
 		pace.logger.Warn("Pacemaker: chPersist is backed up, discarding state", types.LogFields{
 			"state":    state,
 			"capacity": chPersistCapacity,
@@ -313,7 +346,7 @@ func (pace *pacemakerState) persist() {
 // prototol. It resets the timer which will trigger the oracle to broadcast a
 // "newepoch" message, if it runs out.
 func (pace *pacemakerState) eventProgress() {
-	pace.tProgress = time.After(pace.config.DeltaProgress)
+	pace.tProgress.restart(pace.clock, pace.config.DeltaProgress)
 }
 
 func (pace *pacemakerState) sendNewepoch(newEpoch uint32) {
@@ -322,7 +355,7 @@ func (pace *pacemakerState) sendNewepoch(newEpoch uint32) {
 		pace.ne = newEpoch
 		pace.persist()
 	}
-	pace.tResend = time.After(pace.config.DeltaResend)
+	pace.tResend.restart(pace.clock, pace.config.DeltaResend)
 }
 
 func (pace *pacemakerState) eventTResendTimeout() {
@@ -334,7 +367,7 @@ func (pace *pacemakerState) eventTProgressTimeout() {
 }
 
 func (pace *pacemakerState) eventChangeLeader() {
-	pace.tProgress = nil
+	pace.tProgress.stop()
 	sendEpoch := pace.ne
 	epochPlusOne := pace.e + 1
 	if epochPlusOne <= pace.e {
@@ -396,17 +429,23 @@ func (pace *pacemakerState) messageNewepoch(msg MessageNewEpoch, sender types.Or
 				pace.ne = pace.e
 			}
 			pace.persist()
+			pace.metrics.epochChanges.Inc()
+			pace.telemetrySender.EpochChanged(pace.config.ConfigDigest, pace.e, pace.l)
 
 			// abort instance [...], initialize instance (e,l) of report generation
 			pace.spawnReportGeneration()
 
-			pace.tProgress = time.After(pace.config.DeltaProgress) // restart timer T_{progress}
+			pace.tProgress.restart(pace.clock, pace.config.DeltaProgress) // restart timer T_{progress}
 		}
 	}
 }
 
 func (pace *pacemakerState) spawnReportGeneration() {
+	pace.metrics.epoch.Set(float64(pace.e))
+
 	if pace.cancelReportGeneration != nil {
+		// wakes up the report generation of the previous epoch to exit
+		clock.Wake(pace.clock)
 		pace.cancelReportGeneration()
 	}
 
@@ -414,6 +453,9 @@ func (pace *pacemakerState) spawnReportGeneration() {
 	pace.chReportGenerationToPacemaker = chReportGenerationToPacemaker
 
 	ctxReportGeneration, cancelReportGeneration := context.WithCancel(pace.ctx)
+	// pace.e and pace.l change with the next epoch, while report generation
+	// may still be starting up
+	e, l := pace.e, pace.l
 	pace.subprocesses.Go(func() {
 		defer cancelReportGeneration()
 		RunReportGeneration(
@@ -423,18 +465,26 @@ func (pace *pacemakerState) spawnReportGeneration() {
 			pace.chNetToReportGeneration,
 			chReportGenerationToPacemaker,
 			pace.chReportGenerationToTransmission,
+			pace.chStatusToReportGeneration,
+			pace.clock,
 			pace.config,
 			pace.contractTransmitter,
 			pace.datasource,
-			pace.e,
+			e,
 			pace.id,
-			pace.l,
+			l,
 			pace.localConfig,
 			pace.logger,
+			pace.metrics,
 			pace.netSender,
 			pace.privateKeys,
+			pace.spanExporter,
 			pace.telemetrySender,
 		)
+		if pace.ctx.Err() == nil {
+			// report generation exited because the next epoch started
+			clock.Woken(pace.clock)
+		}
 	})
 	pace.cancelReportGeneration = cancelReportGeneration
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/SeerLink/libocr/clock"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 )
 
@@ -11,6 +12,7 @@ type persistState struct {
 	ctx context.Context
 
 	chPersist       <-chan types.PersistentState
+	clock           clock.Clock
 	configDigest    types.ConfigDigest
 	database        types.Database
 	databaseTimeout time.Duration
@@ -24,6 +26,7 @@ type persistState struct {
 func Persist(
 	ctx context.Context,
 	chPersist <-chan types.PersistentState,
+	clock clock.Clock,
 	configDigest types.ConfigDigest,
 	database types.Database,
 	databaseTimeout time.Duration,
@@ -33,6 +36,7 @@ func Persist(
 		ctx,
 
 		chPersist,
+		clock,
 		configDigest,
 		database,
 		databaseTimeout,
@@ -49,8 +53,10 @@ func Persist(
 // written state.
 func (ps *persistState) run() {
 	for {
+		clock.Block(ps.clock)
 		select {
 		case state, ok := <-ps.chPersist:
+			clock.Unblock(ps.clock)
 			if !ok {
 				ps.logger.Error("Persist: chPersist closed unexpectedly, can no longer persist state. This should *not* happen.", types.LogFields{
 					"lastWrittenState": ps.writtenState,
@@ -70,10 +76,13 @@ func (ps *persistState) run() {
 				default:
 					break DrainChannel
 				}
+				clock.Woken(ps.clock)
 			}
+			clock.Woken(ps.clock)
 			ps.writeIfNew(state)
 
 		case <-ps.ctx.Done():
+			clock.Unblock(ps.clock)
 			ps.logger.Debug("Persist: exiting", nil)
 			return
 		}
@@ -87,7 +96,7 @@ func (ps *persistState) writeIfNew(pendingState types.PersistentState) {
 		return
 	}
 
-	writeCtx, writeCancel := context.WithTimeout(ps.ctx, ps.databaseTimeout)
+	writeCtx, writeCancel := ps.clock.WithTimeout(ps.ctx, ps.databaseTimeout)
 	defer writeCancel()
 	err := ps.database.WriteState(
 		writeCtx,
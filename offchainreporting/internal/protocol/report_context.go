@@ -30,6 +30,21 @@ func (r ReportContext) DomainSeparationTag() (d DomainSeparationTag) {
 	return d
 }
 
+// ReportContext returns the ReportContext d was derived from, and false if d
+// isn't zero-padded like the result of ReportContext.DomainSeparationTag
+func (d DomainSeparationTag) ReportContext() (ReportContext, bool) {
+	for _, b := range d[:11] {
+		if b != 0 {
+			return ReportContext{}, false
+		}
+	}
+	var r ReportContext
+	copy(r.ConfigDigest[:], d[11:27])
+	r.Epoch = binary.BigEndian.Uint32(d[27:31])
+	r.Round = d[31]
+	return r, true
+}
+
 func (r ReportContext) Equal(r2 ReportContext) bool {
 	return r.ConfigDigest == r2.ConfigDigest && r.Epoch == r2.Epoch && r.Round == r2.Round
 }
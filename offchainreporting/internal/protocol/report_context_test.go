@@ -0,0 +1,24 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func TestDomainSeparationTagReportContext(t *testing.T) {
+	repctx := ReportContext{types.ConfigDigest{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0x01020304, 5}
+	tag := repctx.DomainSeparationTag()
+	parsed, ok := tag.ReportContext()
+	if !ok || !parsed.Equal(repctx) {
+		t.Fatalf("%v became %v, %v", repctx, parsed, ok)
+	}
+
+	for i := 0; i < 11; i++ {
+		padded := tag
+		padded[i] = 1
+		if _, ok := padded.ReportContext(); ok {
+			t.Errorf("tag with non-zero padding byte %d was accepted", i)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/SeerLink/libocr/clock"
 	"github.com/SeerLink/libocr/offchainreporting/internal/config"
 	"github.com/SeerLink/libocr/offchainreporting/loghelper"
 	"github.com/SeerLink/libocr/offchainreporting/types"
@@ -18,6 +19,8 @@ func RunReportGeneration(
 	chNetToReportGeneration <-chan MessageToReportGenerationWithSender,
 	chReportGenerationToPacemaker chan<- EventToPacemaker,
 	chReportGenerationToTransmission chan<- EventToTransmission,
+	chStatusToReportGeneration <-chan chan<- reportGenerationStatus,
+	clock clock.Clock,
 	config config.SharedConfig,
 	contractTransmitter types.ContractTransmitter,
 	datasource types.DataSource,
@@ -26,8 +29,10 @@ func RunReportGeneration(
 	l types.OracleID,
 	localConfig types.LocalConfig,
 	logger types.Logger,
+	metrics *Metrics,
 	netSender NetworkSender,
 	privateKeys types.PrivateKeys,
+	spanExporter types.SpanExporter,
 	telemetrySender TelemetrySender,
 ) {
 	repgen := reportGenerationState{
@@ -37,6 +42,8 @@ func RunReportGeneration(
 		chNetToReportGeneration:          chNetToReportGeneration,
 		chReportGenerationToPacemaker:    chReportGenerationToPacemaker,
 		chReportGenerationToTransmission: chReportGenerationToTransmission,
+		chStatusToReportGeneration:       chStatusToReportGeneration,
+		clock:                            clock,
 		config:                           config,
 		contractTransmitter:              contractTransmitter,
 		datasource:                       datasource,
@@ -45,9 +52,11 @@ func RunReportGeneration(
 		l:                                l,
 		localConfig:                      localConfig,
 		logger:                           loghelper.MakeLoggerWithContext(logger, types.LogFields{"epoch": e, "leader": l}),
+		metrics:                          metrics,
 		netSender:                        netSender,
 		privateKeys:                      privateKeys,
 		telemetrySender:                  telemetrySender,
+		tracer:                           tracer{clock, spanExporter, id},
 	}
 	repgen.run()
 }
@@ -59,6 +68,8 @@ type reportGenerationState struct {
 	chNetToReportGeneration          <-chan MessageToReportGenerationWithSender
 	chReportGenerationToPacemaker    chan<- EventToPacemaker
 	chReportGenerationToTransmission chan<- EventToTransmission
+	chStatusToReportGeneration       <-chan chan<- reportGenerationStatus
+	clock                            clock.Clock
 	config                           config.SharedConfig
 	contractTransmitter              types.ContractTransmitter
 	datasource                       types.DataSource
@@ -67,9 +78,11 @@ type reportGenerationState struct {
 	l                                types.OracleID // Current leader number
 	localConfig                      types.LocalConfig
 	logger                           types.Logger
+	metrics                          *Metrics
 	netSender                        NetworkSender
 	privateKeys                      types.PrivateKeys
 	telemetrySender                  TelemetrySender
+	tracer                           tracer
 
 	leaderState   leaderState
 	followerState followerState
@@ -87,14 +100,25 @@ type leaderState struct {
 
 	// tRound is a heartbeat indicating when the current leader should start a new
 	// round.
-	tRound <-chan time.Time
+	tRound timer
 
 	// tGrace is a grace period the leader waits for after it has achieved
 	// quorum on "observe" messages, to allow slower oracles time to submit their
 	// observations.
-	tGrace <-chan time.Time
+	tGrace timer
 
 	phase phase
+
+	// span traces the current round from the leader's perspective. It starts
+	// when the observe-req is sent, and ends when the final message is sent or
+	// the next round starts.
+	span *span
+
+	// graceSpan traces the grace period
+	graceSpan *span
+
+	// reportReqSent is the time at which the report-req was sent
+	reportReqSent time.Time
 }
 
 type followerState struct {
@@ -116,6 +140,35 @@ type followerState struct {
 	// completedRound tracks whether the current oracle has completed the current
 	// round
 	completedRound bool
+
+	// span traces the current round from the follower's perspective. It starts
+	// when the observe-req is received, and ends when the round is completed.
+	span *span
+
+	// finalEchoSpan traces the final echo phase, from the receipt of the first
+	// valid final or final-echo message until enough final echos have been
+	// received
+	finalEchoSpan *span
+
+	// reportReq is the report-req accepted during this round, and
+	// reportReqDigest its digest
+	reportReq       *MessageReportReq
+	reportReqDigest ReportReqDigest
+
+	// receivedReportReqDigests' j-th entry is the digest of the report-req the
+	// j-th oracle accepted during this round, if it has told us
+	receivedReportReqDigests []*ReportReqDigest
+
+	// sentReportReqConflict's and receivedReportReqConflict's j-th entries
+	// indicate whether we have sent our report-req to, or received a
+	// report-req from, the j-th oracle during this round
+	sentReportReqConflict     []bool
+	receivedReportReqConflict []bool
+
+	// reportedObserverEquivocation tracks the equivocation already recorded
+	// during this round, so that each instance is recorded only once, however
+	// many followers it is cross-checked with
+	reportedObserverEquivocation []bool
 }
 
 // Run starts the event loop for the report-generation protocol
@@ -129,6 +182,7 @@ func (repgen *reportGenerationState) run() {
 	repgen.followerState.receivedEcho = make([]bool, repgen.config.N())
 	repgen.followerState.sentEcho = nil
 	repgen.followerState.completedRound = false
+	repgen.resetCrossCheck()
 
 	// kick off the protocol
 	if repgen.id == repgen.l {
@@ -138,19 +192,33 @@ func (repgen *reportGenerationState) run() {
 	// Event Loop
 	chDone := repgen.ctx.Done()
 	for {
+		clock.Block(repgen.clock)
 		select {
 		case msg := <-repgen.chNetToReportGeneration:
+			clock.Unblock(repgen.clock)
+			clock.Woken(repgen.clock)
 			msg.msg.processReportGeneration(repgen, msg.sender)
-		case <-repgen.leaderState.tGrace:
+		case ch := <-repgen.chStatusToReportGeneration:
+			clock.Unblock(repgen.clock)
+			ch <- repgen.status()
+		case <-repgen.leaderState.tGrace.C():
+			clock.Unblock(repgen.clock)
+			clock.Woken(repgen.clock)
 			repgen.eventTGraceTimeout()
-		case <-repgen.leaderState.tRound:
+		case <-repgen.leaderState.tRound.C():
+			clock.Unblock(repgen.clock)
+			clock.Woken(repgen.clock)
 			repgen.eventTRoundTimeout()
 		case <-chDone:
+			clock.Unblock(repgen.clock)
 		}
 
 		// ensure prompt exit
 		select {
 		case <-chDone:
+			repgen.leaderState.tGrace.stop()
+			repgen.leaderState.tRound.stop()
+			repgen.abortSpans()
 			repgen.logger.Info("ReportGeneration: exiting", types.LogFields{
 				"e": repgen.e,
 				"l": repgen.l,
@@ -160,3 +228,14 @@ func (repgen *reportGenerationState) run() {
 		}
 	}
 }
+
+// abortSpans ends the spans of the rounds in progress when report generation
+// exits, so that they are exported even though the rounds never complete
+func (repgen *reportGenerationState) abortSpans() {
+	repgen.leaderState.graceSpan.end()
+	repgen.leaderState.span.setAttributes(types.LogFields{"aborted": true})
+	repgen.leaderState.span.end()
+	repgen.followerState.finalEchoSpan.end()
+	repgen.followerState.span.setAttributes(types.LogFields{"aborted": true})
+	repgen.followerState.span.end()
+}
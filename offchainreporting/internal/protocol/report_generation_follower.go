@@ -4,9 +4,9 @@ import (
 	"context"
 	"math/big"
 	"sort"
-	"time"
 
 	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/clock"
 	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
 	"github.com/SeerLink/libocr/offchainreporting/internal/signature"
 	"github.com/SeerLink/libocr/offchainreporting/types"
@@ -75,9 +75,11 @@ func (repgen *reportGenerationState) messageObserveReq(msg MessageObserveReq, se
 				"messageRound": msg.Round,
 				"roundMax":     repgen.config.RMax,
 			})
+		clock.Wake(repgen.clock)
 		select {
 		case repgen.chReportGenerationToPacemaker <- EventChangeLeader{}:
 		case <-repgen.ctx.Done():
+			clock.Woken(repgen.clock)
 		}
 
 		return
@@ -93,6 +95,11 @@ func (repgen *reportGenerationState) messageObserveReq(msg MessageObserveReq, se
 	repgen.followerState.sentReport = false
 	repgen.followerState.completedRound = false
 	repgen.followerState.receivedEcho = make([]bool, repgen.config.N())
+	repgen.resetCrossCheck()
+	repgen.followerState.finalEchoSpan.end()
+	repgen.followerState.span.end()
+	repgen.followerState.span = repgen.tracer.start(repgen.followerReportContext(), "followerRound")
+	repgen.followerState.finalEchoSpan = nil
 
 	repgen.telemetrySender.RoundStarted(
 		repgen.config.ConfigDigest,
@@ -100,11 +107,16 @@ func (repgen *reportGenerationState) messageObserveReq(msg MessageObserveReq, se
 		repgen.followerState.r,
 		repgen.l,
 	)
+	repgen.metrics.roundsStarted.Inc()
+
+	observeSpan := repgen.followerState.span.startChild("observe")
+	defer observeSpan.end()
 
 	value := repgen.observeValue()
 	if value.IsMissingValue() {
 		// Failed to get data from API, nothing to be done...
 		// No need to log because observeValue already does
+		observeSpan.setAttributes(types.LogFields{"missingValue": true})
 		return
 	}
 
@@ -171,8 +183,9 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 			"round": repgen.followerState.r, "msgRound": msg.Round})
 		return
 	}
-	err := repgen.verifyReportReq(msg)
+	err := repgen.verifyReportReq(msg, "reportReq")
 	if err != nil {
+		repgen.recordMisbehavior("reportReq", msg.Round, sender, msg, nil, err)
 		repgen.logger.Error("messageReportReq: could not validate report sent by leader", types.LogFields{
 			"round": repgen.followerState.r,
 			"error": err,
@@ -180,8 +193,14 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 		})
 		return
 	}
+	repgen.crossCheckReportReq(msg)
+
+	reportSpan := repgen.followerState.span.startChild("report")
+	defer reportSpan.end()
 
-	if repgen.shouldReport(msg.AttributedSignedObservations) {
+	shouldReport := repgen.shouldReport(msg.AttributedSignedObservations)
+	reportSpan.setAttributes(types.LogFields{"shouldReport": shouldReport})
+	if shouldReport {
 		attributedValues := make([]AttributedObservation, len(msg.AttributedSignedObservations))
 		for i, aso := range msg.AttributedSignedObservations {
 			// Observation/Observer attribution is verified by checking signature in verifyReportReq
@@ -222,6 +241,15 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 			}
 		}
 
+		if median, err := report.AttributedObservations.Median(); err == nil {
+			observers := make([]types.OracleID, 0, len(report.AttributedObservations))
+			for _, ao := range report.AttributedObservations {
+				observers = append(observers, ao.Observer)
+			}
+			repgen.telemetrySender.ReportGenerated(repgen.config.ConfigDigest,
+				repgen.e, repgen.followerState.r, median, observers)
+		}
+
 		repgen.followerState.sentReport = true
 		repgen.netSender.SendTo(
 			MessageReport{
@@ -262,9 +290,13 @@ func (repgen *reportGenerationState) messageFinal(
 		repgen.logger.Debug("MessageFinal after already sent MessageFinalEcho", nil)
 		return
 	}
-	if !repgen.verifyAttestedReport(msg.Report, sender) {
+	if !repgen.verifyAttestedReport(msg, msg.Round, msg.Report, sender) {
 		return
 	}
+	repgen.followerState.span.event("final", types.LogFields{"sender": sender})
+	if repgen.followerState.finalEchoSpan == nil {
+		repgen.followerState.finalEchoSpan = repgen.followerState.span.startChild("finalEcho")
+	}
 	repgen.followerState.sentEcho = &msg.Report
 	repgen.netSender.Broadcast(MessageFinalEcho{MessageFinal: msg})
 }
@@ -297,11 +329,14 @@ func (repgen *reportGenerationState) messageFinalEcho(msg MessageFinalEcho,
 		repgen.logger.Debug("received final echo after round completion", nil)
 		return
 	}
-	if !repgen.verifyAttestedReport(msg.Report, sender) { // if verify-attested-report(O) then
+	if !repgen.verifyAttestedReport(msg, msg.Round, msg.Report, sender) { // if verify-attested-report(O) then
 		// log messages are in verifyAttestedReport
 		return
 	}
 	repgen.followerState.receivedEcho[sender] = true // receivedecho[j] ← true
+	if repgen.followerState.finalEchoSpan == nil {
+		repgen.followerState.finalEchoSpan = repgen.followerState.span.startChild("finalEcho")
+	}
 
 	if repgen.followerState.sentEcho == nil { // if sentecho = ⊥ then
 		repgen.followerState.sentEcho = &msg.Report // sentecho ← O
@@ -317,6 +352,9 @@ func (repgen *reportGenerationState) messageFinalEcho(msg MessageFinalEcho,
 			}
 		}
 		if repgen.config.F < count {
+			repgen.followerState.finalEchoSpan.setAttributes(types.LogFields{"echoCount": count})
+			repgen.followerState.finalEchoSpan.end()
+			clock.Wake(repgen.clock)
 			select {
 			case repgen.chReportGenerationToTransmission <- EventTransmit{
 				repgen.e,
@@ -324,6 +362,7 @@ func (repgen *reportGenerationState) messageFinalEcho(msg MessageFinalEcho,
 				*repgen.followerState.sentEcho,
 			}:
 			case <-repgen.ctx.Done():
+				clock.Woken(repgen.clock)
 			}
 			repgen.completeRound()
 		}
@@ -340,6 +379,7 @@ func (repgen *reportGenerationState) observeValue() observation.Observation {
 	// We want to make sure we don't wait too long in order to not drop out of the
 	// protocol. Even if an instance cannot make observations, it can still be useful,
 	// e.g. by signing reports.
+	start := repgen.clock.Now()
 	ok := repgen.subprocesses.BlockForAtMost(
 		repgen.ctx,
 		repgen.localConfig.DataSourceTimeout,
@@ -353,27 +393,39 @@ func (repgen *reportGenerationState) observeValue() observation.Observation {
 		},
 	)
 
+	latency := repgen.clock.Now().Sub(start)
+	repgen.metrics.dataSourceLatency.Observe(latency.Seconds())
+
 	if !ok {
+		repgen.metrics.dataSourceErrors.Inc("timeout")
 		repgen.logger.Error("DataSource timed out", types.LogFields{
 			"round":   repgen.followerState.r,
 			"timeout": repgen.localConfig.DataSourceTimeout,
 		})
+		repgen.telemetrySender.ObservationMade(repgen.config.ConfigDigest, repgen.e,
+			repgen.followerState.r, observation.Observation{}, latency,
+			errors.Errorf("timed out after %v", repgen.localConfig.DataSourceTimeout))
 		return observation.Observation{}
 	}
 
 	if err != nil {
+		repgen.metrics.dataSourceErrors.Inc("error")
 		repgen.logger.Error("DataSource errored", types.LogFields{
 			"round": repgen.followerState.r,
 			"error": err,
 		})
+		repgen.telemetrySender.ObservationMade(repgen.config.ConfigDigest, repgen.e,
+			repgen.followerState.r, observation.Observation{}, latency, err)
 		return observation.Observation{}
 	}
 
+	repgen.telemetrySender.ObservationMade(repgen.config.ConfigDigest, repgen.e,
+		repgen.followerState.r, value, latency, nil)
 	return value
 }
 
 func (repgen *reportGenerationState) shouldReport(observations []AttributedSignedObservation) bool {
-	ctx, cancel := context.WithTimeout(repgen.ctx, repgen.localConfig.BlockchainTimeout)
+	ctx, cancel := repgen.clock.WithTimeout(repgen.ctx, repgen.localConfig.BlockchainTimeout)
 	defer cancel()
 	contractConfigDigest, contractEpoch, contractRound, rawAnswer, timestamp,
 		err := repgen.contractTransmitter.LatestTransmissionDetails(ctx)
@@ -398,9 +450,21 @@ func (repgen *reportGenerationState) shouldReport(observations []AttributedSigne
 	}
 
 	initialRound := contractConfigDigest == repgen.config.ConfigDigest && contractEpoch == 0 && contractRound == 0
-	deviation := observations[len(observations)/2].SignedObservation.Observation.Deviates(answer, repgen.config.AlphaPPB)
-	deltaCTimeout := timestamp.Add(repgen.config.DeltaC).Before(time.Now())
-	result := initialRound || deviation || deltaCTimeout
+	deviation := observations[len(observations)/2].SignedObservation.Observation.Deviates(answer, repgen.config.DeviationRule)
+	now := repgen.clock.Now()
+	deltaCTimeout := timestamp.Add(repgen.config.DeltaC).Before(now)
+	heartbeatDue := repgen.config.Heartbeat.DueInRound(timestamp, now, repgen.config.DeltaRound)
+	result := initialRound || deviation || deltaCTimeout || heartbeatDue
+	repgen.telemetrySender.ShouldReportDecision(
+		repgen.config.ConfigDigest,
+		repgen.e,
+		repgen.followerState.r,
+		result,
+		deviation,
+		deltaCTimeout,
+		initialRound,
+		heartbeatDue,
+	)
 
 	repgen.logger.Info("shouldReport: returning result", types.LogFields{
 		"round":         repgen.followerState.r,
@@ -408,6 +472,7 @@ func (repgen *reportGenerationState) shouldReport(observations []AttributedSigne
 		"initialRound":  initialRound,
 		"deviation":     deviation,
 		"deltaCTimeout": deltaCTimeout,
+		"heartbeatDue":  heartbeatDue,
 	})
 
 	return result
@@ -422,17 +487,22 @@ func (repgen *reportGenerationState) completeRound() {
 		"round": repgen.followerState.r,
 	})
 	repgen.followerState.completedRound = true
+	repgen.followerState.span.end()
+	repgen.metrics.roundsCompleted.Inc()
 
+	clock.Wake(repgen.clock)
 	select {
 	case repgen.chReportGenerationToPacemaker <- EventProgress{}:
 	case <-repgen.ctx.Done():
+		clock.Woken(repgen.clock)
 	}
 }
 
 // verifyReportReq errors unless the reports observations are sorted, its
 // signatures are all correct given the current round/epoch/config, and from
-// distinct oracles, and there are more than 2f observations.
-func (repgen *reportGenerationState) verifyReportReq(msg MessageReportReq) error {
+// distinct oracles, and there are more than 2f observations. Invalid
+// signatures are counted under the message label message.
+func (repgen *reportGenerationState) verifyReportReq(msg MessageReportReq, message string) error {
 	// check sortedness
 	if !sort.SliceIsSorted(msg.AttributedSignedObservations,
 		func(i, j int) bool {
@@ -458,6 +528,7 @@ func (repgen *reportGenerationState) verifyReportReq(msg MessageReportReq) error
 			}
 			observerOffchainPublicKey := repgen.config.OracleIdentities[obs.Observer].OffchainPublicKey
 			if err := obs.SignedObservation.Verify(repgen.followerReportContext(), observerOffchainPublicKey); err != nil {
+				repgen.metrics.signatureVerificationFailures.Inc(message)
 				return errors.Errorf("invalid signed observation: %s", err)
 			}
 		}
@@ -470,15 +541,19 @@ func (repgen *reportGenerationState) verifyReportReq(msg MessageReportReq) error
 	return nil
 }
 
-// verifyAttestedReport returns true iff the signatures on msg are valid
-// signatures by oracle participants
+// verifyAttestedReport returns true iff the signatures on report are valid
+// signatures by oracle participants. msg is the message carrying report for
+// round, which is recorded as evidence of misbehavior otherwise.
 func (repgen *reportGenerationState) verifyAttestedReport(
-	report AttestedReportMany, sender types.OracleID,
+	msg Message, round uint8, report AttestedReportMany, sender types.OracleID,
 ) bool {
 	if len(report.Signatures) <= repgen.config.F {
 		repgen.logger.Warn("verifyAttestedReport: dropping final report because "+
 			"it has too few signatures", types.LogFields{"sender": sender,
 			"numSignatures": len(report.Signatures), "F": repgen.config.F})
+		repgen.recordMisbehavior("attestedReport", round, sender, msg, nil,
+			errors.Errorf("report has %d signatures, need more than %d",
+				len(report.Signatures), repgen.config.F))
 		return false
 	}
 
@@ -490,6 +565,8 @@ func (repgen *reportGenerationState) verifyAttestedReport(
 
 	err := report.VerifySignatures(repgen.followerReportContext(), keys)
 	if err != nil {
+		repgen.metrics.signatureVerificationFailures.Inc("attestedReport")
+		repgen.recordMisbehavior("attestedReport", round, sender, msg, nil, err)
 		repgen.logger.Error("could not validate signatures on final report",
 			types.LogFields{
 				"round":  repgen.followerState.r,
@@ -2,7 +2,6 @@ package protocol
 
 import (
 	"sort"
-	"time"
 
 	"github.com/SeerLink/libocr/offchainreporting/types"
 )
@@ -53,12 +52,17 @@ func (repgen *reportGenerationState) startRound() {
 		})
 		return
 	}
+	repgen.leaderState.span.end()
+	repgen.leaderState.graceSpan.end()
 	repgen.leaderState.r = rPlusOne
 	repgen.leaderState.observe = make([]*SignedObservation, repgen.config.N())
 	repgen.leaderState.report = make([]*AttestedReportOne, repgen.config.N())
 	repgen.leaderState.phase = phaseObserve
+	repgen.leaderState.span = repgen.tracer.start(repgen.leaderReportContext(), "leaderRound")
+	repgen.leaderState.graceSpan = nil
 	repgen.netSender.Broadcast(MessageObserveReq{Epoch: repgen.e, Round: repgen.leaderState.r})
-	repgen.leaderState.tRound = time.After(repgen.config.DeltaRound)
+	repgen.leaderState.span.event("observeReq", nil)
+	repgen.leaderState.tRound.restart(repgen.clock, repgen.config.DeltaRound)
 }
 
 // messageObserve is called when the current leader has received an "observe"
@@ -103,15 +107,25 @@ func (repgen *reportGenerationState) messageObserve(msg MessageObserve, sender t
 		return
 	}
 
-	if repgen.leaderState.observe[sender] != nil {
+	if previous := repgen.leaderState.observe[sender]; previous != nil {
 		repgen.logger.Debug("already sent an observation", types.LogFields{
 			"round":  repgen.leaderState.r,
 			"sender": sender,
 		})
+		if !previous.Observation.Equal(msg.SignedObservation.Observation) &&
+			msg.SignedObservation.Verify(repgen.leaderReportContext(), repgen.config.OracleIdentities[sender].OffchainPublicKey) == nil {
+			repgen.logger.Warn("oracle equivocated: signed two different observations", types.LogFields{
+				"round":  repgen.leaderState.r,
+				"sender": sender,
+			})
+			repgen.recordObserverEquivocation(sender, MessageObserve{repgen.e, repgen.leaderState.r, *previous}, msg)
+		}
 		return
 	}
 
 	if err := msg.SignedObservation.Verify(repgen.leaderReportContext(), repgen.config.OracleIdentities[sender].OffchainPublicKey); err != nil {
+		repgen.metrics.signatureVerificationFailures.Inc("observe")
+		repgen.recordMisbehavior("observe", msg.Round, sender, msg, nil, err)
 		repgen.logger.Warn("MessageObserve carries invalid SignedObservation", types.LogFields{
 			"round":  repgen.leaderState.r,
 			"sender": sender,
@@ -129,6 +143,8 @@ func (repgen *reportGenerationState) messageObserve(msg MessageObserve, sender t
 	})
 
 	repgen.leaderState.observe[sender] = &msg.SignedObservation
+	repgen.leaderState.span.recordChild("observe", repgen.leaderState.span.startTime(),
+		types.LogFields{"sender": sender, "phase": englishPhase[repgen.leaderState.phase]})
 
 	//upon (|{p_j ∈ P| observe[j] != ⊥}| > 2f) ∧ (phase = OBSERVE)
 	switch repgen.leaderState.phase {
@@ -149,8 +165,9 @@ func (repgen *reportGenerationState) messageObserve(msg MessageObserve, sender t
 			repgen.logger.Debug("starting observation grace period", types.LogFields{
 				"round": repgen.leaderState.r,
 			})
-			repgen.leaderState.tGrace = time.After(repgen.config.DeltaGrace)
+			repgen.leaderState.tGrace.restart(repgen.clock, repgen.config.DeltaGrace)
 			repgen.leaderState.phase = phaseGrace
+			repgen.leaderState.graceSpan = repgen.leaderState.span.startChild("grace")
 		}
 	case phaseGrace:
 		repgen.logger.Debug("accepted extra observation during grace period", nil)
@@ -180,12 +197,15 @@ func (repgen *reportGenerationState) eventTGraceTimeout() {
 	sort.Slice(asos, func(i, j int) bool {
 		return asos[i].SignedObservation.Observation.Less(asos[j].SignedObservation.Observation)
 	})
+	repgen.leaderState.graceSpan.end()
 	repgen.netSender.Broadcast(MessageReportReq{
 		repgen.e,
 		repgen.leaderState.r,
 		asos,
 	})
 	repgen.leaderState.phase = phaseReport
+	repgen.leaderState.reportReqSent = repgen.clock.Now()
+	repgen.leaderState.span.event("reportReq", types.LogFields{"observationCount": len(asos)})
 }
 
 func (repgen *reportGenerationState) messageReport(msg MessageReport, sender types.OracleID) {
@@ -219,6 +239,8 @@ func (repgen *reportGenerationState) messageReport(msg MessageReport, sender typ
 	a := types.OnChainSigningAddress(repgen.config.OracleIdentities[sender].OnChainSigningAddress)
 	err := msg.Report.Verify(repgen.leaderReportContext(), a)
 	if err != nil {
+		repgen.metrics.signatureVerificationFailures.Inc("report")
+		repgen.recordMisbehavior("report", msg.Round, sender, msg, nil, err)
 		repgen.logger.Error("could not validate signature", types.LogFields{
 			"round": repgen.leaderState.r,
 			"error": err,
@@ -228,6 +250,8 @@ func (repgen *reportGenerationState) messageReport(msg MessageReport, sender typ
 	}
 
 	repgen.leaderState.report[sender] = &msg.Report
+	repgen.leaderState.span.recordChild("report", repgen.leaderState.reportReqSent,
+		types.LogFields{"sender": sender})
 
 	// upon exists R s.t. |{p_j ∈ P | report[j]=(R,·)}| > f ∧ phase = REPORT
 	{ // FUTUREWORK: make it non-quadratic time
@@ -257,6 +281,8 @@ func (repgen *reportGenerationState) messageReport(msg MessageReport, sender typ
 				},
 			})
 			repgen.leaderState.phase = phaseFinal
+			repgen.leaderState.span.event("final", types.LogFields{"signatureCount": len(sigs)})
+			repgen.leaderState.span.end()
 		}
 	}
 }
@@ -0,0 +1,164 @@
+package protocol
+
+import (
+	"context"
+	"sort"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// StatusRequest asks a running oracle for a snapshot of its state. The oracle
+// sends the snapshot on Response, which must be buffered, unless Ctx is done
+// before all subprotocols have responded.
+type StatusRequest struct {
+	Ctx      context.Context
+	Response chan<- types.OracleStatus
+}
+
+type pacemakerStatus struct {
+	epoch     uint32
+	leader    types.OracleID
+	newEpochs []uint32
+}
+
+type reportGenerationStatus struct {
+	epoch       uint32
+	round       uint8
+	phase       string
+	leaderPhase string
+}
+
+type transmissionStatus struct {
+	pending []types.PendingTransmissionStatus
+	recent  []types.RoundOutcome
+}
+
+// maxRecentRounds is the number of round outcomes kept for status reporting
+const maxRecentRounds = 16
+
+// followerPhase describes the follower's progress through the current round
+func (repgen *reportGenerationState) followerPhase() string {
+	switch {
+	case repgen.followerState.completedRound:
+		return "completed"
+	case repgen.followerState.sentEcho != nil:
+		return "finalEcho"
+	case repgen.followerState.sentReport:
+		return "report"
+	default:
+		return "observe"
+	}
+}
+
+func (pace *pacemakerState) status() pacemakerStatus {
+	return pacemakerStatus{
+		pace.e,
+		pace.l,
+		append([]uint32{}, pace.newepoch...),
+	}
+}
+
+func (repgen *reportGenerationState) status() reportGenerationStatus {
+	leaderPhase := ""
+	if repgen.id == repgen.l {
+		leaderPhase = englishPhase[repgen.leaderState.phase]
+	}
+	return reportGenerationStatus{
+		repgen.e,
+		repgen.followerState.r,
+		repgen.followerPhase(),
+		leaderPhase,
+	}
+}
+
+func (t *transmissionState) status() transmissionStatus {
+	pending := make([]types.PendingTransmissionStatus, 0, t.times.Len())
+	for _, item := range t.times.internal {
+		pending = append(pending, types.PendingTransmissionStatus{
+			item.Epoch,
+			item.Round,
+			item.Time,
+			item.Median,
+		})
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Time.Before(pending[j].Time)
+	})
+	return transmissionStatus{
+		pending,
+		append([]types.RoundOutcome{}, t.recentRounds...),
+	}
+}
+
+// recordOutcome keeps track of the outcome for status reporting
+func (t *transmissionState) recordOutcome(epoch uint32, round uint8, outcome types.TransmissionOutcome, reason string) {
+	if len(t.recentRounds) == maxRecentRounds {
+		copy(t.recentRounds, t.recentRounds[1:])
+		t.recentRounds = t.recentRounds[:maxRecentRounds-1]
+	}
+	t.recentRounds = append(t.recentRounds, types.RoundOutcome{
+		epoch,
+		round,
+		t.clock.Now(),
+		outcome,
+		reason,
+	})
+}
+
+// collectStatus asks each subprotocol for its status and combines their
+// responses. The subprotocols respond from within their event loops, so that
+// they never need to synchronize access to their state.
+func (o *oracleState) collectStatus(req StatusRequest) {
+	chPacemaker := make(chan pacemakerStatus, 1)
+	chReportGeneration := make(chan reportGenerationStatus, 1)
+	chTransmission := make(chan transmissionStatus, 1)
+
+	select {
+	case o.chStatusToPacemaker <- chPacemaker:
+	case <-req.Ctx.Done():
+		return
+	case <-o.ctx.Done():
+		return
+	}
+	select {
+	case o.chStatusToReportGeneration <- chReportGeneration:
+	case <-req.Ctx.Done():
+		return
+	case <-o.ctx.Done():
+		return
+	}
+	select {
+	case o.chStatusToTransmission <- chTransmission:
+	case <-req.Ctx.Done():
+		return
+	case <-o.ctx.Done():
+		return
+	}
+
+	// All subprotocols have received their response channel, and respond
+	// without blocking.
+	pace := <-chPacemaker
+	repgen := <-chReportGeneration
+	t := <-chTransmission
+
+	if repgen.epoch != pace.epoch {
+		// report generation for the current epoch hasn't picked up the request,
+		// its predecessor has
+		repgen = reportGenerationStatus{}
+	}
+	req.Response <- types.OracleStatus{
+		true,
+		o.Config.ConfigDigest,
+		o.id,
+		o.Config.F,
+		pace.epoch,
+		pace.leader,
+		pace.newEpochs,
+		repgen.round,
+		repgen.phase,
+		repgen.leaderPhase,
+		t.pending,
+		t.recent,
+		nil,
+	}
+}
@@ -0,0 +1,120 @@
+package protocol
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// statusOracle returns an oracleState whose subprotocols answer status
+// requests with the given statuses
+func statusOracle(
+	ctx context.Context,
+	pace pacemakerStatus,
+	repgen reportGenerationStatus,
+	t transmissionStatus,
+) *oracleState {
+	chStatusToPacemaker := make(chan chan<- pacemakerStatus)
+	chStatusToReportGeneration := make(chan chan<- reportGenerationStatus)
+	chStatusToTransmission := make(chan chan<- transmissionStatus)
+	go func() {
+		for {
+			select {
+			case ch := <-chStatusToPacemaker:
+				ch <- pace
+			case ch := <-chStatusToReportGeneration:
+				ch <- repgen
+			case ch := <-chStatusToTransmission:
+				ch <- t
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &oracleState{
+		ctx:                        ctx,
+		Config:                     config.SharedConfig{PublicConfig: config.PublicConfig{F: 1, ConfigDigest: types.ConfigDigest{1}}},
+		id:                         2,
+		chStatusToPacemaker:        chStatusToPacemaker,
+		chStatusToReportGeneration: chStatusToReportGeneration,
+		chStatusToTransmission:     chStatusToTransmission,
+	}
+}
+
+func TestCollectStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pace := pacemakerStatus{3, 1, []uint32{3, 4, 3, 3}}
+	trans := transmissionStatus{
+		[]types.PendingTransmissionStatus{{2, 5, time.Unix(10, 0), nil}},
+		[]types.RoundOutcome{{2, 4, time.Unix(5, 0), types.TransmissionOutcomeTransmitted, ""}},
+	}
+	collect := func(repgen reportGenerationStatus) types.OracleStatus {
+		chResponse := make(chan types.OracleStatus, 1)
+		statusOracle(ctx, pace, repgen, trans).collectStatus(StatusRequest{ctx, chResponse})
+		select {
+		case status := <-chResponse:
+			return status
+		default:
+			t.Fatal("collectStatus did not respond")
+			return types.OracleStatus{}
+		}
+	}
+
+	expected := types.OracleStatus{
+		true,
+		types.ConfigDigest{1},
+		2,
+		1,
+		3,
+		1,
+		[]uint32{3, 4, 3, 3},
+		7,
+		"report",
+		"",
+		trans.pending,
+		trans.recent,
+		nil,
+	}
+	if status := collect(reportGenerationStatus{3, 7, "report", ""}); !reflect.DeepEqual(status, expected) {
+		t.Errorf("expected %+v, got %+v", expected, status)
+	}
+
+	// Report generation for epoch 2 answered before shutting down, so its
+	// status doesn't describe the current epoch
+	expected.Round, expected.Phase = 0, ""
+	if status := collect(reportGenerationStatus{2, 7, "report", "final"}); !reflect.DeepEqual(status, expected) {
+		t.Errorf("expected %+v, got %+v", expected, status)
+	}
+}
+
+func TestCollectStatusGivesUpWhenRequestIsDone(t *testing.T) {
+	oracleCtx, oracleCancel := context.WithCancel(context.Background())
+	defer oracleCancel()
+	// Nobody answers status requests
+	o := &oracleState{
+		ctx:                        oracleCtx,
+		chStatusToPacemaker:        make(chan chan<- pacemakerStatus),
+		chStatusToReportGeneration: make(chan chan<- reportGenerationStatus),
+		chStatusToTransmission:     make(chan chan<- transmissionStatus),
+	}
+
+	reqCtx, reqCancel := context.WithCancel(context.Background())
+	reqCancel()
+	chResponse := make(chan types.OracleStatus, 1)
+	o.collectStatus(StatusRequest{reqCtx, chResponse})
+	if len(chResponse) != 0 {
+		t.Errorf("collectStatus responded to a request that was done")
+	}
+
+	oracleCancel()
+	o.collectStatus(StatusRequest{context.Background(), chResponse})
+	if len(chResponse) != 0 {
+		t.Errorf("collectStatus responded after the oracle was closed")
+	}
+}
@@ -1,6 +1,11 @@
 package protocol
 
-import "github.com/SeerLink/libocr/offchainreporting/types"
+import (
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
 
 type TelemetrySender interface {
 	RoundStarted(
@@ -9,4 +14,88 @@ type TelemetrySender interface {
 		round uint8,
 		leader types.OracleID,
 	)
+
+	// ObservationMade reports the result of a call to the data source. value
+	// is missing if err is non-nil.
+	ObservationMade(
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		round uint8,
+		value observation.Observation,
+		latency time.Duration,
+		err error,
+	)
+
+	ReportGenerated(
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		round uint8,
+		median observation.Observation,
+		observers []types.OracleID,
+	)
+
+	ShouldReportDecision(
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		round uint8,
+		result bool,
+		deviation bool,
+		deltaCTimeout bool,
+		initialRound bool,
+		heartbeatDue bool,
+	)
+
+	TransmissionScheduled(
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		round uint8,
+		median observation.Observation,
+		delay time.Duration,
+	)
+
+	TransmissionSent(
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		round uint8,
+		median observation.Observation,
+	)
+
+	// TransmissionSkipped reports that a report wasn't transmitted. failed
+	// distinguishes failed transmission attempts from reports which weren't
+	// meant to be transmitted.
+	TransmissionSkipped(
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		round uint8,
+		failed bool,
+		reason string,
+	)
+
+	EpochChanged(
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		leader types.OracleID,
+	)
+
+	MisbehaviorDetected(evidence MisbehaviorEvidence)
+}
+
+// NopTelemetrySender discards everything
+type NopTelemetrySender struct{}
+
+var _ TelemetrySender = NopTelemetrySender{}
+
+func (NopTelemetrySender) RoundStarted(types.ConfigDigest, uint32, uint8, types.OracleID) {}
+func (NopTelemetrySender) ObservationMade(types.ConfigDigest, uint32, uint8, observation.Observation, time.Duration, error) {
+}
+func (NopTelemetrySender) ReportGenerated(types.ConfigDigest, uint32, uint8, observation.Observation, []types.OracleID) {
+}
+func (NopTelemetrySender) ShouldReportDecision(types.ConfigDigest, uint32, uint8, bool, bool, bool, bool, bool) {
+}
+func (NopTelemetrySender) TransmissionScheduled(types.ConfigDigest, uint32, uint8, observation.Observation, time.Duration) {
+}
+func (NopTelemetrySender) TransmissionSent(types.ConfigDigest, uint32, uint8, observation.Observation) {
 }
+func (NopTelemetrySender) TransmissionSkipped(types.ConfigDigest, uint32, uint8, bool, string) {}
+func (NopTelemetrySender) EpochChanged(types.ConfigDigest, uint32, types.OracleID)             {}
+func (NopTelemetrySender) MisbehaviorDetected(MisbehaviorEvidence)                             {}
@@ -0,0 +1,36 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+)
+
+// timer is a timeout which can be restarted and stopped. Restarting it stops
+// the previous timer, so that a timeout which is no longer selected on never
+// fires, which a tracking clock would otherwise wait to be received. The zero
+// value is stopped.
+type timer struct {
+	timer clock.Timer
+}
+
+// restart stops the timer and starts it again, to fire once d has elapsed
+func (t *timer) restart(c clock.Clock, d time.Duration) {
+	t.stop()
+	t.timer = c.NewTimer(d)
+}
+
+func (t *timer) stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+// C returns the channel on which the timer fires, or nil if it's stopped
+func (t *timer) C() <-chan time.Time {
+	if t.timer == nil {
+		return nil
+	}
+	return t.timer.C()
+}
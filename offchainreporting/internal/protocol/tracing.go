@@ -0,0 +1,162 @@
+package protocol
+
+import (
+	cryptorand "crypto/rand"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// tracer records spans for the rounds of the protocol and passes them on to a
+// types.SpanExporter. A tracer with a nil exporter records nothing.
+type tracer struct {
+	clock    clock.Clock
+	exporter types.SpanExporter
+	id       types.OracleID
+}
+
+// span is a span which hasn't ended yet. All methods on span are safe to call
+// on a nil *span, and do nothing in that case.
+type span struct {
+	clock    clock.Clock
+	exporter types.SpanExporter
+	span     types.Span
+}
+
+// roundTraceID returns the trace ID shared by all oracles for repctx's round
+func roundTraceID(repctx ReportContext) (traceID types.TraceID) {
+	tag := repctx.DomainSeparationTag()
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("seerlink offchain reporting v1 round trace"))
+	h.Write(tag[:])
+	copy(traceID[:], h.Sum(nil))
+	return traceID
+}
+
+func newSpanID() (spanID types.SpanID) {
+	if _, err := cryptorand.Read(spanID[:]); err != nil {
+		// assertion
+		panic(err)
+	}
+	return spanID
+}
+
+func (t tracer) attributes(repctx ReportContext) map[string]interface{} {
+	return map[string]interface{}{
+		"configDigest": repctx.ConfigDigest.Hex(),
+		"epoch":        repctx.Epoch,
+		"round":        repctx.Round,
+		"oid":          t.id,
+	}
+}
+
+// start starts a span without parent in repctx's round
+func (t tracer) start(repctx ReportContext, name string) *span {
+	if t.exporter == nil {
+		return nil
+	}
+	return &span{
+		t.clock,
+		t.exporter,
+		types.Span{
+			roundTraceID(repctx),
+			newSpanID(),
+			types.SpanID{},
+			name,
+			t.clock.Now(),
+			time.Time{},
+			t.attributes(repctx),
+		},
+	}
+}
+
+// record records a span without parent in repctx's round, for steps whose
+// start and end are known upfront
+func (t tracer) record(repctx ReportContext, name string, start, end time.Time, attributes types.LogFields) {
+	s := t.start(repctx, name)
+	if s == nil {
+		return
+	}
+	s.span.Start = start
+	s.setAttributes(attributes)
+	s.endAt(end)
+}
+
+// startChild starts a span whose parent is s
+func (s *span) startChild(name string) *span {
+	if s == nil {
+		return nil
+	}
+	attributes := make(map[string]interface{}, len(s.span.Attributes))
+	for k, v := range s.span.Attributes {
+		attributes[k] = v
+	}
+	return &span{
+		s.clock,
+		s.exporter,
+		types.Span{
+			s.span.TraceID,
+			newSpanID(),
+			s.span.SpanID,
+			name,
+			s.clock.Now(),
+			time.Time{},
+			attributes,
+		},
+	}
+}
+
+// recordChild records a span whose parent is s, which started at start and
+// ends now
+func (s *span) recordChild(name string, start time.Time, attributes types.LogFields) {
+	child := s.startChild(name)
+	if child == nil {
+		return
+	}
+	child.span.Start = start
+	child.setAttributes(attributes)
+	child.end()
+}
+
+// event records a zero-length span whose parent is s, for steps which happen
+// at an instant, such as sending a message
+func (s *span) event(name string, attributes types.LogFields) {
+	child := s.startChild(name)
+	child.setAttributes(attributes)
+	child.end()
+}
+
+func (s *span) setAttributes(attributes types.LogFields) {
+	if s == nil {
+		return
+	}
+	for k, v := range attributes {
+		s.span.Attributes[k] = v
+	}
+}
+
+// startTime returns the time at which s started, or the zero time if s is nil
+func (s *span) startTime() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.span.Start
+}
+
+// end ends s and exports it. Subsequent calls to end have no effect.
+func (s *span) end() {
+	if s == nil {
+		return
+	}
+	s.endAt(s.clock.Now())
+}
+
+func (s *span) endAt(end time.Time) {
+	if s == nil || !s.span.End.IsZero() {
+		return
+	}
+	s.span.End = end
+	s.exporter.ExportSpan(s.span)
+}
@@ -0,0 +1,143 @@
+package protocol
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// capturingExporter keeps all spans exported to it
+type capturingExporter struct {
+	mutex sync.Mutex
+	spans []types.Span
+}
+
+func (c *capturingExporter) ExportSpan(span types.Span) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.spans = append(c.spans, span)
+}
+
+// byName returns the exported spans by name, and fails if a span was exported
+// more than once
+func (c *capturingExporter) byName(t *testing.T) map[string][]types.Span {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	exported := map[types.SpanID]bool{}
+	rv := map[string][]types.Span{}
+	for _, s := range c.spans {
+		if exported[s.SpanID] {
+			t.Errorf("span %v (%s) exported more than once", s.SpanID, s.Name)
+		}
+		exported[s.SpanID] = true
+		rv[s.Name] = append(rv[s.Name], s)
+	}
+	return rv
+}
+
+func TestRoundTraceID(t *testing.T) {
+	repctx := ReportContext{types.ConfigDigest{1}, 2, 3}
+	if roundTraceID(repctx) != roundTraceID(ReportContext{types.ConfigDigest{1}, 2, 3}) {
+		t.Errorf("trace ID of a round must be the same for all oracles")
+	}
+	for _, other := range []ReportContext{
+		{types.ConfigDigest{2}, 2, 3},
+		{types.ConfigDigest{1}, 3, 3},
+		{types.ConfigDigest{1}, 2, 4},
+	} {
+		if roundTraceID(repctx) == roundTraceID(other) {
+			t.Errorf("rounds %v and %v have the same trace ID", repctx, other)
+		}
+	}
+}
+
+func TestSpanParentsAndSingleExport(t *testing.T) {
+	exporter := &capturingExporter{}
+	clk := clock.NewVirtual(time.Unix(0, 0))
+	repctx := ReportContext{types.ConfigDigest{1}, 2, 3}
+	tr := tracer{clk, exporter, 1}
+
+	round := tr.start(repctx, "round")
+	child := round.startChild("child")
+	clk.Advance(time.Second)
+	child.end()
+	child.end()
+	round.event("event", types.LogFields{"k": "v"})
+	round.recordChild("recorded", time.Unix(0, 0), nil)
+	round.end()
+	round.end()
+	tr.record(repctx, "standalone", time.Unix(0, 0), time.Unix(1, 0), nil)
+
+	spans := exporter.byName(t)
+	for _, name := range []string{"round", "child", "event", "recorded", "standalone"} {
+		if len(spans[name]) != 1 {
+			t.Fatalf("expected one %s span, got %v", name, spans[name])
+		}
+	}
+	r := spans["round"][0]
+	if r.TraceID != roundTraceID(repctx) || r.ParentSpanID != (types.SpanID{}) {
+		t.Errorf("round span has trace %v and parent %v", r.TraceID, r.ParentSpanID)
+	}
+	for _, name := range []string{"child", "event", "recorded"} {
+		s := spans[name][0]
+		if s.TraceID != r.TraceID || s.ParentSpanID != r.SpanID {
+			t.Errorf("%s span has trace %v and parent %v, expected %v and %v",
+				name, s.TraceID, s.ParentSpanID, r.TraceID, r.SpanID)
+		}
+		if s.Attributes["oid"] != types.OracleID(1) || s.Attributes["round"] != uint8(3) {
+			t.Errorf("%s span didn't inherit the round's attributes: %v", name, s.Attributes)
+		}
+	}
+	if s := spans["standalone"][0]; s.TraceID != r.TraceID || s.ParentSpanID != (types.SpanID{}) {
+		t.Errorf("standalone span has trace %v and parent %v", s.TraceID, s.ParentSpanID)
+	}
+	if !spans["child"][0].End.Equal(time.Unix(1, 0)) {
+		t.Errorf("child span ended at %v, expected the first call to end", spans["child"][0].End)
+	}
+
+	var nilSpan *span
+	nilSpan.startChild("child").end()
+	nilSpan.event("event", nil)
+	tracer{clk, nil, 1}.start(repctx, "round").end()
+	if len(exporter.spans) != 5 {
+		t.Errorf("spans without exporter were exported")
+	}
+}
+
+func TestAbortSpansExportsOpenSpans(t *testing.T) {
+	exporter := &capturingExporter{}
+	clk := clock.NewVirtual(time.Unix(0, 0))
+	repgen := &reportGenerationState{
+		clock:  clk,
+		e:      2,
+		id:     1,
+		l:      1,
+		tracer: tracer{clk, exporter, 1},
+	}
+	repgen.leaderState.r = 3
+	repgen.followerState.r = 3
+	repgen.leaderState.span = repgen.tracer.start(repgen.leaderReportContext(), "leaderRound")
+	repgen.leaderState.graceSpan = repgen.leaderState.span.startChild("grace")
+	repgen.followerState.span = repgen.tracer.start(repgen.followerReportContext(), "followerRound")
+	repgen.followerState.finalEchoSpan = repgen.followerState.span.startChild("finalEcho")
+
+	repgen.abortSpans()
+	repgen.abortSpans()
+
+	spans := exporter.byName(t)
+	for _, name := range []string{"leaderRound", "grace", "followerRound", "finalEcho"} {
+		if len(spans[name]) != 1 {
+			t.Errorf("expected one %s span after abort, got %v", name, spans[name])
+		}
+	}
+	for _, name := range []string{"leaderRound", "followerRound"} {
+		for _, s := range spans[name] {
+			if s.Attributes["aborted"] != true {
+				t.Errorf("%s span isn't marked as aborted: %v", name, s.Attributes)
+			}
+		}
+	}
+}
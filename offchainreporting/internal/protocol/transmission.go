@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/clock"
 	"github.com/SeerLink/libocr/offchainreporting/internal/config"
 	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
 	"github.com/SeerLink/libocr/offchainreporting/types"
@@ -28,10 +29,15 @@ func RunTransmission(
 
 	config config.SharedConfig,
 	chReportGenerationToTransmission <-chan EventToTransmission,
+	chStatusToTransmission <-chan chan<- transmissionStatus,
+	clock clock.Clock,
 	database types.Database,
 	id types.OracleID,
 	localConfig types.LocalConfig,
 	logger types.Logger,
+	metrics *Metrics,
+	spanExporter types.SpanExporter,
+	telemetrySender TelemetrySender,
 	transmitter types.ContractTransmitter,
 ) {
 	t := transmissionState{
@@ -40,10 +46,15 @@ func RunTransmission(
 
 		config:                           config,
 		chReportGenerationToTransmission: chReportGenerationToTransmission,
+		chStatusToTransmission:           chStatusToTransmission,
+		clock:                            clock,
 		database:                         database,
 		id:                               id,
 		localConfig:                      localConfig,
 		logger:                           logger,
+		metrics:                          metrics,
+		telemetrySender:                  telemetrySender,
+		tracer:                           tracer{clock, spanExporter, id},
 		transmitter:                      transmitter,
 	}
 	t.run()
@@ -55,16 +66,24 @@ type transmissionState struct {
 
 	config                           config.SharedConfig
 	chReportGenerationToTransmission <-chan EventToTransmission
+	chStatusToTransmission           <-chan chan<- transmissionStatus
+	clock                            clock.Clock
 	database                         types.Database
 	id                               types.OracleID
 	localConfig                      types.LocalConfig
 	logger                           types.Logger
+	metrics                          *Metrics
+	telemetrySender                  TelemetrySender
+	tracer                           tracer
 	transmitter                      types.ContractTransmitter
 
 	latestEpochRound EpochRound
 	latestMedian     observation.Observation
 	times            MinHeapTimeToPendingTransmission
-	tTransmit        <-chan time.Time
+	tTransmit        timer
+	// recentRounds holds the outcomes of the latest reports, for status
+	// reporting
+	recentRounds []types.RoundOutcome
 }
 
 // run runs the event loop for the local transmission protocol
@@ -73,17 +92,27 @@ func (t *transmissionState) run() {
 
 	chDone := t.ctx.Done()
 	for {
+		clock.Block(t.clock)
 		select {
 		case ev := <-t.chReportGenerationToTransmission:
+			clock.Unblock(t.clock)
+			clock.Woken(t.clock)
 			ev.processTransmission(t)
-		case <-t.tTransmit:
+		case ch := <-t.chStatusToTransmission:
+			clock.Unblock(t.clock)
+			ch <- t.status()
+		case <-t.tTransmit.C():
+			clock.Unblock(t.clock)
+			clock.Woken(t.clock)
 			t.eventTTransmitTimeout()
 		case <-chDone:
+			clock.Unblock(t.clock)
 		}
 
 		// ensure prompt exit
 		select {
 		case <-chDone:
+			t.tTransmit.stop()
 			t.logger.Info("Transmission: exiting", nil)
 			return
 		default:
@@ -92,7 +121,7 @@ func (t *transmissionState) run() {
 }
 
 func (t *transmissionState) restoreFromDatabase() {
-	childCtx, childCancel := context.WithTimeout(t.ctx, t.localConfig.DatabaseTimeout)
+	childCtx, childCancel := t.clock.WithTimeout(t.ctx, t.localConfig.DatabaseTimeout)
 	defer childCancel()
 	pending, err := t.database.PendingTransmissionsWithConfigDigest(childCtx, t.config.ConfigDigest)
 	if err != nil {
@@ -100,7 +129,7 @@ func (t *transmissionState) restoreFromDatabase() {
 		return
 	}
 
-	now := time.Now()
+	now := t.clock.Now()
 
 	// insert non-expired transmissions into queue
 	for key, trans := range pending {
@@ -131,7 +160,7 @@ func (t *transmissionState) restoreFromDatabase() {
 
 	// if queue isn't empty, set tTransmit to expire at next transmission time
 	if t.times.Len() != 0 {
-		t.tTransmit = time.After(now.Sub(t.times.Peek().Time))
+		t.tTransmit.restart(t.clock, t.times.Peek().Time.Sub(now))
 	}
 }
 
@@ -142,9 +171,10 @@ func (t *transmissionState) eventTransmit(ev EventTransmit) {
 	})
 
 	{
-		contractConfigDigest, contractEpochRound, err := t.contractState()
+		contractConfigDigest, contractEpochRound, contractTimestamp, err := t.contractState()
 		if err != nil {
 			t.logger.Error("contractEpoch() failed during eventTransmit", types.LogFields{"error": err})
+			t.reportNotTransmitted(ev.Epoch, ev.Round, "could not read contract state")
 			return
 		}
 
@@ -154,29 +184,32 @@ func (t *transmissionState) eventTransmit(ev EventTransmit) {
 				"contractConfigDigest": contractConfigDigest,
 				"configDigest":         t.config.ConfigDigest,
 			})
+			t.reportNotTransmitted(ev.Epoch, ev.Round, "configDigest mismatch")
 			return
 		}
 
-		if !t.shouldTransmit(ev, contractEpochRound) {
+		if !t.shouldTransmit(ev, contractEpochRound, contractTimestamp) {
 			t.logger.Info("eventTransmit(ev): discarding ev because shouldTransmit returned false", types.LogFields{
 				"ev":                   ev,
 				"contractConfigDigest": contractConfigDigest,
 				"contractEpochRound":   contractEpochRound,
 			})
+			t.reportNotTransmitted(ev.Epoch, ev.Round, "shouldTransmit returned false")
 			return
 		}
 	}
 
 	var err error
+	now := t.clock.Now()
 	t.latestEpochRound = EpochRound{ev.Epoch, ev.Round}
 	t.latestMedian, err = ev.Report.AttributedObservations.Median()
 	if err != nil {
 		t.logger.Error("could not compute median", types.LogFields{"error": err})
 	}
 
-	now := time.Now()
 	delayMaybe := t.transmitDelay(ev.Epoch, ev.Round)
 	if delayMaybe == nil {
+		t.reportNotTransmitted(ev.Epoch, ev.Round, "not scheduled to transmit")
 		return
 	}
 	delay := *delayMaybe
@@ -187,6 +220,7 @@ func (t *transmissionState) eventTransmit(ev EventTransmit) {
 	})
 	if err != nil {
 		t.logger.Error("Failed to serialize contract report", types.LogFields{"error": err})
+		t.transmissionFailed(ev.Epoch, ev.Round, "could not serialize report")
 		return
 	}
 
@@ -222,10 +256,19 @@ func (t *transmissionState) eventTransmit(ev EventTransmit) {
 		})
 	}
 	t.times.Push(MinHeapTimeToPendingTransmissionItem{key, transmission})
+	t.telemetrySender.TransmissionScheduled(t.config.ConfigDigest, ev.Epoch,
+		ev.Round, median, delay)
+	t.tracer.record(
+		ReportContext{t.config.ConfigDigest, ev.Epoch, ev.Round},
+		"transmitSchedule",
+		now,
+		transmission.Time,
+		types.LogFields{"delay": delay.String()},
+	)
 
 	next := t.times.Peek()
 	if (EpochRound{ev.Epoch, ev.Round}) == (EpochRound{next.Epoch, next.Round}) {
-		t.tTransmit = time.After(delay)
+		t.tTransmit.restart(t.clock, delay)
 	}
 }
 
@@ -234,7 +277,7 @@ func (t *transmissionState) eventTTransmitTimeout() {
 		if t.times.Len() != 0 { // If there's other transmissions due later...
 			// ...reset timer to expire when the next one is due
 			item := t.times.Peek()
-			t.tTransmit = time.After(time.Until(item.Time))
+			t.tTransmit.restart(t.clock, item.Time.Sub(t.clock.Now()))
 		}
 	}()
 
@@ -244,6 +287,9 @@ func (t *transmissionState) eventTTransmitTimeout() {
 	item := t.times.Pop()
 	itemEpochRound := EpochRound{item.Epoch, item.Round}
 
+	transmitSpan := t.tracer.start(ReportContext{item.ConfigDigest, item.Epoch, item.Round}, "transmit")
+	defer transmitSpan.end()
+
 	ok := t.subprocesses.BlockForAtMost(
 		t.ctx,
 		t.localConfig.DatabaseTimeout,
@@ -264,13 +310,15 @@ func (t *transmissionState) eventTTransmitTimeout() {
 		// carry on
 	}
 
-	contractConfigDigest, contractEpochRound, err := t.contractState()
+	contractConfigDigest, contractEpochRound, _, err := t.contractState()
 	if err != nil {
 		t.logger.Error("eventTTransmitTimeout: contractState() failed", types.LogFields{"error": err})
+		t.transmissionFailed(item.Epoch, item.Round, "could not read contract state")
 		return
 	}
 
 	if item.ConfigDigest != contractConfigDigest {
+		transmitSpan.setAttributes(types.LogFields{"skipped": "configDigest mismatch"})
 		t.logger.Info("eventTTransmitTimeout: configDigest doesn't match, discarding transmission", types.LogFields{
 			"contractConfigDigest": contractConfigDigest,
 			"configDigest":         item.ConfigDigest,
@@ -278,16 +326,19 @@ func (t *transmissionState) eventTTransmitTimeout() {
 			"epoch":                item.Epoch,
 			"round":                item.Round,
 		})
+		t.reportNotTransmitted(item.Epoch, item.Round, "configDigest mismatch")
 		return
 	}
 
 	if !contractEpochRound.Less(itemEpochRound) {
+		transmitSpan.setAttributes(types.LogFields{"skipped": "stale report"})
 		t.logger.Info("eventTTransmitTimeout: Skipping transmission because report is stale", types.LogFields{
 			"contractEpochRound": contractEpochRound,
 			"median":             item.Median,
 			"epoch":              item.Epoch,
 			"round":              item.Round,
 		})
+		t.reportNotTransmitted(item.Epoch, item.Round, "stale report")
 		return
 	}
 
@@ -305,15 +356,21 @@ func (t *transmissionState) eventTTransmitTimeout() {
 		},
 	)
 	if !ok {
+		transmitSpan.setAttributes(types.LogFields{"error": "timeout"})
 		t.logger.Error("eventTTransmitTimeout: Transmit timed out", types.LogFields{
 			"timeout": t.localConfig.ContractTransmitterTransmitTimeout,
 		})
+		t.transmissionFailed(item.Epoch, item.Round, "timeout")
 		return
 	}
 	if err != nil {
+		transmitSpan.setAttributes(types.LogFields{"error": err.Error()})
 		t.logger.Error("eventTTransmitTimeout: Error while transmitting report on-chain", types.LogFields{"error": err})
+		t.transmissionFailed(item.Epoch, item.Round, "transmit error")
 		return
 	}
+	transmitSpan.setAttributes(types.LogFields{"transmitted": true})
+	t.reportTransmitted(item.Epoch, item.Round, item.Median)
 
 	t.logger.Info("eventTTransmitTimeout:❗️successfully transmitted report on-chain", types.LogFields{
 		"median": item.Median,
@@ -322,7 +379,30 @@ func (t *transmissionState) eventTTransmitTimeout() {
 	})
 }
 
-func (t *transmissionState) shouldTransmit(ev EventTransmit, contractEpochRound EpochRound) bool {
+// reportTransmitted, reportNotTransmitted and transmissionFailed emit metrics
+// and telemetry for the outcome of a round's report, and record the outcome
+// for status reporting
+func (t *transmissionState) reportTransmitted(epoch uint32, round uint8, median types.Observation) {
+	t.metrics.reportsTransmitted.Inc()
+	if median, err := observation.MakeObservation(median); err == nil {
+		t.telemetrySender.TransmissionSent(t.config.ConfigDigest, epoch, round, median)
+	}
+	t.recordOutcome(epoch, round, types.TransmissionOutcomeTransmitted, "")
+}
+
+func (t *transmissionState) reportNotTransmitted(epoch uint32, round uint8, reason string) {
+	t.metrics.reportsSkipped.Inc(reason)
+	t.telemetrySender.TransmissionSkipped(t.config.ConfigDigest, epoch, round, false, reason)
+	t.recordOutcome(epoch, round, types.TransmissionOutcomeNotTransmitted, reason)
+}
+
+func (t *transmissionState) transmissionFailed(epoch uint32, round uint8, reason string) {
+	t.metrics.transmissionFailures.Inc(reason)
+	t.telemetrySender.TransmissionSkipped(t.config.ConfigDigest, epoch, round, true, reason)
+	t.recordOutcome(epoch, round, types.TransmissionOutcomeFailed, reason)
+}
+
+func (t *transmissionState) shouldTransmit(ev EventTransmit, contractEpochRound EpochRound, contractTimestamp time.Time) bool {
 	reportEpochRound := EpochRound{ev.Epoch, ev.Round}
 	if !contractEpochRound.Less(reportEpochRound) {
 		t.logger.Debug("shouldTransmit() = false, report is stale", types.LogFields{
@@ -356,15 +436,20 @@ func (t *transmissionState) shouldTransmit(ev EventTransmit, contractEpochRound
 		return false
 	}
 
-	deviates := t.latestMedian.Deviates(reportMedian, t.config.AlphaPPB)
+	deviates := reportMedian.DeviatesFromTransmitted(t.latestMedian, t.config.DeviationRule)
 	nothingPending := t.latestEpochRound.Less(contractEpochRound) || t.latestEpochRound == contractEpochRound
-	result := deviates || nothingPending
+	// Reports following a heartbeat boundary must be sent until one made after
+	// the boundary is on-chain. Report generation only forces the first round
+	// after a boundary, so this doesn't make later reports must-send.
+	heartbeatDue := t.config.Heartbeat.Due(contractTimestamp, t.clock.Now())
+	result := deviates || nothingPending || heartbeatDue
 
 	t.logger.Debug("shouldTransmit() = result", types.LogFields{
 		"contractEpochRound": contractEpochRound,
 		"epochRound":         reportEpochRound,
 		"latestEpochRound":   t.latestEpochRound,
 		"deviates":           deviates,
+		"heartbeatDue":       heartbeatDue,
 		"result":             result,
 	})
 
@@ -374,29 +459,31 @@ func (t *transmissionState) shouldTransmit(ev EventTransmit, contractEpochRound
 func (t *transmissionState) contractState() (
 	types.ConfigDigest,
 	EpochRound,
+	time.Time,
 	error,
 ) {
 	var configDigest types.ConfigDigest
 	var epoch uint32
 	var round uint8
+	var timestamp time.Time
 	var err error
 	ok := t.subprocesses.BlockForAtMost(
 		t.ctx,
 		t.localConfig.BlockchainTimeout,
 		func(ctx context.Context) {
-			configDigest, epoch, round, _, _, err = t.transmitter.LatestTransmissionDetails(ctx)
+			configDigest, epoch, round, _, timestamp, err = t.transmitter.LatestTransmissionDetails(ctx)
 		},
 	)
 
 	if !ok {
-		return types.ConfigDigest{}, EpochRound{}, fmt.Errorf("LatestTransmissionDetails timed out. Timeout: %v", t.localConfig.BlockchainTimeout)
+		return types.ConfigDigest{}, EpochRound{}, time.Time{}, fmt.Errorf("LatestTransmissionDetails timed out. Timeout: %v", t.localConfig.BlockchainTimeout)
 	}
 
 	if err != nil {
-		return types.ConfigDigest{}, EpochRound{}, errors.Wrap(err, "Error during LatestTransmissionDetails in Transmission")
+		return types.ConfigDigest{}, EpochRound{}, time.Time{}, errors.Wrap(err, "Error during LatestTransmissionDetails in Transmission")
 	}
 
-	return configDigest, EpochRound{epoch, round}, nil
+	return configDigest, EpochRound{epoch, round}, timestamp, nil
 }
 
 func (t *transmissionState) transmitDelay(epoch uint32, round uint8) *time.Duration {
@@ -0,0 +1,405 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.12.3
+// source: cl_offchainreporting_ceremony.proto
+
+package protobuf
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type CeremonyMessageWrapper struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Msg:
+	//	*CeremonyMessageWrapper_Commit
+	//	*CeremonyMessageWrapper_Reveal
+	//	*CeremonyMessageWrapper_Done
+	Msg isCeremonyMessageWrapper_Msg `protobuf_oneof:"msg"`
+}
+
+func (x *CeremonyMessageWrapper) Reset() {
+	*x = CeremonyMessageWrapper{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CeremonyMessageWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CeremonyMessageWrapper) ProtoMessage() {}
+
+func (x *CeremonyMessageWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CeremonyMessageWrapper.ProtoReflect.Descriptor instead.
+func (*CeremonyMessageWrapper) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_ceremony_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *CeremonyMessageWrapper) GetMsg() isCeremonyMessageWrapper_Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (x *CeremonyMessageWrapper) GetCommit() *CeremonyCommit {
+	if x, ok := x.GetMsg().(*CeremonyMessageWrapper_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (x *CeremonyMessageWrapper) GetReveal() *CeremonyReveal {
+	if x, ok := x.GetMsg().(*CeremonyMessageWrapper_Reveal); ok {
+		return x.Reveal
+	}
+	return nil
+}
+
+func (x *CeremonyMessageWrapper) GetDone() *CeremonyDone {
+	if x, ok := x.GetMsg().(*CeremonyMessageWrapper_Done); ok {
+		return x.Done
+	}
+	return nil
+}
+
+type isCeremonyMessageWrapper_Msg interface {
+	isCeremonyMessageWrapper_Msg()
+}
+
+type CeremonyMessageWrapper_Commit struct {
+	Commit *CeremonyCommit `protobuf:"bytes,1,opt,name=commit,proto3,oneof"`
+}
+
+type CeremonyMessageWrapper_Reveal struct {
+	Reveal *CeremonyReveal `protobuf:"bytes,2,opt,name=reveal,proto3,oneof"`
+}
+
+type CeremonyMessageWrapper_Done struct {
+	Done *CeremonyDone `protobuf:"bytes,3,opt,name=done,proto3,oneof"`
+}
+
+func (*CeremonyMessageWrapper_Commit) isCeremonyMessageWrapper_Msg() {}
+
+func (*CeremonyMessageWrapper_Reveal) isCeremonyMessageWrapper_Msg() {}
+
+func (*CeremonyMessageWrapper_Done) isCeremonyMessageWrapper_Msg() {}
+
+type CeremonyCommit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+}
+
+func (x *CeremonyCommit) Reset() {
+	*x = CeremonyCommit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CeremonyCommit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CeremonyCommit) ProtoMessage() {}
+
+func (x *CeremonyCommit) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CeremonyCommit.ProtoReflect.Descriptor instead.
+func (*CeremonyCommit) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_ceremony_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CeremonyCommit) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+type CeremonyReveal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Contribution []byte `protobuf:"bytes,1,opt,name=contribution,proto3" json:"contribution,omitempty"`
+}
+
+func (x *CeremonyReveal) Reset() {
+	*x = CeremonyReveal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CeremonyReveal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CeremonyReveal) ProtoMessage() {}
+
+func (x *CeremonyReveal) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CeremonyReveal.ProtoReflect.Descriptor instead.
+func (*CeremonyReveal) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_ceremony_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CeremonyReveal) GetContribution() []byte {
+	if x != nil {
+		return x.Contribution
+	}
+	return nil
+}
+
+type CeremonyDone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedSecretHash []byte `protobuf:"bytes,1,opt,name=sharedSecretHash,proto3" json:"sharedSecretHash,omitempty"`
+}
+
+func (x *CeremonyDone) Reset() {
+	*x = CeremonyDone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CeremonyDone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CeremonyDone) ProtoMessage() {}
+
+func (x *CeremonyDone) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_ceremony_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CeremonyDone.ProtoReflect.Descriptor instead.
+func (*CeremonyDone) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_ceremony_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CeremonyDone) GetSharedSecretHash() []byte {
+	if x != nil {
+		return x.SharedSecretHash
+	}
+	return nil
+}
+
+var File_cl_offchainreporting_ceremony_proto protoreflect.FileDescriptor
+
+var file_cl_offchainreporting_ceremony_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x63, 0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x22, 0xd0, 0x01, 0x0a, 0x16, 0x43, 0x65, 0x72,
+	0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x12, 0x3b, 0x0a, 0x06, 0x72, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x52, 0x65, 0x76,
+	0x65, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x12, 0x35, 0x0a,
+	0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x44, 0x6f, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x64, 0x6f, 0x6e, 0x65, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x30, 0x0a, 0x0e, 0x43,
+	0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x34, 0x0a,
+	0x0e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x3a, 0x0a, 0x0c, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x44,
+	0x6f, 0x6e, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x48, 0x61, 0x73, 0x68, 0x42,
+	0x0c, 0x5a, 0x0a, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_cl_offchainreporting_ceremony_proto_rawDescOnce sync.Once
+	file_cl_offchainreporting_ceremony_proto_rawDescData = file_cl_offchainreporting_ceremony_proto_rawDesc
+)
+
+func file_cl_offchainreporting_ceremony_proto_rawDescGZIP() []byte {
+	file_cl_offchainreporting_ceremony_proto_rawDescOnce.Do(func() {
+		file_cl_offchainreporting_ceremony_proto_rawDescData = protoimpl.X.CompressGZIP(file_cl_offchainreporting_ceremony_proto_rawDescData)
+	})
+	return file_cl_offchainreporting_ceremony_proto_rawDescData
+}
+
+var file_cl_offchainreporting_ceremony_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_cl_offchainreporting_ceremony_proto_goTypes = []interface{}{
+	(*CeremonyMessageWrapper)(nil), // 0: offchainreporting.CeremonyMessageWrapper
+	(*CeremonyCommit)(nil),         // 1: offchainreporting.CeremonyCommit
+	(*CeremonyReveal)(nil),         // 2: offchainreporting.CeremonyReveal
+	(*CeremonyDone)(nil),           // 3: offchainreporting.CeremonyDone
+}
+var file_cl_offchainreporting_ceremony_proto_depIdxs = []int32{
+	1, // 0: offchainreporting.CeremonyMessageWrapper.commit:type_name -> offchainreporting.CeremonyCommit
+	2, // 1: offchainreporting.CeremonyMessageWrapper.reveal:type_name -> offchainreporting.CeremonyReveal
+	3, // 2: offchainreporting.CeremonyMessageWrapper.done:type_name -> offchainreporting.CeremonyDone
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_cl_offchainreporting_ceremony_proto_init() }
+func file_cl_offchainreporting_ceremony_proto_init() {
+	if File_cl_offchainreporting_ceremony_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_cl_offchainreporting_ceremony_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CeremonyMessageWrapper); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_ceremony_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CeremonyCommit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_ceremony_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CeremonyReveal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_ceremony_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CeremonyDone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_cl_offchainreporting_ceremony_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*CeremonyMessageWrapper_Commit)(nil),
+		(*CeremonyMessageWrapper_Reveal)(nil),
+		(*CeremonyMessageWrapper_Done)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_cl_offchainreporting_ceremony_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cl_offchainreporting_ceremony_proto_goTypes,
+		DependencyIndexes: file_cl_offchainreporting_ceremony_proto_depIdxs,
+		MessageInfos:      file_cl_offchainreporting_ceremony_proto_msgTypes,
+	}.Build()
+	File_cl_offchainreporting_ceremony_proto = out.File
+	file_cl_offchainreporting_ceremony_proto_rawDesc = nil
+	file_cl_offchainreporting_ceremony_proto_goTypes = nil
+	file_cl_offchainreporting_ceremony_proto_depIdxs = nil
+}
@@ -749,6 +749,116 @@ func (x *MessageFinalEcho) GetFinal() *MessageFinal {
 	return nil
 }
 
+type MessageReportReqDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch  uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round  uint64 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Digest []byte `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *MessageReportReqDigest) Reset() {
+	*x = MessageReportReqDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_messages_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageReportReqDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportReqDigest) ProtoMessage() {}
+
+func (x *MessageReportReqDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_messages_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportReqDigest.ProtoReflect.Descriptor instead.
+func (*MessageReportReqDigest) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MessageReportReqDigest) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *MessageReportReqDigest) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MessageReportReqDigest) GetDigest() []byte {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+type MessageReportReqConflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportReq *MessageReportReq `protobuf:"bytes,1,opt,name=reportReq,proto3" json:"reportReq,omitempty"`
+}
+
+func (x *MessageReportReqConflict) Reset() {
+	*x = MessageReportReqConflict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_messages_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageReportReqConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportReqConflict) ProtoMessage() {}
+
+func (x *MessageReportReqConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_messages_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportReqConflict.ProtoReflect.Descriptor instead.
+func (*MessageReportReqConflict) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MessageReportReqConflict) GetReportReq() *MessageReportReq {
+	if x != nil {
+		return x.ReportReq
+	}
+	return nil
+}
+
 type MessageWrapper struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -762,13 +872,15 @@ type MessageWrapper struct {
 	//	*MessageWrapper_MessageReport
 	//	*MessageWrapper_MessageFinal
 	//	*MessageWrapper_MessageFinalEcho
+	//	*MessageWrapper_MessageReportReqDigest
+	//	*MessageWrapper_MessageReportReqConflict
 	Msg isMessageWrapper_Msg `protobuf_oneof:"msg"`
 }
 
 func (x *MessageWrapper) Reset() {
 	*x = MessageWrapper{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_messages_proto_msgTypes[13]
+		mi := &file_cl_offchainreporting_messages_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -781,7 +893,7 @@ func (x *MessageWrapper) String() string {
 func (*MessageWrapper) ProtoMessage() {}
 
 func (x *MessageWrapper) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_messages_proto_msgTypes[13]
+	mi := &file_cl_offchainreporting_messages_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,7 +906,7 @@ func (x *MessageWrapper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageWrapper.ProtoReflect.Descriptor instead.
 func (*MessageWrapper) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_messages_proto_rawDescGZIP(), []int{13}
+	return file_cl_offchainreporting_messages_proto_rawDescGZIP(), []int{15}
 }
 
 func (m *MessageWrapper) GetMsg() isMessageWrapper_Msg {
@@ -853,6 +965,20 @@ func (x *MessageWrapper) GetMessageFinalEcho() *MessageFinalEcho {
 	return nil
 }
 
+func (x *MessageWrapper) GetMessageReportReqDigest() *MessageReportReqDigest {
+	if x, ok := x.GetMsg().(*MessageWrapper_MessageReportReqDigest); ok {
+		return x.MessageReportReqDigest
+	}
+	return nil
+}
+
+func (x *MessageWrapper) GetMessageReportReqConflict() *MessageReportReqConflict {
+	if x, ok := x.GetMsg().(*MessageWrapper_MessageReportReqConflict); ok {
+		return x.MessageReportReqConflict
+	}
+	return nil
+}
+
 type isMessageWrapper_Msg interface {
 	isMessageWrapper_Msg()
 }
@@ -885,6 +1011,14 @@ type MessageWrapper_MessageFinalEcho struct {
 	MessageFinalEcho *MessageFinalEcho `protobuf:"bytes,8,opt,name=messageFinalEcho,proto3,oneof"`
 }
 
+type MessageWrapper_MessageReportReqDigest struct {
+	MessageReportReqDigest *MessageReportReqDigest `protobuf:"bytes,9,opt,name=messageReportReqDigest,proto3,oneof"`
+}
+
+type MessageWrapper_MessageReportReqConflict struct {
+	MessageReportReqConflict *MessageReportReqConflict `protobuf:"bytes,10,opt,name=messageReportReqConflict,proto3,oneof"`
+}
+
 func (*MessageWrapper_MessageNewEpoch) isMessageWrapper_Msg() {}
 
 func (*MessageWrapper_MessageObserveReq) isMessageWrapper_Msg() {}
@@ -899,6 +1033,10 @@ func (*MessageWrapper_MessageFinal) isMessageWrapper_Msg() {}
 
 func (*MessageWrapper_MessageFinalEcho) isMessageWrapper_Msg() {}
 
+func (*MessageWrapper_MessageReportReqDigest) isMessageWrapper_Msg() {}
+
+func (*MessageWrapper_MessageReportReqConflict) isMessageWrapper_Msg() {}
+
 var File_cl_offchainreporting_messages_proto protoreflect.FileDescriptor
 
 var file_cl_offchainreporting_messages_proto_rawDesc = []byte{
@@ -997,45 +1135,70 @@ var file_cl_offchainreporting_messages_proto_rawDesc = []byte{
 	0x45, 0x63, 0x68, 0x6f, 0x12, 0x35, 0x0a, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
 	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46,
-	0x69, 0x6e, 0x61, 0x6c, 0x52, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0xc7, 0x04, 0x0a, 0x0e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x12, 0x4e,
-	0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0f, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x54,
-	0x0a, 0x11, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x52, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x66, 0x66, 0x63,
+	0x69, 0x6e, 0x61, 0x6c, 0x52, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0x5c, 0x0a, 0x16, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x5d, 0x0a, 0x18, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x41, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x52, 0x09, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x22, 0x97, 0x06, 0x0a, 0x0e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x0f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x54, 0x0a, 0x11, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x11,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x12, 0x4b, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63,
 	0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x48,
-	0x00, 0x52, 0x11, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x52, 0x65, 0x71, 0x12, 0x4b, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f,
-	0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x48, 0x00, 0x52, 0x0e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x51,
+	0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52,
+	0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x48, 0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x45, 0x0a, 0x0c, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x12, 0x51, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f,
 	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
-	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x48,
-	0x00, 0x52, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x12, 0x51, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x52, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x66,
-	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
-	0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x52, 0x65, 0x71, 0x12, 0x48, 0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x66,
-	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x00, 0x52,
-	0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x45,
-	0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x51, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x23, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c,
-	0x45, 0x63, 0x68, 0x6f, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46,
-	0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x4a,
-	0x04, 0x08, 0x01, 0x10, 0x02, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68,
+	0x6f, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61,
+	0x6c, 0x45, 0x63, 0x68, 0x6f, 0x12, 0x63, 0x0a, 0x16, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x16, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x71, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x69, 0x0a, 0x18, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x43, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f,
+	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x48, 0x00, 0x52, 0x18, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x02, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1050,7 +1213,7 @@ func file_cl_offchainreporting_messages_proto_rawDescGZIP() []byte {
 	return file_cl_offchainreporting_messages_proto_rawDescData
 }
 
-var file_cl_offchainreporting_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_cl_offchainreporting_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_cl_offchainreporting_messages_proto_goTypes = []interface{}{
 	(*MessageNewEpoch)(nil),             // 0: offchainreporting.MessageNewEpoch
 	(*MessageObserveReq)(nil),           // 1: offchainreporting.MessageObserveReq
@@ -1065,7 +1228,9 @@ var file_cl_offchainreporting_messages_proto_goTypes = []interface{}{
 	(*AttestedReportMany)(nil),          // 10: offchainreporting.AttestedReportMany
 	(*MessageFinal)(nil),                // 11: offchainreporting.MessageFinal
 	(*MessageFinalEcho)(nil),            // 12: offchainreporting.MessageFinalEcho
-	(*MessageWrapper)(nil),              // 13: offchainreporting.MessageWrapper
+	(*MessageReportReqDigest)(nil),      // 13: offchainreporting.MessageReportReqDigest
+	(*MessageReportReqConflict)(nil),    // 14: offchainreporting.MessageReportReqConflict
+	(*MessageWrapper)(nil),              // 15: offchainreporting.MessageWrapper
 }
 var file_cl_offchainreporting_messages_proto_depIdxs = []int32{
 	2,  // 0: offchainreporting.SignedObservation.observation:type_name -> offchainreporting.Observation
@@ -1078,18 +1243,21 @@ var file_cl_offchainreporting_messages_proto_depIdxs = []int32{
 	7,  // 7: offchainreporting.AttestedReportMany.attributedObservations:type_name -> offchainreporting.AttributedObservation
 	10, // 8: offchainreporting.MessageFinal.report:type_name -> offchainreporting.AttestedReportMany
 	11, // 9: offchainreporting.MessageFinalEcho.final:type_name -> offchainreporting.MessageFinal
-	0,  // 10: offchainreporting.MessageWrapper.messageNewEpoch:type_name -> offchainreporting.MessageNewEpoch
-	1,  // 11: offchainreporting.MessageWrapper.messageObserveReq:type_name -> offchainreporting.MessageObserveReq
-	5,  // 12: offchainreporting.MessageWrapper.messageObserve:type_name -> offchainreporting.MessageObserve
-	6,  // 13: offchainreporting.MessageWrapper.messageReportReq:type_name -> offchainreporting.MessageReportReq
-	9,  // 14: offchainreporting.MessageWrapper.messageReport:type_name -> offchainreporting.MessageReport
-	11, // 15: offchainreporting.MessageWrapper.messageFinal:type_name -> offchainreporting.MessageFinal
-	12, // 16: offchainreporting.MessageWrapper.messageFinalEcho:type_name -> offchainreporting.MessageFinalEcho
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	6,  // 10: offchainreporting.MessageReportReqConflict.reportReq:type_name -> offchainreporting.MessageReportReq
+	0,  // 11: offchainreporting.MessageWrapper.messageNewEpoch:type_name -> offchainreporting.MessageNewEpoch
+	1,  // 12: offchainreporting.MessageWrapper.messageObserveReq:type_name -> offchainreporting.MessageObserveReq
+	5,  // 13: offchainreporting.MessageWrapper.messageObserve:type_name -> offchainreporting.MessageObserve
+	6,  // 14: offchainreporting.MessageWrapper.messageReportReq:type_name -> offchainreporting.MessageReportReq
+	9,  // 15: offchainreporting.MessageWrapper.messageReport:type_name -> offchainreporting.MessageReport
+	11, // 16: offchainreporting.MessageWrapper.messageFinal:type_name -> offchainreporting.MessageFinal
+	12, // 17: offchainreporting.MessageWrapper.messageFinalEcho:type_name -> offchainreporting.MessageFinalEcho
+	13, // 18: offchainreporting.MessageWrapper.messageReportReqDigest:type_name -> offchainreporting.MessageReportReqDigest
+	14, // 19: offchainreporting.MessageWrapper.messageReportReqConflict:type_name -> offchainreporting.MessageReportReqConflict
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_cl_offchainreporting_messages_proto_init() }
@@ -1255,6 +1423,30 @@ func file_cl_offchainreporting_messages_proto_init() {
 			}
 		}
 		file_cl_offchainreporting_messages_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageReportReqDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_messages_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageReportReqConflict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_messages_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MessageWrapper); i {
 			case 0:
 				return &v.state
@@ -1267,7 +1459,7 @@ func file_cl_offchainreporting_messages_proto_init() {
 			}
 		}
 	}
-	file_cl_offchainreporting_messages_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_cl_offchainreporting_messages_proto_msgTypes[15].OneofWrappers = []interface{}{
 		(*MessageWrapper_MessageNewEpoch)(nil),
 		(*MessageWrapper_MessageObserveReq)(nil),
 		(*MessageWrapper_MessageObserve)(nil),
@@ -1275,6 +1467,8 @@ func file_cl_offchainreporting_messages_proto_init() {
 		(*MessageWrapper_MessageReport)(nil),
 		(*MessageWrapper_MessageFinal)(nil),
 		(*MessageWrapper_MessageFinalEcho)(nil),
+		(*MessageWrapper_MessageReportReqDigest)(nil),
+		(*MessageWrapper_MessageReportReqConflict)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1282,7 +1476,7 @@ func file_cl_offchainreporting_messages_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cl_offchainreporting_messages_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -36,6 +36,15 @@ type TelemetryWrapper struct {
 	//	*TelemetryWrapper_MessageSent
 	//	*TelemetryWrapper_AssertionViolation
 	//	*TelemetryWrapper_RoundStarted
+	//	*TelemetryWrapper_ObservationMade
+	//	*TelemetryWrapper_ReportGenerated
+	//	*TelemetryWrapper_ShouldReportDecision
+	//	*TelemetryWrapper_TransmissionScheduled
+	//	*TelemetryWrapper_TransmissionSent
+	//	*TelemetryWrapper_TransmissionSkipped
+	//	*TelemetryWrapper_EpochChanged
+	//	*TelemetryWrapper_Dropped
+	//	*TelemetryWrapper_MisbehaviorDetected
 	Wrapped isTelemetryWrapper_Wrapped `protobuf_oneof:"wrapped"`
 }
 
@@ -113,6 +122,69 @@ func (x *TelemetryWrapper) GetRoundStarted() *TelemetryRoundStarted {
 	return nil
 }
 
+func (x *TelemetryWrapper) GetObservationMade() *TelemetryObservationMade {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_ObservationMade); ok {
+		return x.ObservationMade
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetReportGenerated() *TelemetryReportGenerated {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_ReportGenerated); ok {
+		return x.ReportGenerated
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetShouldReportDecision() *TelemetryShouldReportDecision {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_ShouldReportDecision); ok {
+		return x.ShouldReportDecision
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetTransmissionScheduled() *TelemetryTransmissionScheduled {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_TransmissionScheduled); ok {
+		return x.TransmissionScheduled
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetTransmissionSent() *TelemetryTransmissionSent {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_TransmissionSent); ok {
+		return x.TransmissionSent
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetTransmissionSkipped() *TelemetryTransmissionSkipped {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_TransmissionSkipped); ok {
+		return x.TransmissionSkipped
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetEpochChanged() *TelemetryEpochChanged {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_EpochChanged); ok {
+		return x.EpochChanged
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetDropped() *TelemetryDropped {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_Dropped); ok {
+		return x.Dropped
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetMisbehaviorDetected() *TelemetryMisbehaviorDetected {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_MisbehaviorDetected); ok {
+		return x.MisbehaviorDetected
+	}
+	return nil
+}
+
 type isTelemetryWrapper_Wrapped interface {
 	isTelemetryWrapper_Wrapped()
 }
@@ -137,6 +209,42 @@ type TelemetryWrapper_RoundStarted struct {
 	RoundStarted *TelemetryRoundStarted `protobuf:"bytes,5,opt,name=roundStarted,proto3,oneof"`
 }
 
+type TelemetryWrapper_ObservationMade struct {
+	ObservationMade *TelemetryObservationMade `protobuf:"bytes,6,opt,name=observationMade,proto3,oneof"`
+}
+
+type TelemetryWrapper_ReportGenerated struct {
+	ReportGenerated *TelemetryReportGenerated `protobuf:"bytes,7,opt,name=reportGenerated,proto3,oneof"`
+}
+
+type TelemetryWrapper_ShouldReportDecision struct {
+	ShouldReportDecision *TelemetryShouldReportDecision `protobuf:"bytes,8,opt,name=shouldReportDecision,proto3,oneof"`
+}
+
+type TelemetryWrapper_TransmissionScheduled struct {
+	TransmissionScheduled *TelemetryTransmissionScheduled `protobuf:"bytes,9,opt,name=transmissionScheduled,proto3,oneof"`
+}
+
+type TelemetryWrapper_TransmissionSent struct {
+	TransmissionSent *TelemetryTransmissionSent `protobuf:"bytes,10,opt,name=transmissionSent,proto3,oneof"`
+}
+
+type TelemetryWrapper_TransmissionSkipped struct {
+	TransmissionSkipped *TelemetryTransmissionSkipped `protobuf:"bytes,11,opt,name=transmissionSkipped,proto3,oneof"`
+}
+
+type TelemetryWrapper_EpochChanged struct {
+	EpochChanged *TelemetryEpochChanged `protobuf:"bytes,12,opt,name=epochChanged,proto3,oneof"`
+}
+
+type TelemetryWrapper_Dropped struct {
+	Dropped *TelemetryDropped `protobuf:"bytes,13,opt,name=dropped,proto3,oneof"`
+}
+
+type TelemetryWrapper_MisbehaviorDetected struct {
+	MisbehaviorDetected *TelemetryMisbehaviorDetected `protobuf:"bytes,14,opt,name=misbehaviorDetected,proto3,oneof"`
+}
+
 func (*TelemetryWrapper_MessageReceived) isTelemetryWrapper_Wrapped() {}
 
 func (*TelemetryWrapper_MessageBroadcast) isTelemetryWrapper_Wrapped() {}
@@ -147,6 +255,79 @@ func (*TelemetryWrapper_AssertionViolation) isTelemetryWrapper_Wrapped() {}
 
 func (*TelemetryWrapper_RoundStarted) isTelemetryWrapper_Wrapped() {}
 
+func (*TelemetryWrapper_ObservationMade) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_ReportGenerated) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_ShouldReportDecision) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_TransmissionScheduled) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_TransmissionSent) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_TransmissionSkipped) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_EpochChanged) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_Dropped) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_MisbehaviorDetected) isTelemetryWrapper_Wrapped() {}
+
+type TelemetryBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Telemetry []*TelemetryWrapper `protobuf:"bytes,1,rep,name=telemetry,proto3" json:"telemetry,omitempty"`
+	Dropped   uint64              `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *TelemetryBatch) Reset() {
+	*x = TelemetryBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryBatch) ProtoMessage() {}
+
+func (x *TelemetryBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryBatch.ProtoReflect.Descriptor instead.
+func (*TelemetryBatch) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TelemetryBatch) GetTelemetry() []*TelemetryWrapper {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+func (x *TelemetryBatch) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
 type TelemetryMessageReceived struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -160,7 +341,7 @@ type TelemetryMessageReceived struct {
 func (x *TelemetryMessageReceived) Reset() {
 	*x = TelemetryMessageReceived{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[1]
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -173,7 +354,7 @@ func (x *TelemetryMessageReceived) String() string {
 func (*TelemetryMessageReceived) ProtoMessage() {}
 
 func (x *TelemetryMessageReceived) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[1]
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -186,7 +367,7 @@ func (x *TelemetryMessageReceived) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TelemetryMessageReceived.ProtoReflect.Descriptor instead.
 func (*TelemetryMessageReceived) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{1}
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *TelemetryMessageReceived) GetConfigDigest() []byte {
@@ -223,7 +404,7 @@ type TelemetryMessageBroadcast struct {
 func (x *TelemetryMessageBroadcast) Reset() {
 	*x = TelemetryMessageBroadcast{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[2]
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -236,7 +417,7 @@ func (x *TelemetryMessageBroadcast) String() string {
 func (*TelemetryMessageBroadcast) ProtoMessage() {}
 
 func (x *TelemetryMessageBroadcast) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[2]
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +430,7 @@ func (x *TelemetryMessageBroadcast) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TelemetryMessageBroadcast.ProtoReflect.Descriptor instead.
 func (*TelemetryMessageBroadcast) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{2}
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TelemetryMessageBroadcast) GetConfigDigest() []byte {
@@ -287,7 +468,7 @@ type TelemetryMessageSent struct {
 func (x *TelemetryMessageSent) Reset() {
 	*x = TelemetryMessageSent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[3]
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -300,7 +481,7 @@ func (x *TelemetryMessageSent) String() string {
 func (*TelemetryMessageSent) ProtoMessage() {}
 
 func (x *TelemetryMessageSent) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[3]
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,7 +494,7 @@ func (x *TelemetryMessageSent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TelemetryMessageSent.ProtoReflect.Descriptor instead.
 func (*TelemetryMessageSent) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{3}
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TelemetryMessageSent) GetConfigDigest() []byte {
@@ -358,7 +539,7 @@ type TelemetryAssertionViolation struct {
 func (x *TelemetryAssertionViolation) Reset() {
 	*x = TelemetryAssertionViolation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[4]
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -371,7 +552,7 @@ func (x *TelemetryAssertionViolation) String() string {
 func (*TelemetryAssertionViolation) ProtoMessage() {}
 
 func (x *TelemetryAssertionViolation) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[4]
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +565,7 @@ func (x *TelemetryAssertionViolation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TelemetryAssertionViolation.ProtoReflect.Descriptor instead.
 func (*TelemetryAssertionViolation) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{4}
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{5}
 }
 
 func (m *TelemetryAssertionViolation) GetViolation() isTelemetryAssertionViolation_Violation {
@@ -437,7 +618,7 @@ type TelemetryAssertionViolationInvalidSignature struct {
 func (x *TelemetryAssertionViolationInvalidSignature) Reset() {
 	*x = TelemetryAssertionViolationInvalidSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[5]
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -450,7 +631,7 @@ func (x *TelemetryAssertionViolationInvalidSignature) String() string {
 func (*TelemetryAssertionViolationInvalidSignature) ProtoMessage() {}
 
 func (x *TelemetryAssertionViolationInvalidSignature) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[5]
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,7 +644,7 @@ func (x *TelemetryAssertionViolationInvalidSignature) ProtoReflect() protoreflec
 
 // Deprecated: Use TelemetryAssertionViolationInvalidSignature.ProtoReflect.Descriptor instead.
 func (*TelemetryAssertionViolationInvalidSignature) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{5}
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TelemetryAssertionViolationInvalidSignature) GetConfigDigest() []byte {
@@ -500,7 +681,7 @@ type TelemetryAssertionViolationInvalidSerialization struct {
 func (x *TelemetryAssertionViolationInvalidSerialization) Reset() {
 	*x = TelemetryAssertionViolationInvalidSerialization{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[6]
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -513,7 +694,7 @@ func (x *TelemetryAssertionViolationInvalidSerialization) String() string {
 func (*TelemetryAssertionViolationInvalidSerialization) ProtoMessage() {}
 
 func (x *TelemetryAssertionViolationInvalidSerialization) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[6]
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,7 +707,7 @@ func (x *TelemetryAssertionViolationInvalidSerialization) ProtoReflect() protore
 
 // Deprecated: Use TelemetryAssertionViolationInvalidSerialization.ProtoReflect.Descriptor instead.
 func (*TelemetryAssertionViolationInvalidSerialization) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{6}
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TelemetryAssertionViolationInvalidSerialization) GetConfigDigest() []byte {
@@ -565,7 +746,7 @@ type TelemetryRoundStarted struct {
 func (x *TelemetryRoundStarted) Reset() {
 	*x = TelemetryRoundStarted{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[7]
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -578,7 +759,7 @@ func (x *TelemetryRoundStarted) String() string {
 func (*TelemetryRoundStarted) ProtoMessage() {}
 
 func (x *TelemetryRoundStarted) ProtoReflect() protoreflect.Message {
-	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[7]
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +772,7 @@ func (x *TelemetryRoundStarted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TelemetryRoundStarted.ProtoReflect.Descriptor instead.
 func (*TelemetryRoundStarted) Descriptor() ([]byte, []int) {
-	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{7}
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TelemetryRoundStarted) GetConfigDigest() []byte {
@@ -629,201 +810,1267 @@ func (x *TelemetryRoundStarted) GetTime() uint64 {
 	return 0
 }
 
-var File_cl_offchainreporting_telemetry_proto protoreflect.FileDescriptor
+type TelemetryObservationMade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_cl_offchainreporting_telemetry_proto_rawDesc = []byte{
-	0x0a, 0x24, 0x63, 0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x23, 0x63, 0x6c, 0x5f, 0x6f, 0x66,
-	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd1,
-	0x03, 0x0a, 0x10, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x57, 0x72, 0x61, 0x70,
-	0x70, 0x65, 0x72, 0x12, 0x57, 0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f,
-	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
-	0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x5a, 0x0a, 0x10,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
-	0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64,
-	0x63, 0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42,
-	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
-	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
-	0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x60, 0x0a, 0x12, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69,
-	0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x2e, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41,
-	0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x12, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69,
-	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e,
-	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
-	0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70,
-	0x65, 0x64, 0x22, 0x8b, 0x01, 0x0a, 0x18, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12,
-	0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70,
-	0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64,
-	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72,
-	0x22, 0x9a, 0x01, 0x0a, 0x19, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x22,
-	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70,
-	0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
-	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x22, 0xb1, 0x01,
-	0x0a, 0x14, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12,
-	0x24, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a,
-	0x65, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x72, 0x22, 0x92, 0x02, 0x0a, 0x1b, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41,
-	0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x6c, 0x0a, 0x10, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6f, 0x66,
-	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e,
-	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69,
-	0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x10, 0x69,
-	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
-	0x78, 0x0a, 0x14, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x42, 0x2e,
-	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
-	0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72,
-	0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69,
-	0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0b, 0x0a, 0x09, 0x76, 0x69, 0x6f,
-	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9e, 0x01, 0x0a, 0x2b, 0x54, 0x65, 0x6c, 0x65, 0x6d,
-	0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f,
-	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x93, 0x01, 0x0a, 0x2f, 0x54, 0x65, 0x6c, 0x65,
-	0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69,
-	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65,
-	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12,
-	0x24, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a,
-	0x65, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x93, 0x01,
-	0x0a, 0x15, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74,
-	0x69, 0x6d, 0x65, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	ConfigDigest []byte       `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch        uint64       `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round        uint64       `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Value        *Observation `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Latency      uint64       `protobuf:"varint,5,opt,name=latency,proto3" json:"latency,omitempty"`
+	Error        string       `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Time         uint64       `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
 }
 
-var (
-	file_cl_offchainreporting_telemetry_proto_rawDescOnce sync.Once
-	file_cl_offchainreporting_telemetry_proto_rawDescData = file_cl_offchainreporting_telemetry_proto_rawDesc
-)
+func (x *TelemetryObservationMade) Reset() {
+	*x = TelemetryObservationMade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_cl_offchainreporting_telemetry_proto_rawDescGZIP() []byte {
-	file_cl_offchainreporting_telemetry_proto_rawDescOnce.Do(func() {
-		file_cl_offchainreporting_telemetry_proto_rawDescData = protoimpl.X.CompressGZIP(file_cl_offchainreporting_telemetry_proto_rawDescData)
-	})
-	return file_cl_offchainreporting_telemetry_proto_rawDescData
+func (x *TelemetryObservationMade) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_cl_offchainreporting_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_cl_offchainreporting_telemetry_proto_goTypes = []interface{}{
-	(*TelemetryWrapper)(nil),                                // 0: offchainreporting.TelemetryWrapper
-	(*TelemetryMessageReceived)(nil),                        // 1: offchainreporting.TelemetryMessageReceived
-	(*TelemetryMessageBroadcast)(nil),                       // 2: offchainreporting.TelemetryMessageBroadcast
-	(*TelemetryMessageSent)(nil),                            // 3: offchainreporting.TelemetryMessageSent
-	(*TelemetryAssertionViolation)(nil),                     // 4: offchainreporting.TelemetryAssertionViolation
-	(*TelemetryAssertionViolationInvalidSignature)(nil),     // 5: offchainreporting.TelemetryAssertionViolationInvalidSignature
-	(*TelemetryAssertionViolationInvalidSerialization)(nil), // 6: offchainreporting.TelemetryAssertionViolationInvalidSerialization
-	(*TelemetryRoundStarted)(nil),                           // 7: offchainreporting.TelemetryRoundStarted
-	(*MessageWrapper)(nil),                                  // 8: offchainreporting.MessageWrapper
+func (*TelemetryObservationMade) ProtoMessage() {}
+
+func (x *TelemetryObservationMade) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_cl_offchainreporting_telemetry_proto_depIdxs = []int32{
-	1,  // 0: offchainreporting.TelemetryWrapper.messageReceived:type_name -> offchainreporting.TelemetryMessageReceived
-	2,  // 1: offchainreporting.TelemetryWrapper.messageBroadcast:type_name -> offchainreporting.TelemetryMessageBroadcast
-	3,  // 2: offchainreporting.TelemetryWrapper.messageSent:type_name -> offchainreporting.TelemetryMessageSent
-	4,  // 3: offchainreporting.TelemetryWrapper.assertionViolation:type_name -> offchainreporting.TelemetryAssertionViolation
-	7,  // 4: offchainreporting.TelemetryWrapper.roundStarted:type_name -> offchainreporting.TelemetryRoundStarted
-	8,  // 5: offchainreporting.TelemetryMessageReceived.msg:type_name -> offchainreporting.MessageWrapper
-	8,  // 6: offchainreporting.TelemetryMessageBroadcast.msg:type_name -> offchainreporting.MessageWrapper
-	8,  // 7: offchainreporting.TelemetryMessageSent.msg:type_name -> offchainreporting.MessageWrapper
-	5,  // 8: offchainreporting.TelemetryAssertionViolation.invalidSignature:type_name -> offchainreporting.TelemetryAssertionViolationInvalidSignature
-	6,  // 9: offchainreporting.TelemetryAssertionViolation.invalidSerialization:type_name -> offchainreporting.TelemetryAssertionViolationInvalidSerialization
-	8,  // 10: offchainreporting.TelemetryAssertionViolationInvalidSignature.msg:type_name -> offchainreporting.MessageWrapper
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+
+// Deprecated: Use TelemetryObservationMade.ProtoReflect.Descriptor instead.
+func (*TelemetryObservationMade) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{9}
 }
 
-func init() { file_cl_offchainreporting_telemetry_proto_init() }
-func file_cl_offchainreporting_telemetry_proto_init() {
-	if File_cl_offchainreporting_telemetry_proto != nil {
-		return
+func (x *TelemetryObservationMade) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
 	}
-	file_cl_offchainreporting_messages_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_cl_offchainreporting_telemetry_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryWrapper); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_cl_offchainreporting_telemetry_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryMessageReceived); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_cl_offchainreporting_telemetry_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryMessageBroadcast); i {
-			case 0:
+	return nil
+}
+
+func (x *TelemetryObservationMade) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryObservationMade) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryObservationMade) GetValue() *Observation {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TelemetryObservationMade) GetLatency() uint64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *TelemetryObservationMade) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TelemetryObservationMade) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TelemetryReportGenerated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte       `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch        uint64       `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round        uint64       `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Median       *Observation `protobuf:"bytes,4,opt,name=median,proto3" json:"median,omitempty"`
+	Observers    []uint32     `protobuf:"varint,5,rep,packed,name=observers,proto3" json:"observers,omitempty"`
+	Time         uint64       `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *TelemetryReportGenerated) Reset() {
+	*x = TelemetryReportGenerated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryReportGenerated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryReportGenerated) ProtoMessage() {}
+
+func (x *TelemetryReportGenerated) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryReportGenerated.ProtoReflect.Descriptor instead.
+func (*TelemetryReportGenerated) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TelemetryReportGenerated) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryReportGenerated) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryReportGenerated) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryReportGenerated) GetMedian() *Observation {
+	if x != nil {
+		return x.Median
+	}
+	return nil
+}
+
+func (x *TelemetryReportGenerated) GetObservers() []uint32 {
+	if x != nil {
+		return x.Observers
+	}
+	return nil
+}
+
+func (x *TelemetryReportGenerated) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TelemetryShouldReportDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest  []byte `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch         uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round         uint64 `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Result        bool   `protobuf:"varint,4,opt,name=result,proto3" json:"result,omitempty"`
+	Deviation     bool   `protobuf:"varint,5,opt,name=deviation,proto3" json:"deviation,omitempty"`
+	DeltaCTimeout bool   `protobuf:"varint,6,opt,name=deltaCTimeout,proto3" json:"deltaCTimeout,omitempty"`
+	InitialRound  bool   `protobuf:"varint,7,opt,name=initialRound,proto3" json:"initialRound,omitempty"`
+	HeartbeatDue  bool   `protobuf:"varint,8,opt,name=heartbeatDue,proto3" json:"heartbeatDue,omitempty"`
+	Time          uint64 `protobuf:"varint,9,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *TelemetryShouldReportDecision) Reset() {
+	*x = TelemetryShouldReportDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryShouldReportDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryShouldReportDecision) ProtoMessage() {}
+
+func (x *TelemetryShouldReportDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryShouldReportDecision.ProtoReflect.Descriptor instead.
+func (*TelemetryShouldReportDecision) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TelemetryShouldReportDecision) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryShouldReportDecision) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryShouldReportDecision) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryShouldReportDecision) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+func (x *TelemetryShouldReportDecision) GetDeviation() bool {
+	if x != nil {
+		return x.Deviation
+	}
+	return false
+}
+
+func (x *TelemetryShouldReportDecision) GetDeltaCTimeout() bool {
+	if x != nil {
+		return x.DeltaCTimeout
+	}
+	return false
+}
+
+func (x *TelemetryShouldReportDecision) GetInitialRound() bool {
+	if x != nil {
+		return x.InitialRound
+	}
+	return false
+}
+
+func (x *TelemetryShouldReportDecision) GetHeartbeatDue() bool {
+	if x != nil {
+		return x.HeartbeatDue
+	}
+	return false
+}
+
+func (x *TelemetryShouldReportDecision) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TelemetryTransmissionScheduled struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte       `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch        uint64       `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round        uint64       `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Median       *Observation `protobuf:"bytes,4,opt,name=median,proto3" json:"median,omitempty"`
+	Delay        uint64       `protobuf:"varint,5,opt,name=delay,proto3" json:"delay,omitempty"`
+	Time         uint64       `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *TelemetryTransmissionScheduled) Reset() {
+	*x = TelemetryTransmissionScheduled{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryTransmissionScheduled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryTransmissionScheduled) ProtoMessage() {}
+
+func (x *TelemetryTransmissionScheduled) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryTransmissionScheduled.ProtoReflect.Descriptor instead.
+func (*TelemetryTransmissionScheduled) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TelemetryTransmissionScheduled) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryTransmissionScheduled) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionScheduled) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionScheduled) GetMedian() *Observation {
+	if x != nil {
+		return x.Median
+	}
+	return nil
+}
+
+func (x *TelemetryTransmissionScheduled) GetDelay() uint64 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionScheduled) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TelemetryTransmissionSent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte       `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch        uint64       `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round        uint64       `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Median       *Observation `protobuf:"bytes,4,opt,name=median,proto3" json:"median,omitempty"`
+	Time         uint64       `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *TelemetryTransmissionSent) Reset() {
+	*x = TelemetryTransmissionSent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryTransmissionSent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryTransmissionSent) ProtoMessage() {}
+
+func (x *TelemetryTransmissionSent) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryTransmissionSent.ProtoReflect.Descriptor instead.
+func (*TelemetryTransmissionSent) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TelemetryTransmissionSent) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryTransmissionSent) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionSent) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionSent) GetMedian() *Observation {
+	if x != nil {
+		return x.Median
+	}
+	return nil
+}
+
+func (x *TelemetryTransmissionSent) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TelemetryTransmissionSkipped struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch        uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round        uint64 `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Failed       bool   `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Reason       string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Time         uint64 `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *TelemetryTransmissionSkipped) Reset() {
+	*x = TelemetryTransmissionSkipped{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryTransmissionSkipped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryTransmissionSkipped) ProtoMessage() {}
+
+func (x *TelemetryTransmissionSkipped) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryTransmissionSkipped.ProtoReflect.Descriptor instead.
+func (*TelemetryTransmissionSkipped) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TelemetryTransmissionSkipped) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryTransmissionSkipped) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionSkipped) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionSkipped) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+func (x *TelemetryTransmissionSkipped) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TelemetryTransmissionSkipped) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TelemetryEpochChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch        uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Leader       uint64 `protobuf:"varint,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	Time         uint64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *TelemetryEpochChanged) Reset() {
+	*x = TelemetryEpochChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryEpochChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryEpochChanged) ProtoMessage() {}
+
+func (x *TelemetryEpochChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryEpochChanged.ProtoReflect.Descriptor instead.
+func (*TelemetryEpochChanged) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TelemetryEpochChanged) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryEpochChanged) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryEpochChanged) GetLeader() uint64 {
+	if x != nil {
+		return x.Leader
+	}
+	return 0
+}
+
+func (x *TelemetryEpochChanged) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TelemetryDropped struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *TelemetryDropped) Reset() {
+	*x = TelemetryDropped{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryDropped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryDropped) ProtoMessage() {}
+
+func (x *TelemetryDropped) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryDropped.ProtoReflect.Descriptor instead.
+func (*TelemetryDropped) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TelemetryDropped) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TelemetryMisbehaviorDetected struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest       []byte          `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	Epoch              uint64          `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round              uint64          `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Sender             uint64          `protobuf:"varint,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	Check              string          `protobuf:"bytes,5,opt,name=check,proto3" json:"check,omitempty"`
+	Reason             string          `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message            *MessageWrapper `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	Time               uint64          `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"`
+	ConflictingMessage *MessageWrapper `protobuf:"bytes,9,opt,name=conflictingMessage,proto3" json:"conflictingMessage,omitempty"`
+}
+
+func (x *TelemetryMisbehaviorDetected) Reset() {
+	*x = TelemetryMisbehaviorDetected{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryMisbehaviorDetected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryMisbehaviorDetected) ProtoMessage() {}
+
+func (x *TelemetryMisbehaviorDetected) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryMisbehaviorDetected.ProtoReflect.Descriptor instead.
+func (*TelemetryMisbehaviorDetected) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TelemetryMisbehaviorDetected) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryMisbehaviorDetected) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryMisbehaviorDetected) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryMisbehaviorDetected) GetSender() uint64 {
+	if x != nil {
+		return x.Sender
+	}
+	return 0
+}
+
+func (x *TelemetryMisbehaviorDetected) GetCheck() string {
+	if x != nil {
+		return x.Check
+	}
+	return ""
+}
+
+func (x *TelemetryMisbehaviorDetected) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TelemetryMisbehaviorDetected) GetMessage() *MessageWrapper {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *TelemetryMisbehaviorDetected) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *TelemetryMisbehaviorDetected) GetConflictingMessage() *MessageWrapper {
+	if x != nil {
+		return x.ConflictingMessage
+	}
+	return nil
+}
+
+type TelemetryEnvelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	OracleID     uint32 `protobuf:"varint,2,opt,name=oracleID,proto3" json:"oracleID,omitempty"`
+	Batch        bool   `protobuf:"varint,3,opt,name=batch,proto3" json:"batch,omitempty"`
+	Payload      []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature    []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *TelemetryEnvelope) Reset() {
+	*x = TelemetryEnvelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryEnvelope) ProtoMessage() {}
+
+func (x *TelemetryEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_telemetry_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryEnvelope.ProtoReflect.Descriptor instead.
+func (*TelemetryEnvelope) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_telemetry_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TelemetryEnvelope) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryEnvelope) GetOracleID() uint32 {
+	if x != nil {
+		return x.OracleID
+	}
+	return 0
+}
+
+func (x *TelemetryEnvelope) GetBatch() bool {
+	if x != nil {
+		return x.Batch
+	}
+	return false
+}
+
+func (x *TelemetryEnvelope) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *TelemetryEnvelope) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+var File_cl_offchainreporting_telemetry_proto protoreflect.FileDescriptor
+
+var file_cl_offchainreporting_telemetry_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x63, 0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x23, 0x63, 0x6c, 0x5f, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8d,
+	0x0a, 0x0a, 0x10, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x57, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x12, 0x57, 0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f,
+	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x5a, 0x0a, 0x10,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x60, 0x0a, 0x12, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69,
+	0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2e, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41,
+	0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x12, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x57, 0x0a, 0x0f, 0x6f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2b, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x64, 0x65, 0x48, 0x00, 0x52,
+	0x0f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x64, 0x65,
+	0x12, 0x57, 0x0a, 0x0f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f, 0x66, 0x66, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65,
+	0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x66, 0x0a, 0x14, 0x73, 0x68, 0x6f,
+	0x75, 0x6c, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x53, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x14, 0x73, 0x68, 0x6f,
+	0x75, 0x6c, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x69, 0x0a, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x31, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x48, 0x00, 0x52, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x12, 0x5a, 0x0a, 0x10,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6e, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x63, 0x0a, 0x13, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x48, 0x00, 0x52, 0x13, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x4e, 0x0a,
+	0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52,
+	0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x3f, 0x0a,
+	0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x44, 0x72, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x48, 0x00, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x63,
+	0x0a, 0x13, 0x6d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x44, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61,
+	0x76, 0x69, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x13,
+	0x6d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x22, 0x6d,
+	0x0a, 0x0e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x41, 0x0a, 0x09, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x09, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x8b, 0x01,
+	0x0a, 0x18, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x33,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03,
+	0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x9a, 0x01, 0x0a, 0x19,
+	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a,
+	0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d,
+	0x73, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64,
+	0x4d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x22, 0xb1, 0x01, 0x0a, 0x14, 0x54, 0x65, 0x6c,
+	0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e,
+	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72,
+	0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x22, 0x92, 0x02, 0x0a,
+	0x1b, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74,
+	0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x6c, 0x0a, 0x10,
+	0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x10, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x78, 0x0a, 0x14, 0x69, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x6c,
+	0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56,
+	0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x14,
+	0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0b, 0x0a, 0x09, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x9e, 0x01, 0x0a, 0x2b, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41,
+	0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72,
+	0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x22, 0x93, 0x01, 0x0a, 0x2f, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79,
+	0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x93, 0x01, 0x0a, 0x15, 0x54, 0x65, 0x6c,
+	0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xe4,
+	0x01, 0x0a, 0x18, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xd4, 0x01, 0x0a, 0x18, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x6f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xa7, 0x02, 0x0a,
+	0x1d, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x53, 0x68, 0x6f, 0x75, 0x6c, 0x64,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x43, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x43, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x44, 0x75, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x44,
+	0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xd2, 0x01, 0x0a, 0x1e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x66, 0x66, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xb7, 0x01, 0x0a, 0x19,
+	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x66, 0x66, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x1c, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x7d, 0x0a, 0x15, 0x54, 0x65,
+	0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x28, 0x0a, 0x10, 0x54, 0x65, 0x6c,
+	0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xd8, 0x02, 0x0a, 0x1c, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x51, 0x0a, 0x12, 0x63,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x12, 0x63, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa1,
+	0x01, 0x0a, 0x11, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x45, 0x6e, 0x76, 0x65,
+	0x6c, 0x6f, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x61, 0x63,
+	0x6c, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6f, 0x72, 0x61, 0x63,
+	0x6c, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_cl_offchainreporting_telemetry_proto_rawDescOnce sync.Once
+	file_cl_offchainreporting_telemetry_proto_rawDescData = file_cl_offchainreporting_telemetry_proto_rawDesc
+)
+
+func file_cl_offchainreporting_telemetry_proto_rawDescGZIP() []byte {
+	file_cl_offchainreporting_telemetry_proto_rawDescOnce.Do(func() {
+		file_cl_offchainreporting_telemetry_proto_rawDescData = protoimpl.X.CompressGZIP(file_cl_offchainreporting_telemetry_proto_rawDescData)
+	})
+	return file_cl_offchainreporting_telemetry_proto_rawDescData
+}
+
+var file_cl_offchainreporting_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_cl_offchainreporting_telemetry_proto_goTypes = []interface{}{
+	(*TelemetryWrapper)(nil),                                // 0: offchainreporting.TelemetryWrapper
+	(*TelemetryBatch)(nil),                                  // 1: offchainreporting.TelemetryBatch
+	(*TelemetryMessageReceived)(nil),                        // 2: offchainreporting.TelemetryMessageReceived
+	(*TelemetryMessageBroadcast)(nil),                       // 3: offchainreporting.TelemetryMessageBroadcast
+	(*TelemetryMessageSent)(nil),                            // 4: offchainreporting.TelemetryMessageSent
+	(*TelemetryAssertionViolation)(nil),                     // 5: offchainreporting.TelemetryAssertionViolation
+	(*TelemetryAssertionViolationInvalidSignature)(nil),     // 6: offchainreporting.TelemetryAssertionViolationInvalidSignature
+	(*TelemetryAssertionViolationInvalidSerialization)(nil), // 7: offchainreporting.TelemetryAssertionViolationInvalidSerialization
+	(*TelemetryRoundStarted)(nil),                           // 8: offchainreporting.TelemetryRoundStarted
+	(*TelemetryObservationMade)(nil),                        // 9: offchainreporting.TelemetryObservationMade
+	(*TelemetryReportGenerated)(nil),                        // 10: offchainreporting.TelemetryReportGenerated
+	(*TelemetryShouldReportDecision)(nil),                   // 11: offchainreporting.TelemetryShouldReportDecision
+	(*TelemetryTransmissionScheduled)(nil),                  // 12: offchainreporting.TelemetryTransmissionScheduled
+	(*TelemetryTransmissionSent)(nil),                       // 13: offchainreporting.TelemetryTransmissionSent
+	(*TelemetryTransmissionSkipped)(nil),                    // 14: offchainreporting.TelemetryTransmissionSkipped
+	(*TelemetryEpochChanged)(nil),                           // 15: offchainreporting.TelemetryEpochChanged
+	(*TelemetryDropped)(nil),                                // 16: offchainreporting.TelemetryDropped
+	(*TelemetryMisbehaviorDetected)(nil),                    // 17: offchainreporting.TelemetryMisbehaviorDetected
+	(*TelemetryEnvelope)(nil),                               // 18: offchainreporting.TelemetryEnvelope
+	(*MessageWrapper)(nil),                                  // 19: offchainreporting.MessageWrapper
+	(*Observation)(nil),                                     // 20: offchainreporting.Observation
+}
+var file_cl_offchainreporting_telemetry_proto_depIdxs = []int32{
+	2,  // 0: offchainreporting.TelemetryWrapper.messageReceived:type_name -> offchainreporting.TelemetryMessageReceived
+	3,  // 1: offchainreporting.TelemetryWrapper.messageBroadcast:type_name -> offchainreporting.TelemetryMessageBroadcast
+	4,  // 2: offchainreporting.TelemetryWrapper.messageSent:type_name -> offchainreporting.TelemetryMessageSent
+	5,  // 3: offchainreporting.TelemetryWrapper.assertionViolation:type_name -> offchainreporting.TelemetryAssertionViolation
+	8,  // 4: offchainreporting.TelemetryWrapper.roundStarted:type_name -> offchainreporting.TelemetryRoundStarted
+	9,  // 5: offchainreporting.TelemetryWrapper.observationMade:type_name -> offchainreporting.TelemetryObservationMade
+	10, // 6: offchainreporting.TelemetryWrapper.reportGenerated:type_name -> offchainreporting.TelemetryReportGenerated
+	11, // 7: offchainreporting.TelemetryWrapper.shouldReportDecision:type_name -> offchainreporting.TelemetryShouldReportDecision
+	12, // 8: offchainreporting.TelemetryWrapper.transmissionScheduled:type_name -> offchainreporting.TelemetryTransmissionScheduled
+	13, // 9: offchainreporting.TelemetryWrapper.transmissionSent:type_name -> offchainreporting.TelemetryTransmissionSent
+	14, // 10: offchainreporting.TelemetryWrapper.transmissionSkipped:type_name -> offchainreporting.TelemetryTransmissionSkipped
+	15, // 11: offchainreporting.TelemetryWrapper.epochChanged:type_name -> offchainreporting.TelemetryEpochChanged
+	16, // 12: offchainreporting.TelemetryWrapper.dropped:type_name -> offchainreporting.TelemetryDropped
+	17, // 13: offchainreporting.TelemetryWrapper.misbehaviorDetected:type_name -> offchainreporting.TelemetryMisbehaviorDetected
+	0,  // 14: offchainreporting.TelemetryBatch.telemetry:type_name -> offchainreporting.TelemetryWrapper
+	19, // 15: offchainreporting.TelemetryMessageReceived.msg:type_name -> offchainreporting.MessageWrapper
+	19, // 16: offchainreporting.TelemetryMessageBroadcast.msg:type_name -> offchainreporting.MessageWrapper
+	19, // 17: offchainreporting.TelemetryMessageSent.msg:type_name -> offchainreporting.MessageWrapper
+	6,  // 18: offchainreporting.TelemetryAssertionViolation.invalidSignature:type_name -> offchainreporting.TelemetryAssertionViolationInvalidSignature
+	7,  // 19: offchainreporting.TelemetryAssertionViolation.invalidSerialization:type_name -> offchainreporting.TelemetryAssertionViolationInvalidSerialization
+	19, // 20: offchainreporting.TelemetryAssertionViolationInvalidSignature.msg:type_name -> offchainreporting.MessageWrapper
+	20, // 21: offchainreporting.TelemetryObservationMade.value:type_name -> offchainreporting.Observation
+	20, // 22: offchainreporting.TelemetryReportGenerated.median:type_name -> offchainreporting.Observation
+	20, // 23: offchainreporting.TelemetryTransmissionScheduled.median:type_name -> offchainreporting.Observation
+	20, // 24: offchainreporting.TelemetryTransmissionSent.median:type_name -> offchainreporting.Observation
+	19, // 25: offchainreporting.TelemetryMisbehaviorDetected.message:type_name -> offchainreporting.MessageWrapper
+	19, // 26: offchainreporting.TelemetryMisbehaviorDetected.conflictingMessage:type_name -> offchainreporting.MessageWrapper
+	27, // [27:27] is the sub-list for method output_type
+	27, // [27:27] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_cl_offchainreporting_telemetry_proto_init() }
+func file_cl_offchainreporting_telemetry_proto_init() {
+	if File_cl_offchainreporting_telemetry_proto != nil {
+		return
+	}
+	file_cl_offchainreporting_messages_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_cl_offchainreporting_telemetry_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryWrapper); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryMessageReceived); i {
+			case 0:
 				return &v.state
 			case 1:
 				return &v.sizeCache
@@ -834,7 +2081,7 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 			}
 		}
 		file_cl_offchainreporting_telemetry_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryMessageSent); i {
+			switch v := v.(*TelemetryMessageBroadcast); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -846,7 +2093,7 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 			}
 		}
 		file_cl_offchainreporting_telemetry_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryAssertionViolation); i {
+			switch v := v.(*TelemetryMessageSent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -858,7 +2105,7 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 			}
 		}
 		file_cl_offchainreporting_telemetry_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryAssertionViolationInvalidSignature); i {
+			switch v := v.(*TelemetryAssertionViolation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -870,7 +2117,7 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 			}
 		}
 		file_cl_offchainreporting_telemetry_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryAssertionViolationInvalidSerialization); i {
+			switch v := v.(*TelemetryAssertionViolationInvalidSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -882,6 +2129,18 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 			}
 		}
 		file_cl_offchainreporting_telemetry_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryAssertionViolationInvalidSerialization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TelemetryRoundStarted); i {
 			case 0:
 				return &v.state
@@ -893,6 +2152,126 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 				return nil
 			}
 		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryObservationMade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryReportGenerated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryShouldReportDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryTransmissionScheduled); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryTransmissionSent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryTransmissionSkipped); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryEpochChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryDropped); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryMisbehaviorDetected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_telemetry_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryEnvelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_cl_offchainreporting_telemetry_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*TelemetryWrapper_MessageReceived)(nil),
@@ -900,8 +2279,17 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 		(*TelemetryWrapper_MessageSent)(nil),
 		(*TelemetryWrapper_AssertionViolation)(nil),
 		(*TelemetryWrapper_RoundStarted)(nil),
+		(*TelemetryWrapper_ObservationMade)(nil),
+		(*TelemetryWrapper_ReportGenerated)(nil),
+		(*TelemetryWrapper_ShouldReportDecision)(nil),
+		(*TelemetryWrapper_TransmissionScheduled)(nil),
+		(*TelemetryWrapper_TransmissionSent)(nil),
+		(*TelemetryWrapper_TransmissionSkipped)(nil),
+		(*TelemetryWrapper_EpochChanged)(nil),
+		(*TelemetryWrapper_Dropped)(nil),
+		(*TelemetryWrapper_MisbehaviorDetected)(nil),
 	}
-	file_cl_offchainreporting_telemetry_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_cl_offchainreporting_telemetry_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*TelemetryAssertionViolation_InvalidSignature)(nil),
 		(*TelemetryAssertionViolation_InvalidSerialization)(nil),
 	}
@@ -911,7 +2299,7 @@ func file_cl_offchainreporting_telemetry_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cl_offchainreporting_telemetry_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
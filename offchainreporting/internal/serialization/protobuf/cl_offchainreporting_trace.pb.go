@@ -0,0 +1,622 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.12.3
+// source: cl_offchainreporting_trace.proto
+
+package protobuf
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type TraceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time uint64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are assignable to Event:
+	//	*TraceEvent_Started
+	//	*TraceEvent_Sent
+	//	*TraceEvent_Broadcast
+	//	*TraceEvent_Received
+	//	*TraceEvent_TimerFired
+	Event isTraceEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *TraceEvent) Reset() {
+	*x = TraceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_trace_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceEvent) ProtoMessage() {}
+
+func (x *TraceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_trace_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceEvent.ProtoReflect.Descriptor instead.
+func (*TraceEvent) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_trace_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TraceEvent) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (m *TraceEvent) GetEvent() isTraceEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *TraceEvent) GetStarted() *TraceStarted {
+	if x, ok := x.GetEvent().(*TraceEvent_Started); ok {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *TraceEvent) GetSent() *TraceMessageSent {
+	if x, ok := x.GetEvent().(*TraceEvent_Sent); ok {
+		return x.Sent
+	}
+	return nil
+}
+
+func (x *TraceEvent) GetBroadcast() *TraceMessageBroadcast {
+	if x, ok := x.GetEvent().(*TraceEvent_Broadcast); ok {
+		return x.Broadcast
+	}
+	return nil
+}
+
+func (x *TraceEvent) GetReceived() *TraceMessageReceived {
+	if x, ok := x.GetEvent().(*TraceEvent_Received); ok {
+		return x.Received
+	}
+	return nil
+}
+
+func (x *TraceEvent) GetTimerFired() *TraceTimerFired {
+	if x, ok := x.GetEvent().(*TraceEvent_TimerFired); ok {
+		return x.TimerFired
+	}
+	return nil
+}
+
+type isTraceEvent_Event interface {
+	isTraceEvent_Event()
+}
+
+type TraceEvent_Started struct {
+	Started *TraceStarted `protobuf:"bytes,2,opt,name=started,proto3,oneof"`
+}
+
+type TraceEvent_Sent struct {
+	Sent *TraceMessageSent `protobuf:"bytes,3,opt,name=sent,proto3,oneof"`
+}
+
+type TraceEvent_Broadcast struct {
+	Broadcast *TraceMessageBroadcast `protobuf:"bytes,4,opt,name=broadcast,proto3,oneof"`
+}
+
+type TraceEvent_Received struct {
+	Received *TraceMessageReceived `protobuf:"bytes,5,opt,name=received,proto3,oneof"`
+}
+
+type TraceEvent_TimerFired struct {
+	TimerFired *TraceTimerFired `protobuf:"bytes,6,opt,name=timerFired,proto3,oneof"`
+}
+
+func (*TraceEvent_Started) isTraceEvent_Event() {}
+
+func (*TraceEvent_Sent) isTraceEvent_Event() {}
+
+func (*TraceEvent_Broadcast) isTraceEvent_Event() {}
+
+func (*TraceEvent_Received) isTraceEvent_Event() {}
+
+func (*TraceEvent_TimerFired) isTraceEvent_Event() {}
+
+type TraceStarted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte `protobuf:"bytes,1,opt,name=configDigest,proto3" json:"configDigest,omitempty"`
+	OracleID     uint32 `protobuf:"varint,2,opt,name=oracleID,proto3" json:"oracleID,omitempty"`
+}
+
+func (x *TraceStarted) Reset() {
+	*x = TraceStarted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_trace_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceStarted) ProtoMessage() {}
+
+func (x *TraceStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_trace_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceStarted.ProtoReflect.Descriptor instead.
+func (*TraceStarted) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_trace_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TraceStarted) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TraceStarted) GetOracleID() uint32 {
+	if x != nil {
+		return x.OracleID
+	}
+	return 0
+}
+
+type TraceMessageSent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg      *MessageWrapper `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Receiver uint32          `protobuf:"varint,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
+}
+
+func (x *TraceMessageSent) Reset() {
+	*x = TraceMessageSent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_trace_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceMessageSent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceMessageSent) ProtoMessage() {}
+
+func (x *TraceMessageSent) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_trace_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceMessageSent.ProtoReflect.Descriptor instead.
+func (*TraceMessageSent) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_trace_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TraceMessageSent) GetMsg() *MessageWrapper {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *TraceMessageSent) GetReceiver() uint32 {
+	if x != nil {
+		return x.Receiver
+	}
+	return 0
+}
+
+type TraceMessageBroadcast struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg *MessageWrapper `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *TraceMessageBroadcast) Reset() {
+	*x = TraceMessageBroadcast{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_trace_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceMessageBroadcast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceMessageBroadcast) ProtoMessage() {}
+
+func (x *TraceMessageBroadcast) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_trace_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceMessageBroadcast.ProtoReflect.Descriptor instead.
+func (*TraceMessageBroadcast) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_trace_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TraceMessageBroadcast) GetMsg() *MessageWrapper {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type TraceMessageReceived struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg    *MessageWrapper `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Sender uint32          `protobuf:"varint,2,opt,name=sender,proto3" json:"sender,omitempty"`
+}
+
+func (x *TraceMessageReceived) Reset() {
+	*x = TraceMessageReceived{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_trace_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceMessageReceived) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceMessageReceived) ProtoMessage() {}
+
+func (x *TraceMessageReceived) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_trace_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceMessageReceived.ProtoReflect.Descriptor instead.
+func (*TraceMessageReceived) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_trace_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TraceMessageReceived) GetMsg() *MessageWrapper {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *TraceMessageReceived) GetSender() uint32 {
+	if x != nil {
+		return x.Sender
+	}
+	return 0
+}
+
+type TraceTimerFired struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Duration uint64 `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *TraceTimerFired) Reset() {
+	*x = TraceTimerFired{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cl_offchainreporting_trace_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceTimerFired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceTimerFired) ProtoMessage() {}
+
+func (x *TraceTimerFired) ProtoReflect() protoreflect.Message {
+	mi := &file_cl_offchainreporting_trace_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceTimerFired.ProtoReflect.Descriptor instead.
+func (*TraceTimerFired) Descriptor() ([]byte, []int) {
+	return file_cl_offchainreporting_trace_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TraceTimerFired) GetDuration() uint64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+var File_cl_offchainreporting_trace_proto protoreflect.FileDescriptor
+
+var file_cl_offchainreporting_trace_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x63, 0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x23, 0x63, 0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf8, 0x02, 0x0a, 0x0a, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a,
+	0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x04, 0x73, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
+	0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x48, 0x0a, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12,
+	0x45, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x44, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x72, 0x46,
+	0x69, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x72, 0x46, 0x69, 0x72, 0x65, 0x64, 0x48, 0x00,
+	0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x72, 0x46, 0x69, 0x72, 0x65, 0x64, 0x42, 0x07, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x4e, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x49, 0x44, 0x22, 0x63, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x63, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x22, 0x4c, 0x0a, 0x15, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x63, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x33, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
+	0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x2d, 0x0a,
+	0x0f, 0x54, 0x72, 0x61, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x72, 0x46, 0x69, 0x72, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0c, 0x5a, 0x0a,
+	0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_cl_offchainreporting_trace_proto_rawDescOnce sync.Once
+	file_cl_offchainreporting_trace_proto_rawDescData = file_cl_offchainreporting_trace_proto_rawDesc
+)
+
+func file_cl_offchainreporting_trace_proto_rawDescGZIP() []byte {
+	file_cl_offchainreporting_trace_proto_rawDescOnce.Do(func() {
+		file_cl_offchainreporting_trace_proto_rawDescData = protoimpl.X.CompressGZIP(file_cl_offchainreporting_trace_proto_rawDescData)
+	})
+	return file_cl_offchainreporting_trace_proto_rawDescData
+}
+
+var file_cl_offchainreporting_trace_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_cl_offchainreporting_trace_proto_goTypes = []interface{}{
+	(*TraceEvent)(nil),            // 0: offchainreporting.TraceEvent
+	(*TraceStarted)(nil),          // 1: offchainreporting.TraceStarted
+	(*TraceMessageSent)(nil),      // 2: offchainreporting.TraceMessageSent
+	(*TraceMessageBroadcast)(nil), // 3: offchainreporting.TraceMessageBroadcast
+	(*TraceMessageReceived)(nil),  // 4: offchainreporting.TraceMessageReceived
+	(*TraceTimerFired)(nil),       // 5: offchainreporting.TraceTimerFired
+	(*MessageWrapper)(nil),        // 6: offchainreporting.MessageWrapper
+}
+var file_cl_offchainreporting_trace_proto_depIdxs = []int32{
+	1, // 0: offchainreporting.TraceEvent.started:type_name -> offchainreporting.TraceStarted
+	2, // 1: offchainreporting.TraceEvent.sent:type_name -> offchainreporting.TraceMessageSent
+	3, // 2: offchainreporting.TraceEvent.broadcast:type_name -> offchainreporting.TraceMessageBroadcast
+	4, // 3: offchainreporting.TraceEvent.received:type_name -> offchainreporting.TraceMessageReceived
+	5, // 4: offchainreporting.TraceEvent.timerFired:type_name -> offchainreporting.TraceTimerFired
+	6, // 5: offchainreporting.TraceMessageSent.msg:type_name -> offchainreporting.MessageWrapper
+	6, // 6: offchainreporting.TraceMessageBroadcast.msg:type_name -> offchainreporting.MessageWrapper
+	6, // 7: offchainreporting.TraceMessageReceived.msg:type_name -> offchainreporting.MessageWrapper
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_cl_offchainreporting_trace_proto_init() }
+func file_cl_offchainreporting_trace_proto_init() {
+	if File_cl_offchainreporting_trace_proto != nil {
+		return
+	}
+	file_cl_offchainreporting_messages_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_cl_offchainreporting_trace_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_trace_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceStarted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_trace_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceMessageSent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_trace_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceMessageBroadcast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_trace_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceMessageReceived); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cl_offchainreporting_trace_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceTimerFired); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_cl_offchainreporting_trace_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*TraceEvent_Started)(nil),
+		(*TraceEvent_Sent)(nil),
+		(*TraceEvent_Broadcast)(nil),
+		(*TraceEvent_Received)(nil),
+		(*TraceEvent_TimerFired)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_cl_offchainreporting_trace_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cl_offchainreporting_trace_proto_goTypes,
+		DependencyIndexes: file_cl_offchainreporting_trace_proto_depIdxs,
+		MessageInfos:      file_cl_offchainreporting_trace_proto_msgTypes,
+	}.Build()
+	File_cl_offchainreporting_trace_proto = out.File
+	file_cl_offchainreporting_trace_proto_rawDesc = nil
+	file_cl_offchainreporting_trace_proto_goTypes = nil
+	file_cl_offchainreporting_trace_proto_depIdxs = nil
+}
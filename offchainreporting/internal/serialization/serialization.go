@@ -59,15 +59,7 @@ func toProtoMessage(m protocol.Message) (*protobuf.MessageWrapper, error) {
 		}
 		msgWrapper.Msg = &protobuf.MessageWrapper_MessageObserve{pm}
 	case protocol.MessageReportReq:
-		pm := &protobuf.MessageReportReq{
-			Round: uint64(v.Round),
-			Epoch: uint64(v.Epoch),
-		}
-		for _, o := range v.AttributedSignedObservations {
-			pm.AttributedSignedObservations = append(pm.AttributedSignedObservations,
-				attributedSignedObservationToProtoMessage(o))
-		}
-		msgWrapper.Msg = &protobuf.MessageWrapper_MessageReportReq{pm}
+		msgWrapper.Msg = &protobuf.MessageWrapper_MessageReportReq{reportReqToProtoMessage(v)}
 	case protocol.MessageReport:
 		pm := &protobuf.MessageReport{
 			Epoch:  uint64(v.Epoch),
@@ -81,6 +73,17 @@ func toProtoMessage(m protocol.Message) (*protobuf.MessageWrapper, error) {
 		msgWrapper.Msg = &protobuf.MessageWrapper_MessageFinalEcho{
 			&protobuf.MessageFinalEcho{Final: finalToProtoMessage(v.MessageFinal)},
 		}
+	case protocol.MessageReportReqDigest:
+		pm := &protobuf.MessageReportReqDigest{
+			Epoch:  uint64(v.Epoch),
+			Round:  uint64(v.Round),
+			Digest: v.Digest[:],
+		}
+		msgWrapper.Msg = &protobuf.MessageWrapper_MessageReportReqDigest{pm}
+	case protocol.MessageReportReqConflict:
+		msgWrapper.Msg = &protobuf.MessageWrapper_MessageReportReqConflict{
+			&protobuf.MessageReportReqConflict{ReportReq: reportReqToProtoMessage(v.ReportReq)},
+		}
 	default:
 		return nil, errors.Errorf("Unable to serialize message of type %T", m)
 
@@ -88,6 +91,18 @@ func toProtoMessage(m protocol.Message) (*protobuf.MessageWrapper, error) {
 	return &msgWrapper, nil
 }
 
+func reportReqToProtoMessage(v protocol.MessageReportReq) *protobuf.MessageReportReq {
+	pm := &protobuf.MessageReportReq{
+		Round: uint64(v.Round),
+		Epoch: uint64(v.Epoch),
+	}
+	for _, o := range v.AttributedSignedObservations {
+		pm.AttributedSignedObservations = append(pm.AttributedSignedObservations,
+			attributedSignedObservationToProtoMessage(o))
+	}
+	return pm
+}
+
 func observationToProtoMessage(o observation.Observation) *protobuf.Observation {
 	return &protobuf.Observation{Value: o.Marshal()}
 }
@@ -126,6 +141,10 @@ func messageWrapperFromProtoMessage(wrapper *protobuf.MessageWrapper) (protocol.
 		return messageFinalFromProtoMessage(wrapper.GetMessageFinal())
 	case *protobuf.MessageWrapper_MessageFinalEcho:
 		return messageFinalEchoFromProtoMessage(wrapper.GetMessageFinalEcho())
+	case *protobuf.MessageWrapper_MessageReportReqDigest:
+		return messageReportReqDigestFromProtoMessage(wrapper.GetMessageReportReqDigest())
+	case *protobuf.MessageWrapper_MessageReportReqConflict:
+		return messageReportReqConflictFromProtoMessage(wrapper.GetMessageReportReqConflict())
 	default:
 		return nil, errors.Errorf("Unrecognised Msg type %T", msg)
 	}
@@ -297,6 +316,29 @@ func messageFinalEchoFromProtoMessage(m *protobuf.MessageFinalEcho) (protocol.Me
 	return protocol.MessageFinalEcho{MessageFinal: final}, nil
 }
 
+func messageReportReqDigestFromProtoMessage(m *protobuf.MessageReportReqDigest) (protocol.MessageReportReqDigest, error) {
+	if m == nil {
+		return protocol.MessageReportReqDigest{}, errors.New("Unable to extract a MessageReportReqDigest value")
+	}
+	var digest protocol.ReportReqDigest
+	if len(m.Digest) != len(digest) {
+		return protocol.MessageReportReqDigest{}, errors.Errorf("Invalid digest length %d", len(m.Digest))
+	}
+	copy(digest[:], m.Digest)
+	return protocol.MessageReportReqDigest{uint32(m.Epoch), uint8(m.Round), digest}, nil
+}
+
+func messageReportReqConflictFromProtoMessage(m *protobuf.MessageReportReqConflict) (protocol.MessageReportReqConflict, error) {
+	if m == nil {
+		return protocol.MessageReportReqConflict{}, errors.New("Unable to extract a MessageReportReqConflict value")
+	}
+	reportReq, err := messageReportReqFromProtoMessage(m.ReportReq)
+	if err != nil {
+		return protocol.MessageReportReqConflict{}, err
+	}
+	return protocol.MessageReportReqConflict{reportReq}, nil
+}
+
 func attributedSignedObservationsFromProtoMessage(pbasos []*protobuf.AttributedSignedObservation) ([]protocol.AttributedSignedObservation, error) {
 	if pbasos == nil {
 		// note: we return an empty list instead of an error, because protobuf
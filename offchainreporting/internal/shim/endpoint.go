@@ -13,7 +13,7 @@ import (
 )
 
 type SerializingEndpoint struct {
-	chTelemetry  chan<- *protobuf.TelemetryWrapper
+	telemetry    TelemetryQueue
 	configDigest types.ConfigDigest
 	endpoint     types.BinaryNetworkEndpoint
 	logger       types.Logger
@@ -29,13 +29,13 @@ type SerializingEndpoint struct {
 var _ protocol.NetworkEndpoint = (*SerializingEndpoint)(nil)
 
 func NewSerializingEndpoint(
-	chTelemetry chan<- *protobuf.TelemetryWrapper,
+	telemetry TelemetryQueue,
 	configDigest types.ConfigDigest,
 	endpoint types.BinaryNetworkEndpoint,
 	logger types.Logger,
 ) *SerializingEndpoint {
 	return &SerializingEndpoint{
-		chTelemetry,
+		telemetry,
 		configDigest,
 		endpoint,
 		logger,
@@ -50,10 +50,7 @@ func NewSerializingEndpoint(
 }
 
 func (n *SerializingEndpoint) sendTelemetry(t *protobuf.TelemetryWrapper) {
-	select {
-	case n.chTelemetry <- t:
-	default:
-	}
+	n.telemetry.Enqueue(t)
 }
 
 func (n *SerializingEndpoint) serialize(msg protocol.Message) ([]byte, *protobuf.MessageWrapper) {
@@ -0,0 +1,59 @@
+package shim
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// EvidenceStore keeps the most recent evidence of misbehavior against each
+// oracle, up to a fixed capacity per oracle, so that a single misbehaving
+// oracle can't crowd out the evidence against others. It is safe for
+// concurrent use.
+type EvidenceStore struct {
+	mutex             sync.Mutex
+	capacityPerOracle int
+	evidence          map[types.OracleID][]types.MisbehaviorEvidence
+}
+
+func NewEvidenceStore(capacityPerOracle int) *EvidenceStore {
+	return &EvidenceStore{
+		capacityPerOracle: capacityPerOracle,
+		evidence:          map[types.OracleID][]types.MisbehaviorEvidence{},
+	}
+}
+
+func (s *EvidenceStore) add(e types.MisbehaviorEvidence) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.capacityPerOracle <= 0 {
+		return
+	}
+	evidence := s.evidence[e.Sender]
+	if len(evidence) == s.capacityPerOracle {
+		copy(evidence, evidence[1:])
+		evidence = evidence[:s.capacityPerOracle-1]
+	}
+	s.evidence[e.Sender] = append(evidence, e)
+}
+
+// Evidence returns the stored evidence, oldest first
+func (s *EvidenceStore) Evidence() []types.MisbehaviorEvidence {
+	if s == nil {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var evidence []types.MisbehaviorEvidence
+	for _, e := range s.evidence {
+		evidence = append(evidence, e...)
+	}
+	sort.SliceStable(evidence, func(i, j int) bool {
+		return evidence[i].Time.Before(evidence[j].Time)
+	})
+	return evidence
+}
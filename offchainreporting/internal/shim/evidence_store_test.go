@@ -0,0 +1,82 @@
+package shim
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func TestEvidenceStoreKeepsEvidencePerOracle(t *testing.T) {
+	s := NewEvidenceStore(2)
+	start := time.Unix(0, 0)
+	s.add(types.MisbehaviorEvidence{Time: start, Sender: 1, Reason: "kept"})
+	// Oracle 2 misbehaves a lot, which must not push out the evidence
+	// against oracle 1
+	for i := 1; i <= 10; i++ {
+		s.add(types.MisbehaviorEvidence{Time: start.Add(time.Duration(i) * time.Second), Sender: 2})
+	}
+
+	evidence := s.Evidence()
+	if len(evidence) != 3 {
+		t.Fatalf("stored %d pieces of evidence, expected 3", len(evidence))
+	}
+	if evidence[0].Sender != 1 || evidence[0].Reason != "kept" {
+		t.Errorf("evidence against oracle 1 was pushed out: %v", evidence)
+	}
+	for i, e := range evidence[1:] {
+		if expected := start.Add(time.Duration(9+i) * time.Second); e.Sender != 2 || !e.Time.Equal(expected) {
+			t.Errorf("evidence #%d is from %v against oracle %d, expected the latest against oracle 2",
+				i+1, e.Time, e.Sender)
+		}
+	}
+}
+
+func TestEvidenceStoreWithoutCapacity(t *testing.T) {
+	s := NewEvidenceStore(0)
+	s.add(types.MisbehaviorEvidence{Sender: 1})
+	if len(s.Evidence()) != 0 {
+		t.Errorf("store without capacity kept evidence")
+	}
+	var nilStore *EvidenceStore
+	nilStore.add(types.MisbehaviorEvidence{Sender: 1})
+	if nilStore.Evidence() != nil {
+		t.Errorf("nil store returned evidence")
+	}
+}
+
+func TestTelemetrySenderOnlyStoresSelfAuthenticatingEvidence(t *testing.T) {
+	s := NewEvidenceStore(10)
+	queue := NewTelemetryQueue(10)
+	ts := MakeTelemetrySender(clock.Real, s, testlogger.Nop{}, queue)
+
+	observe := func(v int64) protocol.MessageObserve {
+		o, err := observation.MakeObservation(big.NewInt(v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return protocol.MessageObserve{1, 2, protocol.SignedObservation{o, []byte{1}}}
+	}
+	ts.MisbehaviorDetected(protocol.MisbehaviorEvidence{
+		time.Unix(1, 0), types.ConfigDigest{}, 1, 2, 3, "observe", "invalid signature",
+		observe(1), nil, false,
+	})
+	ts.MisbehaviorDetected(protocol.MisbehaviorEvidence{
+		time.Unix(2, 0), types.ConfigDigest{}, 1, 2, 3, "observerEquivocation", "two observations",
+		observe(1), observe(2), true,
+	})
+
+	if len(queue.Chan()) != 2 {
+		t.Errorf("sent %d pieces of evidence as telemetry, expected all 2", len(queue.Chan()))
+	}
+	evidence := s.Evidence()
+	if len(evidence) != 1 || evidence[0].Check != "observerEquivocation" ||
+		len(evidence[0].Message) == 0 || len(evidence[0].ConflictingMessage) == 0 {
+		t.Errorf("stored %v, expected only the equivocation", evidence)
+	}
+}
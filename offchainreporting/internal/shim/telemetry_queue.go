@@ -0,0 +1,62 @@
+package shim
+
+import (
+	"sync/atomic"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// TelemetrySource identifies the protocol instance telemetry originates from
+type TelemetrySource struct {
+	ConfigDigest types.ConfigDigest
+	OracleID     types.OracleID
+}
+
+// TelemetryEvent is a queued telemetry event together with its source
+type TelemetryEvent struct {
+	Source    TelemetrySource
+	Telemetry *protobuf.TelemetryWrapper
+}
+
+// TelemetryQueue buffers telemetry on its way to the monitoring endpoint.
+// Enqueue never blocks: if the queue is full, the telemetry is dropped and
+// counted instead, so that a slow monitoring endpoint cannot stall the
+// protocol.
+type TelemetryQueue struct {
+	ch      chan TelemetryEvent
+	dropped *uint64
+	source  TelemetrySource
+}
+
+func NewTelemetryQueue(capacity int) TelemetryQueue {
+	return TelemetryQueue{
+		make(chan TelemetryEvent, capacity),
+		new(uint64),
+		TelemetrySource{},
+	}
+}
+
+// WithSource returns a view of q which attributes all telemetry enqueued
+// through it to source
+func (q TelemetryQueue) WithSource(source TelemetrySource) TelemetryQueue {
+	return TelemetryQueue{q.ch, q.dropped, source}
+}
+
+func (q TelemetryQueue) Enqueue(t *protobuf.TelemetryWrapper) {
+	select {
+	case q.ch <- TelemetryEvent{q.source, t}:
+	default:
+		atomic.AddUint64(q.dropped, 1)
+	}
+}
+
+// Chan returns the channel carrying the queued telemetry
+func (q TelemetryQueue) Chan() <-chan TelemetryEvent {
+	return q.ch
+}
+
+// Dropped returns the total number of telemetry events dropped so far
+func (q TelemetryQueue) Dropped() uint64 {
+	return atomic.LoadUint64(q.dropped)
+}
@@ -3,23 +3,29 @@ package shim
 import (
 	"time"
 
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization"
 	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 )
 
 type TelemetrySender struct {
-	chTelemetry chan<- *protobuf.TelemetryWrapper
+	clock     clock.Clock
+	evidence  *EvidenceStore
+	logger    types.Logger
+	telemetry TelemetryQueue
 }
 
-func MakeTelemetrySender(chTelemetry chan<- *protobuf.TelemetryWrapper) TelemetrySender {
-	return TelemetrySender{chTelemetry}
+// MakeTelemetrySender returns a TelemetrySender which timestamps events with
+// clock, which should be the clock the protocol runs against
+func MakeTelemetrySender(clock clock.Clock, evidence *EvidenceStore, logger types.Logger, telemetry TelemetryQueue) TelemetrySender {
+	return TelemetrySender{clock, evidence, logger, telemetry}
 }
 
 func (ts TelemetrySender) send(t *protobuf.TelemetryWrapper) {
-	select {
-	case ts.chTelemetry <- t:
-	default:
-	}
+	ts.telemetry.Enqueue(t)
 }
 
 func (ts TelemetrySender) RoundStarted(
@@ -34,7 +40,209 @@ func (ts TelemetrySender) RoundStarted(
 			Epoch:        uint64(epoch),
 			Round:        uint64(round),
 			Leader:       uint64(leader),
-			Time:         uint64(time.Now().UnixNano()),
+			Time:         uint64(ts.clock.Now().UnixNano()),
+		}},
+	})
+}
+
+func (ts TelemetrySender) ObservationMade(
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	value observation.Observation,
+	latency time.Duration,
+	err error,
+) {
+	errString := ""
+	if err != nil {
+		errString = err.Error()
+	}
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_ObservationMade{&protobuf.TelemetryObservationMade{
+			ConfigDigest: configDigest[:],
+			Epoch:        uint64(epoch),
+			Round:        uint64(round),
+			Value:        observationProtobuf(value),
+			Latency:      uint64(latency),
+			Error:        errString,
+			Time:         uint64(ts.clock.Now().UnixNano()),
+		}},
+	})
+}
+
+func (ts TelemetrySender) ReportGenerated(
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	median observation.Observation,
+	observers []types.OracleID,
+) {
+	pbObservers := make([]uint32, 0, len(observers))
+	for _, o := range observers {
+		pbObservers = append(pbObservers, uint32(o))
+	}
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_ReportGenerated{&protobuf.TelemetryReportGenerated{
+			ConfigDigest: configDigest[:],
+			Epoch:        uint64(epoch),
+			Round:        uint64(round),
+			Median:       observationProtobuf(median),
+			Observers:    pbObservers,
+			Time:         uint64(ts.clock.Now().UnixNano()),
+		}},
+	})
+}
+
+func (ts TelemetrySender) ShouldReportDecision(
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	result bool,
+	deviation bool,
+	deltaCTimeout bool,
+	initialRound bool,
+	heartbeatDue bool,
+) {
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_ShouldReportDecision{&protobuf.TelemetryShouldReportDecision{
+			ConfigDigest:  configDigest[:],
+			Epoch:         uint64(epoch),
+			Round:         uint64(round),
+			Result:        result,
+			Deviation:     deviation,
+			DeltaCTimeout: deltaCTimeout,
+			InitialRound:  initialRound,
+			HeartbeatDue:  heartbeatDue,
+			Time:          uint64(ts.clock.Now().UnixNano()),
+		}},
+	})
+}
+
+func (ts TelemetrySender) TransmissionScheduled(
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	median observation.Observation,
+	delay time.Duration,
+) {
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_TransmissionScheduled{&protobuf.TelemetryTransmissionScheduled{
+			ConfigDigest: configDigest[:],
+			Epoch:        uint64(epoch),
+			Round:        uint64(round),
+			Median:       observationProtobuf(median),
+			Delay:        uint64(delay),
+			Time:         uint64(ts.clock.Now().UnixNano()),
 		}},
 	})
 }
+
+func (ts TelemetrySender) TransmissionSent(
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	median observation.Observation,
+) {
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_TransmissionSent{&protobuf.TelemetryTransmissionSent{
+			ConfigDigest: configDigest[:],
+			Epoch:        uint64(epoch),
+			Round:        uint64(round),
+			Median:       observationProtobuf(median),
+			Time:         uint64(ts.clock.Now().UnixNano()),
+		}},
+	})
+}
+
+func (ts TelemetrySender) TransmissionSkipped(
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	failed bool,
+	reason string,
+) {
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_TransmissionSkipped{&protobuf.TelemetryTransmissionSkipped{
+			ConfigDigest: configDigest[:],
+			Epoch:        uint64(epoch),
+			Round:        uint64(round),
+			Failed:       failed,
+			Reason:       reason,
+			Time:         uint64(ts.clock.Now().UnixNano()),
+		}},
+	})
+}
+
+func (ts TelemetrySender) EpochChanged(
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	leader types.OracleID,
+) {
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_EpochChanged{&protobuf.TelemetryEpochChanged{
+			ConfigDigest: configDigest[:],
+			Epoch:        uint64(epoch),
+			Leader:       uint64(leader),
+			Time:         uint64(ts.clock.Now().UnixNano()),
+		}},
+	})
+}
+
+// MisbehaviorDetected sends evidence as telemetry, and also stores it in the
+// evidence store if it is self-authenticating
+func (ts TelemetrySender) MisbehaviorDetected(evidence protocol.MisbehaviorEvidence) {
+	message, pbm, err := serialization.Serialize(evidence.Msg)
+	if err != nil {
+		ts.logger.Error("TelemetrySender: could not serialize misbehavior evidence", types.LogFields{
+			"evidence": evidence,
+			"error":    err,
+		})
+		return
+	}
+	var conflictingMessage []byte
+	var conflictingPbm *protobuf.MessageWrapper
+	if evidence.ConflictingMsg != nil {
+		conflictingMessage, conflictingPbm, err = serialization.Serialize(evidence.ConflictingMsg)
+		if err != nil {
+			ts.logger.Error("TelemetrySender: could not serialize misbehavior evidence", types.LogFields{
+				"evidence": evidence,
+				"error":    err,
+			})
+			return
+		}
+	}
+	if evidence.SelfAuthenticating {
+		ts.evidence.add(types.MisbehaviorEvidence{
+			evidence.Time,
+			evidence.ConfigDigest,
+			evidence.Epoch,
+			evidence.Round,
+			evidence.Sender,
+			evidence.Check,
+			evidence.Reason,
+			message,
+			conflictingMessage,
+		})
+	}
+	ts.send(&protobuf.TelemetryWrapper{
+		Wrapped: &protobuf.TelemetryWrapper_MisbehaviorDetected{&protobuf.TelemetryMisbehaviorDetected{
+			ConfigDigest:       evidence.ConfigDigest[:],
+			Epoch:              uint64(evidence.Epoch),
+			Round:              uint64(evidence.Round),
+			Sender:             uint64(evidence.Sender),
+			Check:              evidence.Check,
+			Reason:             evidence.Reason,
+			Message:            pbm,
+			Time:               uint64(evidence.Time.UnixNano()),
+			ConflictingMessage: conflictingPbm,
+		}},
+	})
+}
+
+// observationProtobuf returns nil for missing observations
+func observationProtobuf(o observation.Observation) *protobuf.Observation {
+	if o.IsMissingValue() {
+		return nil
+	}
+	return &protobuf.Observation{Value: o.Marshal()}
+}
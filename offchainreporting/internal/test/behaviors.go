@@ -0,0 +1,206 @@
+package test
+
+import (
+	"sync"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// Behavior determines how a byzantine oracle deviates from the protocol. The
+// oracle runs the honest protocol, but every message it sends passes through
+// its Behavior first.
+//
+// All its functions should be thread-safe.
+type Behavior interface {
+	// Rewrite is called for each message the oracle sends to receiver, and
+	// returns the messages which are delivered to receiver instead.
+	Rewrite(msg protocol.Message, receiver types.OracleID) []protocol.Message
+}
+
+// BehaviorFunc adapts a function to the Behavior interface
+type BehaviorFunc func(msg protocol.Message, receiver types.OracleID) []protocol.Message
+
+func (f BehaviorFunc) Rewrite(msg protocol.Message, receiver types.OracleID) []protocol.Message {
+	return f(msg, receiver)
+}
+
+// Silent returns a Behavior which never sends anything, modelling a crashed
+// or disconnected oracle.
+func Silent() Behavior {
+	return BehaviorFunc(func(protocol.Message, types.OracleID) []protocol.Message {
+		return nil
+	})
+}
+
+// Compose returns a Behavior which applies each of behaviors in turn
+func Compose(behaviors ...Behavior) Behavior {
+	return BehaviorFunc(func(msg protocol.Message, receiver types.OracleID) []protocol.Message {
+		msgs := []protocol.Message{msg}
+		for _, b := range behaviors {
+			var rewritten []protocol.Message
+			for _, m := range msgs {
+				rewritten = append(rewritten, b.Rewrite(m, receiver)...)
+			}
+			msgs = rewritten
+		}
+		return msgs
+	})
+}
+
+func corrupt(sig []byte) []byte {
+	rv := append([]byte{}, sig...)
+	if len(rv) != 0 {
+		rv[len(rv)/2] ^= 0x01
+	}
+	return rv
+}
+
+func corruptAttestedReportMany(report protocol.AttestedReportMany) protocol.AttestedReportMany {
+	sigs := make([][]byte, len(report.Signatures))
+	for i, sig := range report.Signatures {
+		sigs[i] = corrupt(sig)
+	}
+	return protocol.AttestedReportMany{report.AttributedObservations, sigs}
+}
+
+// InvalidSignatures returns a Behavior which corrupts the signatures on all
+// observations, reports and final reports the oracle sends.
+func InvalidSignatures() Behavior {
+	return BehaviorFunc(func(msg protocol.Message, _ types.OracleID) []protocol.Message {
+		switch m := msg.(type) {
+		case protocol.MessageObserve:
+			m.SignedObservation.Signature = corrupt(m.SignedObservation.Signature)
+			return []protocol.Message{m}
+		case protocol.MessageReportReq:
+			asos := make([]protocol.AttributedSignedObservation, len(m.AttributedSignedObservations))
+			for i, aso := range m.AttributedSignedObservations {
+				aso.SignedObservation.Signature = corrupt(aso.SignedObservation.Signature)
+				asos[i] = aso
+			}
+			m.AttributedSignedObservations = asos
+			return []protocol.Message{m}
+		case protocol.MessageReport:
+			m.Report.Signature = corrupt(m.Report.Signature)
+			return []protocol.Message{m}
+		case protocol.MessageFinal:
+			m.Report = corruptAttestedReportMany(m.Report)
+			return []protocol.Message{m}
+		case protocol.MessageFinalEcho:
+			m.Report = corruptAttestedReportMany(m.Report)
+			return []protocol.Message{m}
+		}
+		return []protocol.Message{msg}
+	})
+}
+
+// UnsortedReportReqs returns a Behavior which reverses the order of the
+// observations in every report-req the oracle sends as leader.
+func UnsortedReportReqs() Behavior {
+	return BehaviorFunc(func(msg protocol.Message, _ types.OracleID) []protocol.Message {
+		m, ok := msg.(protocol.MessageReportReq)
+		if !ok {
+			return []protocol.Message{msg}
+		}
+		n := len(m.AttributedSignedObservations)
+		asos := make([]protocol.AttributedSignedObservation, n)
+		for i, aso := range m.AttributedSignedObservations {
+			asos[n-1-i] = aso
+		}
+		m.AttributedSignedObservations = asos
+		return []protocol.Message{m}
+	})
+}
+
+// EquivocatingLeader returns a Behavior which, as leader, sends different
+// (but individually valid) report-reqs to different followers: Followers with
+// odd oracle IDs receive the report-req without its largest observation, as
+// long as that leaves more than 2f observations.
+func EquivocatingLeader(f int) Behavior {
+	return BehaviorFunc(func(msg protocol.Message, receiver types.OracleID) []protocol.Message {
+		m, ok := msg.(protocol.MessageReportReq)
+		if !ok || receiver%2 == 0 || len(m.AttributedSignedObservations) <= 2*f+1 {
+			return []protocol.Message{msg}
+		}
+		n := len(m.AttributedSignedObservations)
+		m.AttributedSignedObservations = append(
+			[]protocol.AttributedSignedObservation{},
+			m.AttributedSignedObservations[:n-1]...,
+		)
+		return []protocol.Message{m}
+	})
+}
+
+// ReplayOldFinals returns a Behavior which remembers the final reports the
+// oracle echoes, and replays the latest one from an earlier epoch whenever it
+// sends a message in a later epoch: once verbatim, and once relabelled with
+// the later epoch and round.
+func ReplayOldFinals() Behavior {
+	const maxFinals = 16
+	var mutex sync.Mutex
+	var finals []protocol.MessageFinal
+	return BehaviorFunc(func(msg protocol.Message, _ types.OracleID) []protocol.Message {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		var epoch uint32
+		var round uint8
+		switch m := msg.(type) {
+		case protocol.MessageFinalEcho:
+			if len(finals) == 0 || !finals[len(finals)-1].Equal(m.MessageFinal) {
+				finals = append(finals, m.MessageFinal)
+			}
+			if len(finals) > maxFinals {
+				finals = finals[len(finals)-maxFinals:]
+			}
+			return []protocol.Message{msg}
+		case protocol.MessageNewEpoch:
+			epoch = m.Epoch
+		case protocol.MessageObserve:
+			epoch, round = m.Epoch, m.Round
+		case protocol.MessageReport:
+			epoch, round = m.Epoch, m.Round
+		default:
+			return []protocol.Message{msg}
+		}
+
+		msgs := []protocol.Message{msg}
+		for i := len(finals) - 1; i >= 0; i-- {
+			final := finals[i]
+			if !(final.Epoch < epoch) {
+				continue
+			}
+			relabelled := final
+			relabelled.Epoch, relabelled.Round = epoch, round
+			msgs = append(msgs,
+				final,
+				protocol.MessageFinalEcho{MessageFinal: final},
+				relabelled,
+				protocol.MessageFinalEcho{MessageFinal: relabelled},
+			)
+			break
+		}
+		return msgs
+	})
+}
+
+// byzantineEndpoint passes all messages sent through endpoint through behavior
+type byzantineEndpoint struct {
+	protocol.NetworkEndpoint
+	n        int
+	behavior Behavior
+}
+
+var _ protocol.NetworkEndpoint = byzantineEndpoint{}
+
+func (b byzantineEndpoint) SendTo(msg protocol.Message, to types.OracleID) {
+	for _, m := range b.behavior.Rewrite(msg, to) {
+		b.NetworkEndpoint.SendTo(m, to)
+	}
+}
+
+func (b byzantineEndpoint) Broadcast(msg protocol.Message) {
+	for to := 0; to < b.n; to++ {
+		b.SendTo(msg, types.OracleID(to))
+	}
+}
@@ -1 +1,5 @@
+// Package test contains a harness which runs several oracles in-process over
+// a protocol.SimpleNetwork, some of which may behave byzantine, and checks
+// the safety and liveness of the resulting transmissions. Scenarios can run in
+// real time, or against virtual time in a reproducible simulation.
 package test
@@ -0,0 +1,323 @@
+package test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	cryptorand "crypto/rand"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/signature"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// privateKeys is a types.PrivateKeys holding its keys in memory
+type privateKeys struct {
+	onChain  *signature.OnchainPrivateKey
+	offChain *signature.OffchainPrivateKey
+	config   [curve25519.ScalarSize]byte
+}
+
+var _ types.PrivateKeys = (*privateKeys)(nil)
+
+func newPrivateKeys() (*privateKeys, error) {
+	onChain, err := ecdsa.GenerateKey(signature.Curve, cryptorand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	_, offChain, err := ed25519.GenerateKey(cryptorand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	k := privateKeys{
+		(*signature.OnchainPrivateKey)(onChain),
+		(*signature.OffchainPrivateKey)(&offChain),
+		[curve25519.ScalarSize]byte{},
+	}
+	if _, err := cryptorand.Read(k.config[:]); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (k *privateKeys) SignOnChain(msg []byte) ([]byte, error) {
+	return k.onChain.Sign(msg)
+}
+
+func (k *privateKeys) SignOffChain(msg []byte) ([]byte, error) {
+	return k.offChain.Sign(msg)
+}
+
+func (k *privateKeys) ConfigDiffieHellman(base *[curve25519.ScalarSize]byte) (*[curve25519.PointSize]byte, error) {
+	p, err := curve25519.X25519(k.config[:], base[:])
+	if err != nil {
+		return nil, err
+	}
+	var sharedPoint [curve25519.PointSize]byte
+	copy(sharedPoint[:], p)
+	return &sharedPoint, nil
+}
+
+func (k *privateKeys) PublicKeyAddressOnChain() types.OnChainSigningAddress {
+	return k.onChain.Address()
+}
+
+func (k *privateKeys) PublicKeyOffChain() types.OffchainPublicKey {
+	return types.OffchainPublicKey(k.offChain.PublicKey())
+}
+
+func (k *privateKeys) PublicKeyConfig() [curve25519.PointSize]byte {
+	p, err := curve25519.X25519(k.config[:], curve25519.Basepoint)
+	if err != nil {
+		// assertion
+		panic(err)
+	}
+	var rv [curve25519.PointSize]byte
+	copy(rv[:], p)
+	return rv
+}
+
+// database is a types.Database holding its contents in memory
+type database struct {
+	mutex                sync.Mutex
+	states               map[types.ConfigDigest]types.PersistentState
+	config               *types.ContractConfig
+	pendingTransmissions map[types.PendingTransmissionKey]types.PendingTransmission
+}
+
+var _ types.Database = (*database)(nil)
+
+func newDatabase() *database {
+	return &database{
+		states:               map[types.ConfigDigest]types.PersistentState{},
+		pendingTransmissions: map[types.PendingTransmissionKey]types.PendingTransmission{},
+	}
+}
+
+func (db *database) ReadState(_ context.Context, configDigest types.ConfigDigest) (*types.PersistentState, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	state, ok := db.states[configDigest]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (db *database) WriteState(_ context.Context, configDigest types.ConfigDigest, state types.PersistentState) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.states[configDigest] = state
+	return nil
+}
+
+func (db *database) ReadConfig(context.Context) (*types.ContractConfig, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return db.config, nil
+}
+
+func (db *database) WriteConfig(_ context.Context, config types.ContractConfig) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.config = &config
+	return nil
+}
+
+func (db *database) StorePendingTransmission(_ context.Context, key types.PendingTransmissionKey, transmission types.PendingTransmission) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.pendingTransmissions[key] = transmission
+	return nil
+}
+
+func (db *database) PendingTransmissionsWithConfigDigest(_ context.Context, configDigest types.ConfigDigest) (map[types.PendingTransmissionKey]types.PendingTransmission, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	rv := map[types.PendingTransmissionKey]types.PendingTransmission{}
+	for key, transmission := range db.pendingTransmissions {
+		if key.ConfigDigest == configDigest {
+			rv[key] = transmission
+		}
+	}
+	return rv, nil
+}
+
+func (db *database) DeletePendingTransmission(_ context.Context, key types.PendingTransmissionKey) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	delete(db.pendingTransmissions, key)
+	return nil
+}
+
+func (db *database) DeletePendingTransmissionsOlderThan(_ context.Context, t time.Time) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for key, transmission := range db.pendingTransmissions {
+		if transmission.Time.Before(t) {
+			delete(db.pendingTransmissions, key)
+		}
+	}
+	return nil
+}
+
+// Transmission records a call to ContractTransmitter.Transmit
+type Transmission struct {
+	Transmitter  types.OracleID
+	ConfigDigest types.ConfigDigest
+	Epoch        uint32
+	Round        uint8
+	Median       *big.Int
+	Report       []byte
+	// Err is nil iff the report and its signatures are valid
+	Err error
+	// Accepted is true iff the report is valid and newer than the latest
+	// report accepted before
+	Accepted bool
+}
+
+// contract models the on-chain OffchainAggregator. It accepts transmissions
+// with enough valid signatures whose epoch and round are newer than those of
+// the latest accepted transmission.
+type contract struct {
+	mutex         sync.Mutex
+	clock         clock.Clock
+	config        config.SharedConfig
+	signers       signature.EthAddresses
+	latest        *Transmission
+	latestTime    time.Time
+	transmissions []Transmission
+}
+
+func newContract(clock clock.Clock, c config.SharedConfig) *contract {
+	signers := signature.EthAddresses{}
+	for oid, identity := range c.OracleIdentities {
+		signers[identity.OnChainSigningAddress] = types.OracleID(oid)
+	}
+	return &contract{clock: clock, config: c, signers: signers}
+}
+
+func (c *contract) transmit(from types.OracleID, report []byte, rs, ss [][32]byte, vs [32]byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	transmission, err := c.verify(report, rs, ss, vs)
+	transmission.Transmitter = from
+	transmission.Report = report
+	transmission.Err = err
+	transmission.Accepted = err == nil && (c.latest == nil ||
+		c.latest.Epoch < transmission.Epoch ||
+		(c.latest.Epoch == transmission.Epoch && c.latest.Round < transmission.Round))
+	c.transmissions = append(c.transmissions, transmission)
+	if transmission.Accepted {
+		latest := transmission
+		c.latest = &latest
+		c.latestTime = c.clock.Now()
+	}
+}
+
+// verify decodes report and checks its signatures the way the contract does
+func (c *contract) verify(report []byte, rs, ss [][32]byte, vs [32]byte) (Transmission, error) {
+	vals, err := reportTypes.Unpack(report)
+	if err != nil {
+		return Transmission{}, errors.Wrap(err, "could not decode report")
+	}
+	rawReportContext := vals[0].([32]byte)
+	observations := vals[2].([]*big.Int)
+	var transmission Transmission
+	copy(transmission.ConfigDigest[:], rawReportContext[11:27])
+	transmission.Epoch = uint32(new(big.Int).SetBytes(rawReportContext[27:31]).Uint64())
+	transmission.Round = rawReportContext[31]
+	if len(observations) == 0 {
+		return transmission, errors.New("report has no observations")
+	}
+	transmission.Median = observations[len(observations)/2]
+
+	if transmission.ConfigDigest != c.config.ConfigDigest {
+		return transmission, errors.New("wrong configDigest")
+	}
+	if len(observations) <= 2*c.config.F {
+		return transmission, errors.Errorf("report has %d observations, need more than %d",
+			len(observations), 2*c.config.F)
+	}
+	for i := 1; i < len(observations); i++ {
+		if observations[i-1].Cmp(observations[i]) > 0 {
+			return transmission, errors.New("observations are not sorted")
+		}
+	}
+	if len(rs) != len(ss) || len(rs) <= c.config.F {
+		return transmission, errors.Errorf("report has %d signatures, need more than %d",
+			len(rs), c.config.F)
+	}
+	seen := map[types.OracleID]bool{}
+	for i := range rs {
+		sig := append(append(append([]byte{}, rs[i][:]...), ss[i][:]...), vs[i])
+		oid, err := signature.VerifyOnChain(report, sig, c.signers)
+		if err != nil {
+			return transmission, errors.Wrapf(err, "invalid signature #%d", i)
+		}
+		if seen[oid] {
+			return transmission, errors.Errorf("oracle %d signed more than once", oid)
+		}
+		seen[oid] = true
+	}
+	return transmission, nil
+}
+
+// contractTransmitter gives an oracle access to the contract
+type contractTransmitter struct {
+	contract *contract
+	id       types.OracleID
+	address  common.Address
+}
+
+var _ types.ContractTransmitter = contractTransmitter{}
+
+func (t contractTransmitter) Transmit(_ context.Context, report []byte, rs, ss [][32]byte, vs [32]byte) error {
+	t.contract.transmit(t.id, report, rs, ss, vs)
+	return nil
+}
+
+func (t contractTransmitter) LatestTransmissionDetails(context.Context) (
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	latestAnswer types.Observation,
+	latestTimestamp time.Time,
+	err error,
+) {
+	t.contract.mutex.Lock()
+	defer t.contract.mutex.Unlock()
+	if t.contract.latest == nil {
+		return t.contract.config.ConfigDigest, 0, 0, big.NewInt(0), time.Time{}, nil
+	}
+	latest := t.contract.latest
+	return latest.ConfigDigest, latest.Epoch, latest.Round, latest.Median, t.contract.latestTime, nil
+}
+
+func (t contractTransmitter) FromAddress() common.Address {
+	return t.address
+}
+
+func randomAddress() common.Address {
+	k, err := crypto.GenerateKey()
+	if err != nil {
+		// assertion
+		panic(err)
+	}
+	return crypto.PubkeyToAddress(k.PublicKey)
+}
+
+// constantDataSource always observes the same value
+type constantDataSource struct{ value *big.Int }
+
+func (ds constantDataSource) Observe(context.Context) (types.Observation, error) {
+	return new(big.Int).Set(ds.value), nil
+}
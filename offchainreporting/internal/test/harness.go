@@ -0,0 +1,396 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/internal/shim"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"github.com/SeerLink/libocr/subprocesses"
+)
+
+// Scenario describes a run of several in-process oracles
+type Scenario struct {
+	N int
+	F int
+	// Byzantine maps the oracles which deviate from the protocol to their
+	// behavior. Must have at most F entries.
+	Byzantine map[types.OracleID]Behavior
+	// Values holds the value each oracle observes. Defaults to 1000+i for
+	// oracle i if nil.
+	Values []*big.Int
+	// Duration for which the oracles run
+	Duration time.Duration
+	// Logger returns the logger for each oracle. Defaults to discarding all
+	// logs if nil.
+	Logger func(types.OracleID) types.Logger
+	// SpanExporter returns the span exporter for each oracle. Optional, no
+	// spans are recorded if nil.
+	SpanExporter func(types.OracleID) types.SpanExporter
+	// Telemetry returns the queue receiving the telemetry of each oracle.
+	// Optional, no telemetry is sent if nil.
+	Telemetry func(types.OracleID) shim.TelemetryQueue
+	// Simulation makes the scenario run against virtual time. Optional, the
+	// scenario runs in real time if nil. Can't be combined with Network.
+	Simulation *Simulation
+	// Network makes the oracles communicate over a
+	// protocol.ConditionedNetwork. Optional, they use a
+	// protocol.SimpleNetwork if nil. Can't be combined with Simulation.
+	Network *NetworkConditions
+}
+
+// NetworkConditions configures the protocol.ConditionedNetwork of a Scenario
+type NetworkConditions struct {
+	Seed int64
+	// Default applies to all links not listed in Links
+	Default    protocol.LinkConditions
+	Links      map[[2]types.OracleID]protocol.LinkConditions
+	Partitions []protocol.Partition
+}
+
+// Result holds everything that happened on the contract during a run
+type Result struct {
+	Config        config.SharedConfig
+	Scenario      Scenario
+	Transmissions []Transmission
+	// Dropped is the number of messages dropped by the network. Always zero
+	// without Scenario.Network.
+	Dropped uint64
+}
+
+// Run runs scenario until its Duration has passed or ctx is cancelled,
+// whichever happens first. To replay a simulated scenario, pass
+// Result.Scenario to Run again.
+func Run(ctx context.Context, scenario Scenario) (Result, error) {
+	if !(0 <= scenario.F && 3*scenario.F < scenario.N) {
+		return Result{}, errors.Errorf("need 0 <= F < N/3, got N=%d F=%d",
+			scenario.N, scenario.F)
+	}
+	if len(scenario.Byzantine) > scenario.F {
+		return Result{}, errors.Errorf("%d byzantine oracles exceed F=%d",
+			len(scenario.Byzantine), scenario.F)
+	}
+	for id := range scenario.Byzantine {
+		if !(0 <= int(id) && int(id) < scenario.N) {
+			return Result{}, errors.Errorf("byzantine oracle %d out of range", id)
+		}
+	}
+	if scenario.Simulation != nil && scenario.Network != nil {
+		// the conditioned network delivers messages from a goroutine which
+		// the virtual clock doesn't track
+		return Result{}, errors.New("can't simulate a scenario with network conditions")
+	}
+	if scenario.Values == nil {
+		for i := 0; i < scenario.N; i++ {
+			scenario.Values = append(scenario.Values, big.NewInt(int64(1000+i)))
+		}
+	}
+	if len(scenario.Values) != scenario.N {
+		return Result{}, errors.Errorf("need %d values, got %d",
+			scenario.N, len(scenario.Values))
+	}
+	if scenario.Logger == nil {
+		scenario.Logger = func(types.OracleID) types.Logger { return testlogger.Nop{} }
+	}
+
+	keys := make([]*privateKeys, scenario.N)
+	identities := make([]config.OracleIdentity, scenario.N)
+	for i := range keys {
+		k, err := newPrivateKeys()
+		if err != nil {
+			return Result{}, errors.Wrap(err, "could not generate keys")
+		}
+		keys[i] = k
+		identities[i] = config.OracleIdentity{
+			fmt.Sprintf("oracle-%d", i),
+			k.PublicKeyOffChain(),
+			k.PublicKeyAddressOnChain(),
+			randomAddress(),
+		}
+	}
+	randomness := io.Reader(cryptorand.Reader)
+	var sim *simulator
+	clk := clock.Real
+	if scenario.Simulation != nil {
+		// leader selection depends on the shared secret, so it has to be
+		// derived from the seed for replays to be exact
+		randomness = rand.New(rand.NewSource(scenario.Simulation.Seed))
+		sim = newSimulator(*scenario.Simulation)
+		clk = sim.clock
+	}
+	sharedConfig, err := harnessConfig(randomness, identities, scenario.F)
+	if err != nil {
+		return Result{}, err
+	}
+
+	c := newContract(clk, sharedConfig)
+	var net interface {
+		Endpoint(types.OracleID) (protocol.NetworkEndpoint, error)
+	}
+	var conditioned *protocol.ConditionedNetwork
+	if scenario.Network == nil {
+		net = protocol.NewSimpleNetwork(scenario.N)
+	} else {
+		conditioned = protocol.NewConditionedNetwork(
+			scenario.N,
+			clk,
+			scenario.Network.Seed,
+			scenario.Network.Default,
+		)
+		for link, conditions := range scenario.Network.Links {
+			conditioned.SetLinkConditions(link[0], link[1], conditions)
+		}
+		for _, p := range scenario.Network.Partitions {
+			conditioned.SchedulePartition(p)
+		}
+		defer conditioned.Close()
+		net = conditioned
+	}
+	endpoints := make([]protocol.NetworkEndpoint, scenario.N)
+	for i := range endpoints {
+		id := types.OracleID(i)
+		endpoint, err := net.Endpoint(id)
+		if err != nil {
+			return Result{}, err
+		}
+		if sim != nil {
+			endpoint = simulatedEndpoint{endpoint, id, scenario.N, sim}
+		}
+		if behavior, ok := scenario.Byzantine[id]; ok {
+			endpoint = byzantineEndpoint{endpoint, scenario.N, behavior}
+		}
+		endpoints[i] = endpoint
+	}
+
+	var runCtx context.Context
+	var cancel context.CancelFunc
+	if sim == nil {
+		runCtx, cancel = context.WithTimeout(ctx, scenario.Duration)
+	} else {
+		runCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	oracles := subprocesses.Subprocesses{Clock: clk}
+	for i := range endpoints {
+		id := types.OracleID(i)
+		var spanExporter types.SpanExporter
+		if scenario.SpanExporter != nil {
+			spanExporter = scenario.SpanExporter(id)
+		}
+		var telemetrySender protocol.TelemetrySender = protocol.NopTelemetrySender{}
+		if scenario.Telemetry != nil {
+			telemetrySender = shim.MakeTelemetrySender(
+				clk,
+				shim.NewEvidenceStore(10),
+				scenario.Logger(id),
+				scenario.Telemetry(id),
+			)
+		}
+		oracles.Go(func() {
+			protocol.RunOracle(
+				runCtx,
+
+				nil,
+				clk,
+				sharedConfig,
+				contractTransmitter{c, id, identities[id].TransmitAddress},
+				newDatabase(),
+				constantDataSource{scenario.Values[id]},
+				id,
+				keys[id],
+				harnessLocalConfig,
+				scenario.Logger(id),
+				protocol.NewMetrics(nil),
+				endpoints[id],
+				spanExporter,
+				telemetrySender,
+			)
+		})
+	}
+	if sim != nil {
+		sim.run(runCtx, simulationStart.Add(scenario.Duration))
+		cancel()
+	}
+	<-runCtx.Done()
+
+	// Oracles which are still winding down may block on sending to an oracle
+	// which has already exited, so keep draining all endpoints until everyone
+	// is done.
+	chDone := make(chan struct{})
+	var drainers subprocesses.Subprocesses
+	for _, endpoint := range endpoints {
+		chNet := endpoint.Receive()
+		drainers.Go(func() {
+			for {
+				select {
+				case <-chNet:
+				case <-chDone:
+					return
+				}
+			}
+		})
+	}
+	oracles.Wait()
+	close(chDone)
+	drainers.Wait()
+
+	var dropped uint64
+	if conditioned != nil {
+		dropped = conditioned.Dropped()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return Result{
+		sharedConfig,
+		scenario,
+		append([]Transmission{}, c.transmissions...),
+		dropped,
+	}, nil
+}
+
+// harnessConfig returns a config with short timeouts, so that scenarios
+// make progress quickly. It deliberately bypasses the bounds enforced on
+// configs read from the contract.
+func harnessConfig(randomness io.Reader, identities []config.OracleIdentity, f int) (config.SharedConfig, error) {
+	var sharedSecret [config.SharedSecretSize]byte
+	if _, err := io.ReadFull(randomness, sharedSecret[:]); err != nil {
+		return config.SharedConfig{}, errors.Wrap(err, "could not generate shared secret")
+	}
+	var configDigest types.ConfigDigest
+	if _, err := io.ReadFull(randomness, configDigest[:]); err != nil {
+		return config.SharedConfig{}, errors.Wrap(err, "could not generate config digest")
+	}
+	s := make([]int, len(identities))
+	for i := range s {
+		s[i] = 1
+	}
+	return config.SharedConfig{
+		config.PublicConfig{
+			2 * time.Second,
+			1 * time.Second,
+			500 * time.Millisecond,
+			100 * time.Millisecond,
+			0,
+			config.HeartbeatSchedule{},
+			0,
+			observation.RelativeDeviationRule(0),
+			true,
+			500 * time.Millisecond,
+			10,
+			s,
+			identities,
+			f,
+			configDigest,
+		},
+		&sharedSecret,
+	}, nil
+}
+
+var harnessLocalConfig = types.LocalConfig{
+	BlockchainTimeout:                  time.Second,
+	ContractTransmitterTransmitTimeout: time.Second,
+	DatabaseTimeout:                    time.Second,
+	DataSourceTimeout:                  time.Second,
+}
+
+// CheckSafety returns an error if
+//
+// - an honest oracle transmitted an invalid report,
+//
+// - two different valid reports were transmitted for the same epoch and round
+// (the contract only accepts one of them, but both carry the signatures of
+// more than f oracles), or
+//
+// - the contract accepted a report whose median isn't bounded by the values
+// observed by honest oracles.
+//
+// Invalid transmissions by byzantine oracles are rejected by the contract, so
+// they don't count as violations.
+func (r Result) CheckSafety() error {
+	minHonest, maxHonest := r.honestRange()
+	reports := map[[2]uint64][]byte{}
+	for _, t := range r.Transmissions {
+		if t.Err != nil {
+			if _, byzantine := r.Scenario.Byzantine[t.Transmitter]; byzantine {
+				continue
+			}
+			return errors.Wrapf(t.Err, "honest oracle %d transmitted an invalid report for epoch %d round %d",
+				t.Transmitter, t.Epoch, t.Round)
+		}
+		key := [2]uint64{uint64(t.Epoch), uint64(t.Round)}
+		if report, ok := reports[key]; ok && !bytes.Equal(report, t.Report) {
+			return errors.Errorf("conflicting reports for epoch %d round %d",
+				t.Epoch, t.Round)
+		}
+		reports[key] = t.Report
+		if !t.Accepted {
+			continue
+		}
+		if t.Median.Cmp(minHonest) < 0 || t.Median.Cmp(maxHonest) > 0 {
+			return errors.Errorf("median %v for epoch %d round %d lies outside the honest range [%v, %v]",
+				t.Median, t.Epoch, t.Round, minHonest, maxHonest)
+		}
+	}
+	return nil
+}
+
+// CheckLiveness returns an error if the contract accepted fewer than
+// minReports reports.
+func (r Result) CheckLiveness(minReports int) error {
+	accepted := 0
+	for _, t := range r.Transmissions {
+		if t.Accepted {
+			accepted++
+		}
+	}
+	if accepted < minReports {
+		return errors.Errorf("contract accepted %d reports, expected at least %d",
+			accepted, minReports)
+	}
+	return nil
+}
+
+func (r Result) honestRange() (min, max *big.Int) {
+	for i, value := range r.Scenario.Values {
+		if _, ok := r.Scenario.Byzantine[types.OracleID(i)]; ok {
+			continue
+		}
+		if min == nil || value.Cmp(min) < 0 {
+			min = value
+		}
+		if max == nil || value.Cmp(max) > 0 {
+			max = value
+		}
+	}
+	return min, max
+}
+
+var reportTypes = getReportTypes()
+
+func getReportTypes() abi.Arguments {
+	mustNewType := func(t string) abi.Type {
+		result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
+		if err != nil {
+			panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
+		}
+		return result
+	}
+	return abi.Arguments([]abi.Argument{
+		{Name: "rawReportContext", Type: mustNewType("bytes32")},
+		{Name: "rawObservers", Type: mustNewType("bytes32")},
+		{Name: "observations", Type: mustNewType("int192[]")},
+	})
+}
@@ -0,0 +1,325 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/internal/shim"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func runScenario(t *testing.T, scenario Scenario) Result {
+	t.Helper()
+	scenario.Simulation = &Simulation{
+		Seed:     1,
+		MinDelay: time.Millisecond,
+		MaxDelay: 20 * time.Millisecond,
+	}
+	result, err := Run(context.Background(), scenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func checkScenario(t *testing.T, result Result, minReports int) {
+	t.Helper()
+	if err := result.CheckSafety(); err != nil {
+		t.Error(err)
+	}
+	if err := result.CheckLiveness(minReports); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHonest(t *testing.T) {
+	result := runScenario(t, Scenario{N: 4, F: 1, Duration: 30 * time.Second})
+	checkScenario(t, result, 5)
+}
+
+func TestCrashedOracles(t *testing.T) {
+	result := runScenario(t, Scenario{
+		N: 7,
+		F: 2,
+		Byzantine: map[types.OracleID]Behavior{
+			1: Silent(),
+			4: Silent(),
+		},
+		Duration: 60 * time.Second,
+	})
+	checkScenario(t, result, 5)
+}
+
+func TestEquivocatingLeader(t *testing.T) {
+	result := runScenario(t, Scenario{
+		N:         4,
+		F:         1,
+		Byzantine: map[types.OracleID]Behavior{0: EquivocatingLeader(1)},
+		Duration:  60 * time.Second,
+	})
+	checkScenario(t, result, 5)
+}
+
+func TestInvalidSignatures(t *testing.T) {
+	result := runScenario(t, Scenario{
+		N:         4,
+		F:         1,
+		Byzantine: map[types.OracleID]Behavior{0: InvalidSignatures()},
+		Duration:  60 * time.Second,
+	})
+	checkScenario(t, result, 5)
+}
+
+func TestUnsortedReportReqs(t *testing.T) {
+	result := runScenario(t, Scenario{
+		N:         4,
+		F:         1,
+		Byzantine: map[types.OracleID]Behavior{0: UnsortedReportReqs()},
+		Duration:  60 * time.Second,
+	})
+	checkScenario(t, result, 5)
+}
+
+func TestReplayOldFinals(t *testing.T) {
+	result := runScenario(t, Scenario{
+		N:         4,
+		F:         1,
+		Byzantine: map[types.OracleID]Behavior{0: ReplayOldFinals()},
+		Duration:  60 * time.Second,
+	})
+	checkScenario(t, result, 5)
+}
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	scenario := Scenario{
+		N:         4,
+		F:         1,
+		Byzantine: map[types.OracleID]Behavior{2: Silent()},
+		Duration:  30 * time.Second,
+	}
+	first := runScenario(t, scenario)
+	if len(first.Transmissions) == 0 {
+		t.Fatal("no transmissions")
+	}
+	second, err := Run(context.Background(), first.Scenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first.Transmissions, second.Transmissions) {
+		t.Errorf("replay with the same seed produced different transmissions:\n%+v\n%+v",
+			first.Transmissions, second.Transmissions)
+	}
+}
+
+// spanCollector collects the spans of all oracles in a scenario
+type spanCollector struct {
+	mutex sync.Mutex
+	spans []types.Span
+}
+
+func (c *spanCollector) ExportSpan(span types.Span) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.spans = append(c.spans, span)
+}
+
+func TestTracing(t *testing.T) {
+	collector := &spanCollector{}
+	// Oracle 0's silence makes epochs it leads abort
+	runScenario(t, Scenario{
+		N:            4,
+		F:            1,
+		Byzantine:    map[types.OracleID]Behavior{0: Silent()},
+		Duration:     30 * time.Second,
+		SpanExporter: func(types.OracleID) types.SpanExporter { return collector },
+	})
+
+	type round struct {
+		configDigest string
+		epoch        uint32
+		round        uint8
+	}
+	spans := map[types.SpanID]types.Span{}
+	traceIDs := map[round]types.TraceID{}
+	roundSpans := map[round]map[string]bool{}
+	aborted := 0
+	for _, s := range collector.spans {
+		if _, ok := spans[s.SpanID]; ok {
+			t.Errorf("span %v (%s) exported more than once", s.SpanID, s.Name)
+		}
+		spans[s.SpanID] = s
+		r := round{
+			s.Attributes["configDigest"].(string),
+			s.Attributes["epoch"].(uint32),
+			s.Attributes["round"].(uint8),
+		}
+		if traceID, ok := traceIDs[r]; ok && traceID != s.TraceID {
+			t.Errorf("round %v has several trace IDs: %v and %v", r, traceID, s.TraceID)
+		}
+		traceIDs[r] = s.TraceID
+		if roundSpans[r] == nil {
+			roundSpans[r] = map[string]bool{}
+		}
+		roundSpans[r][fmt.Sprintf("%s/%v", s.Name, s.Attributes["oid"])] = true
+		if s.Attributes["aborted"] == true {
+			aborted++
+		}
+	}
+	for _, s := range spans {
+		if s.ParentSpanID == (types.SpanID{}) {
+			continue
+		}
+		parent, ok := spans[s.ParentSpanID]
+		if !ok {
+			t.Errorf("parent of span %v (%s) wasn't exported", s.SpanID, s.Name)
+			continue
+		}
+		if parent.TraceID != s.TraceID || parent.Attributes["oid"] != s.Attributes["oid"] {
+			t.Errorf("span %v (%s) has a parent from another trace or oracle", s.SpanID, s.Name)
+		}
+	}
+
+	// Some round must have been traced by its leader and all honest followers
+	complete := false
+	for _, names := range roundSpans {
+		leaders, followers := 0, 0
+		for name := range names {
+			switch {
+			case strings.HasPrefix(name, "leaderRound/"):
+				leaders++
+			case strings.HasPrefix(name, "followerRound/"):
+				followers++
+			}
+		}
+		if leaders == 1 && followers >= 3 {
+			complete = true
+		}
+	}
+	if !complete {
+		t.Errorf("no round was traced by its leader and its followers")
+	}
+	if aborted == 0 {
+		t.Errorf("no aborted rounds were traced")
+	}
+}
+
+// telemetryKind returns the kind of a telemetry event of a round or epoch,
+// and the epoch and round it belongs to. Epoch-level events have round 0.
+func telemetryKind(w *protobuf.TelemetryWrapper) (string, uint64, uint64) {
+	switch t := w.Wrapped.(type) {
+	case *protobuf.TelemetryWrapper_EpochChanged:
+		return "EpochChanged", t.EpochChanged.Epoch, 0
+	case *protobuf.TelemetryWrapper_RoundStarted:
+		return "RoundStarted", t.RoundStarted.Epoch, t.RoundStarted.Round
+	case *protobuf.TelemetryWrapper_ObservationMade:
+		return "ObservationMade", t.ObservationMade.Epoch, t.ObservationMade.Round
+	case *protobuf.TelemetryWrapper_ReportGenerated:
+		return "ReportGenerated", t.ReportGenerated.Epoch, t.ReportGenerated.Round
+	case *protobuf.TelemetryWrapper_ShouldReportDecision:
+		return "ShouldReportDecision", t.ShouldReportDecision.Epoch, t.ShouldReportDecision.Round
+	case *protobuf.TelemetryWrapper_TransmissionScheduled:
+		return "TransmissionScheduled", t.TransmissionScheduled.Epoch, t.TransmissionScheduled.Round
+	case *protobuf.TelemetryWrapper_TransmissionSent:
+		return "TransmissionSent", t.TransmissionSent.Epoch, t.TransmissionSent.Round
+	case *protobuf.TelemetryWrapper_TransmissionSkipped:
+		return "TransmissionSkipped", t.TransmissionSkipped.Epoch, t.TransmissionSkipped.Round
+	default:
+		return fmt.Sprintf("%T", t), 0, 0
+	}
+}
+
+func TestTelemetry(t *testing.T) {
+	queue := shim.NewTelemetryQueue(100000)
+	runScenario(t, Scenario{
+		N:        4,
+		F:        1,
+		Duration: 10 * time.Second,
+		Telemetry: func(id types.OracleID) shim.TelemetryQueue {
+			return queue.WithSource(shim.TelemetrySource{OracleID: id})
+		},
+	})
+	if queue.Dropped() != 0 {
+		t.Fatalf("dropped %d telemetry events", queue.Dropped())
+	}
+
+	// The lifecycle of the rounds of epoch 2, as seen by oracle 1. Epoch 1
+	// starts without an epoch change.
+	const epoch = 2
+	epochChanged := false
+	rounds := map[uint64][]string{}
+	for len(queue.Chan()) > 0 {
+		ev := <-queue.Chan()
+		if ev.Source.OracleID != 1 {
+			continue
+		}
+		kind, e, r := telemetryKind(ev.Telemetry)
+		if e != epoch {
+			continue
+		}
+		if kind == "EpochChanged" {
+			epochChanged = true
+			continue
+		}
+		if !epochChanged {
+			t.Errorf("%s of round %d came before EpochChanged", kind, r)
+		}
+		rounds[r] = append(rounds[r], kind)
+	}
+	if !epochChanged {
+		t.Fatalf("no EpochChanged for epoch %d", epoch)
+	}
+
+	lifecycle := func(outcome string) []string {
+		return []string{
+			"RoundStarted",
+			"ObservationMade",
+			"ShouldReportDecision",
+			"ReportGenerated",
+			"TransmissionScheduled",
+			outcome,
+		}
+	}
+	for _, outcome := range []string{"TransmissionSent", "TransmissionSkipped"} {
+		found := false
+		for _, kinds := range rounds {
+			if reflect.DeepEqual(kinds, lifecycle(outcome)) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no round of epoch %d went through %v, got %v", epoch, lifecycle(outcome), rounds)
+		}
+	}
+}
+
+func TestCheckSafetyIgnoresRejectedByzantineTransmissions(t *testing.T) {
+	result := runScenario(t, Scenario{N: 4, F: 1, Duration: 10 * time.Second})
+	if len(result.Transmissions) == 0 {
+		t.Fatal("no transmissions")
+	}
+	forged := result.Transmissions[0]
+	forged.Transmitter = 3
+	forged.Report = append([]byte{}, forged.Report...)
+	forged.Report[len(forged.Report)-1] ^= 1
+	forged.Err = errInvalidForTest
+	forged.Accepted = false
+	result.Transmissions = append(result.Transmissions, forged)
+
+	result.Scenario.Byzantine = map[types.OracleID]Behavior{3: Silent()}
+	if err := result.CheckSafety(); err != nil {
+		t.Errorf("rejected byzantine transmission must not violate safety: %v", err)
+	}
+	result.Scenario.Byzantine = nil
+	if err := result.CheckSafety(); err == nil {
+		t.Errorf("invalid transmission by an honest oracle must violate safety")
+	}
+}
+
+var errInvalidForTest = errors.New("invalid for test")
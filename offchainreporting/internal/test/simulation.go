@@ -0,0 +1,182 @@
+package test
+
+import (
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// Simulation makes a Scenario run against virtual time, with message delays
+// (and hence reorderings) derived from Seed. Time only advances once the
+// oracles are idle, so scenarios typically run several times faster than they
+// would in real time, and running a scenario again with the same Seed replays
+// the same message delays and timer firings.
+//
+// The oracles are idle once all their goroutines are blocked waiting for a
+// timer of the virtual clock or for a message, which the virtual clock keeps
+// track of (see clock.Tracker), so that only the simulator can wake them. The
+// simulator fires one timer or delivers one message at a time, and waits for
+// the oracles to be idle in between, so that their reactions to simultaneous
+// events don't race. Replays are exact as long as the oracles only depend on
+// virtual time, the messages they receive and Seed.
+type Simulation struct {
+	Seed int64
+	// Message delays are drawn from [MinDelay, MaxDelay]
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// simulationStart is the virtual time at which all simulations start
+var simulationStart = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type simulator struct {
+	simulation Simulation
+	clock      *clock.Virtual
+
+	mutex      sync.Mutex
+	deliveries deliveryHeap
+	linkSeqs   map[[2]types.OracleID]uint64
+}
+
+func newSimulator(simulation Simulation) *simulator {
+	if simulation.MaxDelay < simulation.MinDelay {
+		simulation.MaxDelay = simulation.MinDelay
+	}
+	return &simulator{
+		simulation: simulation,
+		clock:      clock.NewVirtual(simulationStart),
+		linkSeqs:   map[[2]types.OracleID]uint64{},
+	}
+}
+
+// delay returns the delay of the seq'th message from sender to receiver. It
+// is a function of the seed and the message's position on its link only, so
+// that it doesn't depend on how goroutines on different links interleave.
+func (sim *simulator) delay(sender, receiver types.OracleID, seq uint64) time.Duration {
+	spread := uint64(sim.simulation.MaxDelay - sim.simulation.MinDelay)
+	if spread == 0 {
+		return sim.simulation.MinDelay
+	}
+	h := fnv.New64a()
+	var buf [8 + 2 + 8]byte
+	binary.BigEndian.PutUint64(buf[0:], uint64(sim.simulation.Seed))
+	buf[8], buf[9] = byte(sender), byte(receiver)
+	binary.BigEndian.PutUint64(buf[10:], seq)
+	h.Write(buf[:])
+	return sim.simulation.MinDelay + time.Duration(h.Sum64()%(spread+1))
+}
+
+func (sim *simulator) send(via protocol.NetworkEndpoint, sender, receiver types.OracleID, msg protocol.Message) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	link := [2]types.OracleID{sender, receiver}
+	seq := sim.linkSeqs[link]
+	sim.linkSeqs[link]++
+	heap.Push(&sim.deliveries, delivery{
+		sim.clock.Now().Add(sim.delay(sender, receiver, seq)),
+		sender,
+		receiver,
+		seq,
+		msg,
+		via,
+	})
+}
+
+// run advances virtual time from event to event, until it reaches end or ctx
+// is cancelled. Timers which expire at the same time as a message is due fire
+// before the message is delivered.
+func (sim *simulator) run(ctx context.Context, end time.Time) {
+	for sim.clock.AwaitIdle(ctx) {
+		deadline, timerPending := sim.clock.NextDeadline()
+		sim.mutex.Lock()
+		deliver := len(sim.deliveries) != 0 &&
+			(!timerPending || sim.deliveries[0].at.Before(deadline))
+		var d delivery
+		if deliver {
+			d = heap.Pop(&sim.deliveries).(delivery)
+		}
+		sim.mutex.Unlock()
+
+		switch {
+		case deliver && !d.at.After(end):
+			sim.clock.AdvanceTo(d.at)
+			// wakes up the receiver's oracle, which receives the message from
+			// the underlying endpoint
+			sim.clock.Wake()
+			d.via.SendTo(d.msg, d.receiver)
+		case !deliver && timerPending && !deadline.After(end):
+			sim.clock.Step()
+		default:
+			sim.clock.AdvanceTo(end)
+			return
+		}
+	}
+}
+
+// simulatedEndpoint hands all messages sent through it to the simulator,
+// which delivers them through the underlying endpoint once they're due
+type simulatedEndpoint struct {
+	protocol.NetworkEndpoint
+	id  types.OracleID
+	n   int
+	sim *simulator
+}
+
+var _ protocol.NetworkEndpoint = simulatedEndpoint{}
+
+func (s simulatedEndpoint) SendTo(msg protocol.Message, to types.OracleID) {
+	s.sim.send(s.NetworkEndpoint, s.id, to, msg)
+}
+
+func (s simulatedEndpoint) Broadcast(msg protocol.Message) {
+	for to := 0; to < s.n; to++ {
+		s.SendTo(msg, types.OracleID(to))
+	}
+}
+
+type delivery struct {
+	at       time.Time
+	sender   types.OracleID
+	receiver types.OracleID
+	seq      uint64
+	msg      protocol.Message
+	via      protocol.NetworkEndpoint
+}
+
+// deliveryHeap implements heap.Interface, ordered by due time, then link,
+// then position on the link
+type deliveryHeap []delivery
+
+func (h deliveryHeap) Len() int { return len(h) }
+
+func (h deliveryHeap) Less(i, j int) bool {
+	if !h[i].at.Equal(h[j].at) {
+		return h[i].at.Before(h[j].at)
+	}
+	if h[i].sender != h[j].sender {
+		return h[i].sender < h[j].sender
+	}
+	if h[i].receiver != h[j].receiver {
+		return h[i].receiver < h[j].receiver
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h deliveryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *deliveryHeap) Push(x interface{}) { *h = append(*h, x.(delivery)) }
+
+func (h *deliveryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,16 @@
+// Package testlogger provides loggers for tests. It only depends on types, so
+// that the internal tests of any package can use it, unlike package test.
+package testlogger
+
+import "github.com/SeerLink/libocr/offchainreporting/types"
+
+// Nop discards everything
+type Nop struct{}
+
+var _ types.Logger = Nop{}
+
+func (Nop) Trace(string, types.LogFields) {}
+func (Nop) Debug(string, types.LogFields) {}
+func (Nop) Info(string, types.LogFields)  {}
+func (Nop) Warn(string, types.LogFields)  {}
+func (Nop) Error(string, types.LogFields) {}
@@ -0,0 +1,355 @@
+package trace
+
+import (
+	"container/heap"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// Capacity of the buffer between the oracle and the goroutine writing the
+// trace
+const recorderBufferCapacity = 1000
+
+// Recorder writes a trace of everything an oracle sends, receives and waits
+// for. Messages are recorded by wrapping the oracle's protocol.NetworkEndpoint,
+// timer firings by wrapping its clock.Clock.
+//
+// Events are handed to a separate goroutine for writing, so that a slow writer
+// doesn't hold up the oracle. If the writer falls too far behind, events are
+// dropped and counted, and the trace can no longer be replayed faithfully. If
+// writing fails, the Recorder logs the error and stops recording, without
+// affecting the oracle.
+type Recorder struct {
+	clock  clock.Clock
+	logger types.Logger
+
+	w        io.Writer
+	chEvents chan *protobuf.TraceEvent
+	chDone   chan struct{}
+	// chWriterDone is closed once the writer goroutine exits
+	chWriterDone chan struct{}
+	closeOnce    sync.Once
+
+	mutex   sync.Mutex
+	dropped uint64
+}
+
+// NewRecorder returns a Recorder which writes to w, and timestamps events
+// using clock. Close must be called to flush the trace and release the
+// Recorder's goroutine.
+func NewRecorder(w io.Writer, clock clock.Clock, logger types.Logger) *Recorder {
+	r := &Recorder{
+		clock:        clock,
+		logger:       logger,
+		w:            w,
+		chEvents:     make(chan *protobuf.TraceEvent, recorderBufferCapacity),
+		chDone:       make(chan struct{}),
+		chWriterDone: make(chan struct{}),
+	}
+	go r.runWriter()
+	return r
+}
+
+// Close writes the events which are still buffered, and stops recording.
+// Events recorded after Close are dropped. Can be called multiple times.
+func (r *Recorder) Close() {
+	r.closeOnce.Do(func() { close(r.chDone) })
+	<-r.chWriterDone
+}
+
+// Dropped returns the number of events dropped so far because the writer fell
+// behind
+func (r *Recorder) Dropped() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.dropped
+}
+
+func (r *Recorder) record(event *protobuf.TraceEvent) {
+	event.Time = uint64(r.clock.Now().UnixNano())
+	select {
+	case <-r.chDone:
+		return
+	default:
+	}
+	select {
+	case r.chEvents <- event:
+	default:
+		r.mutex.Lock()
+		r.dropped++
+		r.mutex.Unlock()
+	}
+}
+
+// runWriter writes the recorded events to r.w until the Recorder is closed
+func (r *Recorder) runWriter() {
+	defer close(r.chWriterDone)
+	failed := false
+	reportedDropped := uint64(0)
+	write := func(event *protobuf.TraceEvent) {
+		if dropped := r.Dropped(); dropped != reportedDropped {
+			r.logger.Warn("Recorder: writer fell behind, dropped trace events", types.LogFields{
+				"dropped": dropped,
+			})
+			reportedDropped = dropped
+		}
+		if failed {
+			return
+		}
+		if err := writeEvent(r.w, event); err != nil {
+			failed = true
+			r.logger.Error("Recorder: error while writing trace, not recording anymore", types.LogFields{
+				"error": err,
+			})
+		}
+	}
+	for {
+		select {
+		case event := <-r.chEvents:
+			write(event)
+		case <-r.chDone:
+			for {
+				select {
+				case event := <-r.chEvents:
+					write(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *Recorder) wrap(msg protocol.Message) *protobuf.MessageWrapper {
+	_, pbm, err := serialization.Serialize(msg)
+	if err != nil {
+		r.logger.Error("Recorder: could not serialize message", types.LogFields{
+			"error":   err,
+			"message": msg,
+		})
+		return nil
+	}
+	return pbm
+}
+
+// Start begins a new segment of the trace, for a protocol instance with the
+// given config digest and oracle id
+func (r *Recorder) Start(configDigest types.ConfigDigest, id types.OracleID) {
+	r.record(&protobuf.TraceEvent{Event: &protobuf.TraceEvent_Started{&protobuf.TraceStarted{
+		ConfigDigest: configDigest[:],
+		OracleID:     uint32(id),
+	}}})
+}
+
+// Endpoint returns a protocol.NetworkEndpoint which records all messages sent
+// and received through endpoint. Received messages are forwarded until ctx is
+// cancelled.
+func (r *Recorder) Endpoint(ctx context.Context, endpoint protocol.NetworkEndpoint) protocol.NetworkEndpoint {
+	chReceive := make(chan protocol.MessageWithSender)
+	go func() {
+		chIn := endpoint.Receive()
+		chDone := ctx.Done()
+		for {
+			select {
+			case msg, ok := <-chIn:
+				if !ok {
+					return
+				}
+				r.record(&protobuf.TraceEvent{Event: &protobuf.TraceEvent_Received{&protobuf.TraceMessageReceived{
+					Msg:    r.wrap(msg.Msg),
+					Sender: uint32(msg.Sender),
+				}}})
+				select {
+				case chReceive <- msg:
+				case <-chDone:
+					return
+				}
+			case <-chDone:
+				return
+			}
+		}
+	}()
+	return recordingEndpoint{endpoint, r, chReceive}
+}
+
+type recordingEndpoint struct {
+	protocol.NetworkEndpoint
+	recorder  *Recorder
+	chReceive <-chan protocol.MessageWithSender
+}
+
+var _ protocol.NetworkEndpoint = recordingEndpoint{}
+
+func (e recordingEndpoint) SendTo(msg protocol.Message, to types.OracleID) {
+	e.recorder.record(&protobuf.TraceEvent{Event: &protobuf.TraceEvent_Sent{&protobuf.TraceMessageSent{
+		Msg:      e.recorder.wrap(msg),
+		Receiver: uint32(to),
+	}}})
+	e.NetworkEndpoint.SendTo(msg, to)
+}
+
+func (e recordingEndpoint) Broadcast(msg protocol.Message) {
+	e.recorder.record(&protobuf.TraceEvent{Event: &protobuf.TraceEvent_Broadcast{&protobuf.TraceMessageBroadcast{
+		Msg: e.recorder.wrap(msg),
+	}}})
+	e.NetworkEndpoint.Broadcast(msg)
+}
+
+func (e recordingEndpoint) Receive() <-chan protocol.MessageWithSender {
+	return e.chReceive
+}
+
+// Clock returns a clock.Clock which records the firing of all timers created
+// through clock.After and clock.NewTimer. Timers are kept in a single queue,
+// which one goroutine serves while any of them are pending.
+func (r *Recorder) Clock(clock clock.Clock) clock.Clock {
+	return &recordingClock{clock, r, sync.Mutex{}, nil, 0, false, make(chan struct{}, 1)}
+}
+
+type recordingClock struct {
+	clock.Clock
+	recorder *Recorder
+
+	mutex   sync.Mutex
+	timers  recordedTimerHeap
+	seq     uint64
+	running bool
+	// chWake tells the running goroutine that the earliest timer changed
+	chWake chan struct{}
+}
+
+func (c *recordingClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+func (c *recordingClock) NewTimer(d time.Duration) clock.Timer {
+	if d < 0 {
+		d = 0
+	}
+	t := &recordedTimer{c, d, c.Clock.Now().Add(d), 0, make(chan time.Time, 1), -1}
+
+	c.mutex.Lock()
+	t.seq = c.seq
+	c.seq++
+	heap.Push(&c.timers, t)
+	isEarliest := c.timers[0] == t
+	start := !c.running
+	c.running = true
+	c.mutex.Unlock()
+
+	if start {
+		go c.run()
+	} else if isEarliest {
+		select {
+		case c.chWake <- struct{}{}:
+		default:
+		}
+	}
+	return t
+}
+
+// run fires the timers as they expire, and exits once none are pending
+func (c *recordingClock) run() {
+	for {
+		c.mutex.Lock()
+		if len(c.timers) == 0 {
+			c.running = false
+			c.mutex.Unlock()
+			return
+		}
+		deadline := c.timers[0].deadline
+		c.mutex.Unlock()
+
+		timer := c.Clock.NewTimer(deadline.Sub(c.Clock.Now()))
+		select {
+		case now := <-timer.C():
+			for _, t := range c.expired(now) {
+				c.recorder.record(&protobuf.TraceEvent{Event: &protobuf.TraceEvent_TimerFired{&protobuf.TraceTimerFired{
+					Duration: uint64(t.d),
+				}}})
+				t.ch <- now
+			}
+		case <-c.chWake:
+		}
+		timer.Stop()
+	}
+}
+
+// expired removes the timers which expired by now from the queue, and returns
+// them in the order in which they must fire
+func (c *recordingClock) expired(now time.Time) []*recordedTimer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var expired []*recordedTimer
+	for len(c.timers) != 0 && !c.timers[0].deadline.After(now) {
+		expired = append(expired, heap.Pop(&c.timers).(*recordedTimer))
+	}
+	return expired
+}
+
+type recordedTimer struct {
+	clock    *recordingClock
+	d        time.Duration
+	deadline time.Time
+	seq      uint64
+	ch       chan time.Time
+	// index in clock.timers, or -1 if the timer isn't pending. Guarded by
+	// clock.mutex.
+	index int
+}
+
+func (t *recordedTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *recordedTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	if t.index < 0 {
+		return false
+	}
+	heap.Remove(&t.clock.timers, t.index)
+	return true
+}
+
+// recordedTimerHeap implements heap.Interface, ordered by deadline and seq
+type recordedTimerHeap []*recordedTimer
+
+func (h recordedTimerHeap) Len() int { return len(h) }
+
+func (h recordedTimerHeap) Less(i, j int) bool {
+	if !h[i].deadline.Equal(h[j].deadline) {
+		return h[i].deadline.Before(h[j].deadline)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h recordedTimerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *recordedTimerHeap) Push(x interface{}) {
+	t := x.(*recordedTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *recordedTimerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	x.index = -1
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,146 @@
+package trace
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func TestRecorderWritesEventsOnClose(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, clock.NewVirtual(time.Unix(1, 0)), testlogger.Nop{})
+	r.Start(types.ConfigDigest{1}, 2)
+	r.Start(types.ConfigDigest{3}, 4)
+	r.Close()
+	r.Start(types.ConfigDigest{5}, 6)
+	r.Close()
+
+	traces, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(traces) != 2 || traces[0].ConfigDigest != (types.ConfigDigest{1}) ||
+		traces[1].OracleID != 4 || !traces[1].Start.Equal(time.Unix(1, 0)) {
+		t.Errorf("read back %v", traces)
+	}
+}
+
+// blockingWriter blocks all writes until chUnblock is closed
+type blockingWriter struct {
+	w         io.Writer
+	chUnblock chan struct{}
+}
+
+func (w blockingWriter) Write(b []byte) (int, error) {
+	<-w.chUnblock
+	return w.w.Write(b)
+}
+
+func TestRecorderDropsEventsWhenWriterFallsBehind(t *testing.T) {
+	var buf bytes.Buffer
+	chUnblock := make(chan struct{})
+	r := NewRecorder(blockingWriter{&buf, chUnblock}, clock.NewVirtual(time.Unix(0, 0)), testlogger.Nop{})
+
+	// One event may be taken by the writer goroutine, the others must fit
+	// into the buffer or be dropped, without blocking
+	const count = recorderBufferCapacity + 10
+	chRecorded := make(chan struct{})
+	go func() {
+		for i := 0; i < count; i++ {
+			r.Start(types.ConfigDigest{}, 0)
+		}
+		close(chRecorded)
+	}()
+	select {
+	case <-chRecorded:
+	case <-time.After(10 * time.Second):
+		t.Fatal("recording blocked on the writer")
+	}
+	dropped := r.Dropped()
+	if dropped < count-recorderBufferCapacity-1 {
+		t.Errorf("dropped %d events, expected at least %d", dropped, count-recorderBufferCapacity-1)
+	}
+
+	close(chUnblock)
+	r.Close()
+	events, err := ReadEvents(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(len(events))+dropped != count {
+		t.Errorf("wrote %d events and dropped %d, expected %d in total", len(events), dropped, count)
+	}
+}
+
+func TestRecordingClockRecordsTimers(t *testing.T) {
+	var buf bytes.Buffer
+	v := clock.NewVirtual(time.Unix(0, 0))
+	r := NewRecorder(&buf, v, testlogger.Nop{})
+	c := r.Clock(v)
+
+	chLate := c.After(2 * time.Second)
+	chEarly := c.After(time.Second)
+	stopped := c.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Fatal("Stop() on a pending timer must return true")
+	}
+
+	v.Advance(time.Second)
+	select {
+	case <-chEarly:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timer didn't fire")
+	}
+	select {
+	case <-chLate:
+		t.Fatal("timer fired early")
+	default:
+	}
+	v.Advance(time.Second)
+	select {
+	case <-chLate:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timer didn't fire")
+	}
+	select {
+	case <-stopped.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+
+	// Once no timers are pending, the clock must not keep a goroutine around
+	rc := c.(*recordingClock)
+	for i := 0; ; i++ {
+		rc.mutex.Lock()
+		running := rc.running
+		rc.mutex.Unlock()
+		if !running {
+			break
+		}
+		if i == 1000 {
+			t.Fatal("goroutine serving the timers didn't exit")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	r.Close()
+	events, err := ReadEvents(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fired []time.Duration
+	for _, event := range events {
+		if e, ok := event.Event.(*protobuf.TraceEvent_TimerFired); ok {
+			fired = append(fired, time.Duration(e.TimerFired.Duration))
+		}
+	}
+	if len(fired) != 2 || fired[0] != time.Second || fired[1] != 2*time.Second {
+		t.Errorf("recorded timers firing %v, expected [1s 2s]", fired)
+	}
+}
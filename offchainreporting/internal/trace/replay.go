@@ -0,0 +1,436 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/clock"
+	"github.com/SeerLink/libocr/offchainreporting/internal/config"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Sent is a message sent by an oracle, either in a recorded trace or during a
+// replay
+type Sent struct {
+	Time time.Time
+	// Receiver is nil for broadcasts
+	Receiver *types.OracleID
+	Msg      protocol.Message
+}
+
+func (s Sent) String() string {
+	to := "all"
+	if s.Receiver != nil {
+		to = fmt.Sprint(*s.Receiver)
+	}
+	return fmt.Sprintf("%v %T to %v", s.Time.Format(time.RFC3339Nano), s.Msg, to)
+}
+
+// ReplayArgs holds everything Replay needs to run the oracle, apart from the
+// trace itself.
+type ReplayArgs struct {
+	// Config must be the config of the recorded protocol instance
+	Config config.SharedConfig
+	// ContractTransmitter is optional. By default, transmissions are
+	// discarded, and the contract appears to have never received a report.
+	ContractTransmitter types.ContractTransmitter
+	Database            types.Database
+	// Datasource is optional. By default, the oracle observes the values it
+	// sent in its recorded observe messages, in order.
+	Datasource  types.DataSource
+	LocalConfig types.LocalConfig
+	Logger      types.Logger
+	PrivateKeys types.PrivateKeys
+	// Settle is the real time for which the oracle must have been quiet before
+	// the next event of the trace is replayed. Defaults to 10ms.
+	Settle time.Duration
+}
+
+func deserialize(pbm *protobuf.MessageWrapper) (protocol.Message, error) {
+	b, err := proto.Marshal(pbm)
+	if err != nil {
+		return nil, err
+	}
+	msg, _, err := serialization.Deserialize(b)
+	return msg, err
+}
+
+// Recorded returns the messages the oracle sent in trace
+func Recorded(trace Trace) ([]Sent, error) {
+	var sent []Sent
+	for i, event := range trace.Events {
+		var pbm *protobuf.MessageWrapper
+		var receiver *types.OracleID
+		switch e := event.Event.(type) {
+		case *protobuf.TraceEvent_Sent:
+			pbm = e.Sent.Msg
+			to := types.OracleID(e.Sent.Receiver)
+			receiver = &to
+		case *protobuf.TraceEvent_Broadcast:
+			pbm = e.Broadcast.Msg
+		default:
+			continue
+		}
+		msg, err := deserialize(pbm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not deserialize message of event #%d", i)
+		}
+		sent = append(sent, Sent{eventTime(event), receiver, msg})
+	}
+	return sent, nil
+}
+
+// FirstDivergence returns the index of the first message at which replayed
+// differs from recorded in type, receiver, epoch or round, or false if there
+// is none. Message contents are not compared, since signatures may be
+// randomized.
+func FirstDivergence(recorded, replayed []Sent) (int, bool) {
+	for i := range recorded {
+		if i >= len(replayed) {
+			return i, true
+		}
+		a, b := recorded[i], replayed[i]
+		if fmt.Sprintf("%T", a.Msg) != fmt.Sprintf("%T", b.Msg) ||
+			(a.Receiver == nil) != (b.Receiver == nil) ||
+			(a.Receiver != nil && *a.Receiver != *b.Receiver) {
+			return i, true
+		}
+		ea, ra := epochRound(a.Msg)
+		eb, rb := epochRound(b.Msg)
+		if ea != eb || ra != rb {
+			return i, true
+		}
+	}
+	if len(replayed) > len(recorded) {
+		return len(recorded), true
+	}
+	return 0, false
+}
+
+func epochRound(msg protocol.Message) (uint32, uint8) {
+	switch m := msg.(type) {
+	case protocol.MessageNewEpoch:
+		return m.Epoch, 0
+	case protocol.MessageObserveReq:
+		return m.Epoch, m.Round
+	case protocol.MessageObserve:
+		return m.Epoch, m.Round
+	case protocol.MessageReportReq:
+		return m.Epoch, m.Round
+	case protocol.MessageReport:
+		return m.Epoch, m.Round
+	case protocol.MessageFinal:
+		return m.Epoch, m.Round
+	case protocol.MessageFinalEcho:
+		return m.Epoch, m.Round
+	case protocol.MessageReportReqDigest:
+		return m.Epoch, m.Round
+	case protocol.MessageReportReqConflict:
+		return m.ReportReq.Epoch, m.ReportReq.Round
+	}
+	return 0, 0
+}
+
+// Replay runs a single oracle against virtual time, feeding it the messages
+// it received in trace at the times it received them, and returns the
+// messages it sends. The oracle's timers fire when the trace records a timer
+// of the same duration firing, so that they interleave with the received
+// messages as they did in the recording. (Timers of the same duration fire in
+// the order they were created.) Timeouts of contexts aren't recorded, and
+// expire in virtual time. The replay runs as fast as the oracle can process
+// the trace.
+//
+// Replay stops after the last event of the trace, or when ctx is cancelled.
+func Replay(ctx context.Context, trace Trace, args ReplayArgs) ([]Sent, error) {
+	if args.Settle == 0 {
+		args.Settle = 10 * time.Millisecond
+	}
+	if args.Datasource == nil {
+		recorded, err := Recorded(trace)
+		if err != nil {
+			return nil, err
+		}
+		args.Datasource = newRecordedDataSource(recorded)
+	}
+	if args.ContractTransmitter == nil {
+		args.ContractTransmitter = discardingTransmitter{args.Config.ConfigDigest}
+	}
+
+	var received []protocol.MessageWithSender
+	for i, event := range trace.Events {
+		if e, ok := event.Event.(*protobuf.TraceEvent_Received); ok {
+			msg, err := deserialize(e.Received.Msg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not deserialize message of event #%d", i)
+			}
+			received = append(received, protocol.MessageWithSender{msg, types.OracleID(e.Received.Sender)})
+		}
+	}
+
+	r := &replayer{
+		clock:     clock.NewVirtual(trace.Start),
+		chReceive: make(chan protocol.MessageWithSender, len(received)),
+	}
+	quiet := activityClock{r}
+
+	oracleCtx, oracleCancel := context.WithCancel(ctx)
+	defer oracleCancel()
+	chOracleDone := make(chan struct{})
+	go func() {
+		defer close(chOracleDone)
+		protocol.RunOracle(
+			oracleCtx,
+
+			nil,
+			quiet,
+			args.Config,
+			args.ContractTransmitter,
+			args.Database,
+			args.Datasource,
+			trace.OracleID,
+			args.PrivateKeys,
+			args.LocalConfig,
+			args.Logger,
+			protocol.NewMetrics(nil),
+			replayEndpoint{r},
+			nil,
+			protocol.NopTelemetrySender{},
+		)
+	}()
+
+	for _, event := range trace.Events {
+		r.settle(ctx, args.Settle)
+		if ctx.Err() != nil {
+			break
+		}
+		r.clock.AdvanceTo(eventTime(event))
+		switch e := event.Event.(type) {
+		case *protobuf.TraceEvent_Received:
+			r.touch()
+			r.chReceive <- received[0]
+			received = received[1:]
+		case *protobuf.TraceEvent_TimerFired:
+			d := time.Duration(e.TimerFired.Duration)
+			if !r.fireTimer(d) {
+				args.Logger.Warn("Replay: trace has a timer firing which the oracle didn't start, replay has diverged", types.LogFields{
+					"duration": d,
+					"time":     eventTime(event),
+				})
+			}
+		}
+	}
+	r.settle(ctx, args.Settle)
+	oracleCancel()
+	<-chOracleDone
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Sent{}, r.sent...), ctx.Err()
+}
+
+type replayer struct {
+	clock     *clock.Virtual
+	chReceive chan protocol.MessageWithSender
+
+	mutex    sync.Mutex
+	activity uint64
+	sent     []Sent
+	// timers the oracle started which haven't fired yet, in the order they
+	// were started
+	timers []*replayTimer
+}
+
+func (r *replayer) touch() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.activity++
+}
+
+// settle blocks until the oracle has been quiet for d
+func (r *replayer) settle(ctx context.Context, d time.Duration) {
+	for {
+		r.mutex.Lock()
+		before := r.activity
+		r.mutex.Unlock()
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return
+		}
+		r.mutex.Lock()
+		after := r.activity
+		r.mutex.Unlock()
+		if before == after {
+			return
+		}
+	}
+}
+
+func (r *replayer) newTimer(d time.Duration) *replayTimer {
+	if d < 0 {
+		d = 0
+	}
+	t := &replayTimer{r, d, make(chan time.Time, 1)}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.activity++
+	r.timers = append(r.timers, t)
+	return t
+}
+
+// fireTimer fires the earliest started pending timer with duration d. It
+// returns false if there is none.
+func (r *replayer) fireTimer(d time.Duration) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, t := range r.timers {
+		if t.d == d {
+			r.activity++
+			r.timers = append(r.timers[:i], r.timers[i+1:]...)
+			t.ch <- r.clock.Now()
+			return true
+		}
+	}
+	return false
+}
+
+// replayTimer is a timer the oracle started during a replay. It fires when
+// the trace says so, rather than when it expires.
+type replayTimer struct {
+	r  *replayer
+	d  time.Duration
+	ch chan time.Time
+}
+
+func (t *replayTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *replayTimer) Stop() bool {
+	t.r.mutex.Lock()
+	defer t.r.mutex.Unlock()
+	for i, t2 := range t.r.timers {
+		if t2 == t {
+			t.r.timers = append(t.r.timers[:i], t.r.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (r *replayer) send(receiver *types.OracleID, msg protocol.Message) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.activity++
+	r.sent = append(r.sent, Sent{r.clock.Now(), receiver, msg})
+}
+
+// activityClock reports all uses of the virtual clock to the replayer, so that
+// it can tell when the oracle has gone quiet, and lets the replayer fire the
+// oracle's timers
+type activityClock struct {
+	r *replayer
+}
+
+var _ clock.Clock = activityClock{}
+
+func (c activityClock) Now() time.Time {
+	c.r.touch()
+	return c.r.clock.Now()
+}
+
+func (c activityClock) After(d time.Duration) <-chan time.Time {
+	return c.r.newTimer(d).C()
+}
+
+func (c activityClock) NewTimer(d time.Duration) clock.Timer {
+	return c.r.newTimer(d)
+}
+
+func (c activityClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	c.r.touch()
+	return c.r.clock.WithTimeout(ctx, d)
+}
+
+type replayEndpoint struct {
+	r *replayer
+}
+
+var _ protocol.NetworkEndpoint = replayEndpoint{}
+
+func (e replayEndpoint) SendTo(msg protocol.Message, to types.OracleID) {
+	e.r.send(&to, msg)
+}
+
+func (e replayEndpoint) Broadcast(msg protocol.Message) {
+	e.r.send(nil, msg)
+}
+
+func (e replayEndpoint) Receive() <-chan protocol.MessageWithSender {
+	return e.r.chReceive
+}
+
+func (replayEndpoint) Start() error { return nil }
+
+func (replayEndpoint) Close() error { return nil }
+
+// recordedDataSource observes the values of an oracle's recorded
+// observations, in order
+type recordedDataSource struct {
+	mutex  sync.Mutex
+	values []*big.Int
+}
+
+func newRecordedDataSource(recorded []Sent) *recordedDataSource {
+	ds := recordedDataSource{}
+	for _, sent := range recorded {
+		if m, ok := sent.Msg.(protocol.MessageObserve); ok {
+			ds.values = append(ds.values, m.SignedObservation.Observation.GoEthereumValue())
+		}
+	}
+	return &ds
+}
+
+func (ds *recordedDataSource) Observe(context.Context) (types.Observation, error) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	if len(ds.values) == 0 {
+		return nil, errors.New("no recorded observations left")
+	}
+	value := ds.values[0]
+	ds.values = ds.values[1:]
+	return new(big.Int).Set(value), nil
+}
+
+// discardingTransmitter discards all transmissions
+type discardingTransmitter struct {
+	configDigest types.ConfigDigest
+}
+
+func (t discardingTransmitter) Transmit(context.Context, []byte, [][32]byte, [][32]byte, [32]byte) error {
+	return nil
+}
+
+func (t discardingTransmitter) LatestTransmissionDetails(context.Context) (
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	latestAnswer types.Observation,
+	latestTimestamp time.Time,
+	err error,
+) {
+	return t.configDigest, 0, 0, big.NewInt(0), time.Time{}, nil
+}
+
+func (t discardingTransmitter) FromAddress() common.Address {
+	return common.Address{}
+}
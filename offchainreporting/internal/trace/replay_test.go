@@ -0,0 +1,57 @@
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+)
+
+func TestReplayerFiresTimersAsRecorded(t *testing.T) {
+	r := &replayer{clock: clock.NewVirtual(time.Unix(0, 0))}
+	c := activityClock{r}
+
+	first := c.After(time.Second)
+	stopped := c.NewTimer(time.Second)
+	second := c.After(time.Second)
+	other := c.After(time.Minute)
+	if !stopped.Stop() {
+		t.Fatal("Stop() on a pending timer must return true")
+	}
+
+	// Timers must not fire on their own, only when the trace says so
+	r.clock.Advance(time.Hour)
+	for _, ch := range []<-chan time.Time{first, stopped.C(), second, other} {
+		select {
+		case <-ch:
+			t.Fatal("timer fired before the trace recorded it")
+		default:
+		}
+	}
+
+	if !r.fireTimer(time.Second) {
+		t.Fatal("fireTimer found no pending timer")
+	}
+	select {
+	case <-first:
+	default:
+		t.Fatal("timers of the same duration must fire in the order they were started")
+	}
+	if !r.fireTimer(time.Second) {
+		t.Fatal("fireTimer found no pending timer")
+	}
+	select {
+	case <-second:
+	default:
+		t.Fatal("timer didn't fire")
+	}
+	if r.fireTimer(time.Second) {
+		t.Error("fireTimer fired a stopped timer")
+	}
+	if r.fireTimer(time.Hour) {
+		t.Error("fireTimer fired a timer of a different duration")
+	}
+	if !r.fireTimer(time.Minute) {
+		t.Error("fireTimer found no pending timer")
+	}
+}
@@ -0,0 +1,135 @@
+// Package trace records the messages an oracle sends and receives, and the
+// timers that fire while it runs, and replays such recordings against a
+// single oracle, so that incidents can be reproduced and stepped through
+// locally.
+//
+// A trace is a sequence of protobuf.TraceEvents, each prefixed with its
+// length as a varint. Each (re)start of the protocol, e.g. after a config
+// change, begins a new segment with a TraceStarted event.
+package trace
+
+import (
+	"bufio"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxEventSize bounds the size of a single event when reading traces, so that
+// a corrupted length prefix can't cause a huge allocation.
+const maxEventSize = 1 << 24
+
+// Trace is one segment of a recorded trace
+type Trace struct {
+	ConfigDigest types.ConfigDigest
+	OracleID     types.OracleID
+	Start        time.Time
+	// Events following the TraceStarted event of the segment
+	Events []*protobuf.TraceEvent
+}
+
+func writeEvent(w io.Writer, event *protobuf.TraceEvent) error {
+	b, err := proto.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal trace event")
+	}
+	framed := protowire.AppendVarint(make([]byte, 0, len(b)+binaryMaxVarintLen), uint64(len(b)))
+	framed = append(framed, b...)
+	_, err = w.Write(framed)
+	return err
+}
+
+const binaryMaxVarintLen = 10
+
+// ReadEvents reads all events of a trace from r
+func ReadEvents(r io.Reader) ([]*protobuf.TraceEvent, error) {
+	br := bufio.NewReader(r)
+	var events []*protobuf.TraceEvent
+	for {
+		size, err := readVarint(br)
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read length of event #%d", len(events))
+		}
+		if size > maxEventSize {
+			return nil, errors.Errorf("event #%d is too large (%d bytes)", len(events), size)
+		}
+		b := make([]byte, size)
+		if _, err := io.ReadFull(br, b); err != nil {
+			return nil, errors.Wrapf(err, "could not read event #%d", len(events))
+		}
+		event := &protobuf.TraceEvent{}
+		if err := proto.Unmarshal(b, event); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal event #%d", len(events))
+		}
+		events = append(events, event)
+	}
+}
+
+func readVarint(br *bufio.Reader) (uint64, error) {
+	var buf []byte
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF && len(buf) != 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		buf = append(buf, c)
+		if c < 0x80 {
+			break
+		}
+		if len(buf) == binaryMaxVarintLen {
+			return 0, errors.New("varint overflow")
+		}
+	}
+	v, n := protowire.ConsumeVarint(buf)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return v, nil
+}
+
+// Read reads a trace from r and splits it into its segments. Events before
+// the first TraceStarted event are discarded.
+func Read(r io.Reader) ([]Trace, error) {
+	events, err := ReadEvents(r)
+	if err != nil {
+		return nil, err
+	}
+	var traces []Trace
+	for _, event := range events {
+		if started := event.GetStarted(); started != nil {
+			var configDigest types.ConfigDigest
+			if len(started.ConfigDigest) != len(configDigest) {
+				return nil, errors.Errorf("TraceStarted has config digest of wrong length %d",
+					len(started.ConfigDigest))
+			}
+			copy(configDigest[:], started.ConfigDigest)
+			traces = append(traces, Trace{
+				configDigest,
+				types.OracleID(started.OracleID),
+				eventTime(event),
+				nil,
+			})
+			continue
+		}
+		if len(traces) == 0 {
+			continue
+		}
+		traces[len(traces)-1].Events = append(traces[len(traces)-1].Events, event)
+	}
+	return traces, nil
+}
+
+func eventTime(event *protobuf.TraceEvent) time.Time {
+	return time.Unix(0, int64(event.Time))
+}
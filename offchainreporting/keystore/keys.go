@@ -0,0 +1,138 @@
+// Package keystore provides a reference implementation of types.PrivateKeys,
+// whose keys are kept in a password-encrypted file.
+//
+// An oracle's PrivateKeys consist of three keys: a secp256k1 key for signing
+// reports for the OffchainAggregator contract, an Ed25519 key for signing
+// messages to other oracles, and an X25519 key for decrypting the shared
+// secret of configs. The key of the oracle's peer-to-peer identity and the
+// account it transmits from are managed separately, by the networking stack
+// and the ContractTransmitter.
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"io"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/signature"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// Keys holds an oracle's private keys in memory
+type Keys struct {
+	onChain  *signature.OnchainPrivateKey
+	offChain *signature.OffchainPrivateKey
+	config   [curve25519.ScalarSize]byte
+}
+
+var _ types.PrivateKeys = (*Keys)(nil)
+
+// NewKeys generates fresh keys, drawing randomness from rand
+func NewKeys(rand io.Reader) (*Keys, error) {
+	onChain, err := ecdsa.GenerateKey(signature.Curve, rand)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate on-chain key")
+	}
+	_, offChain, err := ed25519.GenerateKey(rand)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate off-chain key")
+	}
+	k := Keys{
+		(*signature.OnchainPrivateKey)(onChain),
+		(*signature.OffchainPrivateKey)(&offChain),
+		[curve25519.ScalarSize]byte{},
+	}
+	if _, err := io.ReadFull(rand, k.config[:]); err != nil {
+		return nil, errors.Wrap(err, "could not generate config key")
+	}
+	return &k, nil
+}
+
+// Size of the serialization of Keys returned by secretBytes
+const secretSize = 32 + ed25519.SeedSize + curve25519.ScalarSize
+
+// secretBytes returns the secret scalars of k: the on-chain key, the seed of
+// the off-chain key, and the config key, in that order
+func (k *Keys) secretBytes() []byte {
+	rv := make([]byte, 0, secretSize)
+	rv = append(rv, crypto.FromECDSA((*ecdsa.PrivateKey)(k.onChain))...)
+	rv = append(rv, ed25519.PrivateKey(*k.offChain).Seed()...)
+	rv = append(rv, k.config[:]...)
+	return rv
+}
+
+// keysFromSecretBytes is the inverse of secretBytes
+func keysFromSecretBytes(b []byte) (*Keys, error) {
+	if len(b) != secretSize {
+		return nil, errors.Errorf("keys must be %d bytes, got %d", secretSize, len(b))
+	}
+	onChain, err := crypto.ToECDSA(b[:32])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid on-chain key")
+	}
+	offChain := ed25519.NewKeyFromSeed(b[32 : 32+ed25519.SeedSize])
+	k := Keys{
+		(*signature.OnchainPrivateKey)(onChain),
+		(*signature.OffchainPrivateKey)(&offChain),
+		[curve25519.ScalarSize]byte{},
+	}
+	copy(k.config[:], b[32+ed25519.SeedSize:])
+	return &k, nil
+}
+
+func (k *Keys) SignOnChain(msg []byte) ([]byte, error) {
+	return k.onChain.Sign(msg)
+}
+
+func (k *Keys) SignOffChain(msg []byte) ([]byte, error) {
+	return k.offChain.Sign(msg)
+}
+
+func (k *Keys) ConfigDiffieHellman(base *[curve25519.ScalarSize]byte) (*[curve25519.PointSize]byte, error) {
+	p, err := curve25519.X25519(k.config[:], base[:])
+	if err != nil {
+		return nil, err
+	}
+	var sharedPoint [curve25519.PointSize]byte
+	copy(sharedPoint[:], p)
+	return &sharedPoint, nil
+}
+
+func (k *Keys) PublicKeyAddressOnChain() types.OnChainSigningAddress {
+	return k.onChain.Address()
+}
+
+func (k *Keys) PublicKeyOffChain() types.OffchainPublicKey {
+	return types.OffchainPublicKey(k.offChain.PublicKey())
+}
+
+func (k *Keys) PublicKeyConfig() [curve25519.PointSize]byte {
+	p, err := curve25519.X25519(k.config[:], curve25519.Basepoint)
+	if err != nil {
+		// assertion
+		panic(err)
+	}
+	var rv [curve25519.PointSize]byte
+	copy(rv[:], p)
+	return rv
+}
+
+// PublicIdentity holds the public keys of an oracle which go into the configs
+// it takes part in
+type PublicIdentity struct {
+	OnChainSigningAddress           types.OnChainSigningAddress
+	OffchainPublicKey               types.OffchainPublicKey
+	SharedSecretEncryptionPublicKey types.SharedSecretEncryptionPublicKey
+}
+
+// PublicIdentity returns the public keys of k
+func (k *Keys) PublicIdentity() PublicIdentity {
+	return PublicIdentity{
+		k.PublicKeyAddressOnChain(),
+		k.PublicKeyOffChain(),
+		types.SharedSecretEncryptionPublicKey(k.PublicKeyConfig()),
+	}
+}
@@ -0,0 +1,151 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// Parameters of the scrypt key derivation which protects keystore files. The
+// standard parameters take about a second and 256MB of memory to decrypt a
+// file; the light ones are only meant for tests.
+const (
+	StandardScryptN = ethkeystore.StandardScryptN
+	StandardScryptP = ethkeystore.StandardScryptP
+	LightScryptN    = ethkeystore.LightScryptN
+	LightScryptP    = ethkeystore.LightScryptP
+)
+
+// Version of the keystore file format
+const keystoreVersion = 1
+
+// encryptedKeysJSON is the format of keystore files. The keys are encrypted
+// with the password as in ethereum's keystore files (scrypt, AES-128-CTR and
+// a keccak256 MAC). The public identity is stored in the clear, so that files
+// can be told apart without the password, and checked against the decrypted
+// keys when the file is read.
+type encryptedKeysJSON struct {
+	Version        int                    `json:"version"`
+	PublicIdentity PublicIdentity         `json:"publicIdentity"`
+	Crypto         ethkeystore.CryptoJSON `json:"crypto"`
+}
+
+// publicIdentityJSON is the JSON representation of a PublicIdentity. Its field
+// names match those ocrconfig expects for oracles.
+type publicIdentityJSON struct {
+	OnChainSigningAddress           common.Address `json:"onChainSigningAddress"`
+	OffchainPublicKey               hexutil.Bytes  `json:"offchainPublicKey"`
+	SharedSecretEncryptionPublicKey hexutil.Bytes  `json:"sharedSecretEncryptionPublicKey"`
+}
+
+func (i PublicIdentity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(publicIdentityJSON{
+		common.Address(i.OnChainSigningAddress),
+		hexutil.Bytes(i.OffchainPublicKey),
+		i.SharedSecretEncryptionPublicKey[:],
+	})
+}
+
+func (i *PublicIdentity) UnmarshalJSON(b []byte) error {
+	var ij publicIdentityJSON
+	if err := json.Unmarshal(b, &ij); err != nil {
+		return err
+	}
+	if len(ij.OffchainPublicKey) != ed25519.PublicKeySize {
+		return errors.Errorf("offchainPublicKey must be %d bytes, got %d",
+			ed25519.PublicKeySize, len(ij.OffchainPublicKey))
+	}
+	var rv PublicIdentity
+	rv.OnChainSigningAddress = types.OnChainSigningAddress(ij.OnChainSigningAddress)
+	rv.OffchainPublicKey = types.OffchainPublicKey(ij.OffchainPublicKey)
+	if len(ij.SharedSecretEncryptionPublicKey) != len(rv.SharedSecretEncryptionPublicKey) {
+		return errors.Errorf("sharedSecretEncryptionPublicKey must be %d bytes, got %d",
+			len(rv.SharedSecretEncryptionPublicKey), len(ij.SharedSecretEncryptionPublicKey))
+	}
+	copy(rv.SharedSecretEncryptionPublicKey[:], ij.SharedSecretEncryptionPublicKey)
+	*i = rv
+	return nil
+}
+
+// Equal returns true iff i and i2 hold the same public keys
+func (i PublicIdentity) Equal(i2 PublicIdentity) bool {
+	return i.OnChainSigningAddress == i2.OnChainSigningAddress &&
+		bytes.Equal(i.OffchainPublicKey, i2.OffchainPublicKey) &&
+		i.SharedSecretEncryptionPublicKey == i2.SharedSecretEncryptionPublicKey
+}
+
+// EncryptKeys returns the keystore file holding k, encrypted with password
+func EncryptKeys(k *Keys, password string, scryptN, scryptP int) ([]byte, error) {
+	cryptoJSON, err := ethkeystore.EncryptDataV3(k.secretBytes(), []byte(password), scryptN, scryptP)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt keys")
+	}
+	return json.Marshal(encryptedKeysJSON{
+		keystoreVersion,
+		k.PublicIdentity(),
+		cryptoJSON,
+	})
+}
+
+// DecryptKeys returns the keys held by the keystore file keyJSON
+func DecryptKeys(keyJSON []byte, password string) (*Keys, error) {
+	var ej encryptedKeysJSON
+	if err := json.Unmarshal(keyJSON, &ej); err != nil {
+		return nil, errors.Wrap(err, "could not parse keystore file")
+	}
+	if ej.Version != keystoreVersion {
+		return nil, errors.Errorf("unknown keystore file version %d", ej.Version)
+	}
+	secret, err := ethkeystore.DecryptDataV3(ej.Crypto, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt keys")
+	}
+	k, err := keysFromSecretBytes(secret)
+	if err != nil {
+		return nil, err
+	}
+	if !k.PublicIdentity().Equal(ej.PublicIdentity) {
+		return nil, errors.Errorf("decrypted keys don't match the public identity of the keystore file")
+	}
+	return k, nil
+}
+
+// ReadKeysFile reads and decrypts the keystore file at path
+func ReadKeysFile(path string, password string) (*Keys, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKeys(keyJSON, password)
+}
+
+// WriteKeysFile encrypts k with password and writes it to a new keystore file
+// at path, which only the current user may read. It fails if path exists, so
+// that keys aren't overwritten by accident.
+func WriteKeysFile(path string, k *Keys, password string, scryptN, scryptP int) error {
+	keyJSON, err := EncryptKeys(k, password, scryptN, scryptP)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(keyJSON)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
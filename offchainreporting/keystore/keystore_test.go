@@ -0,0 +1,140 @@
+package keystore
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/signature"
+)
+
+func newTestKeys(t *testing.T) *Keys {
+	k, err := NewKeys(cryptorand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestEncryptDecryptKeys(t *testing.T) {
+	k := newTestKeys(t)
+	keyJSON, err := EncryptKeys(k, "password", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := DecryptKeys(keyJSON, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k2.PublicIdentity().Equal(k.PublicIdentity()) {
+		t.Errorf("public identity changed in round trip: %+v became %+v", k.PublicIdentity(), k2.PublicIdentity())
+	}
+	if string(k2.secretBytes()) != string(k.secretBytes()) {
+		t.Errorf("keys changed in round trip")
+	}
+}
+
+func TestDecryptKeysRejectsWrongPassword(t *testing.T) {
+	keyJSON, err := EncryptKeys(newTestKeys(t), "password", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptKeys(keyJSON, "wrong password"); err == nil {
+		t.Errorf("keys were decrypted with the wrong password")
+	}
+}
+
+func TestDecryptKeysRejectsTamperedPublicIdentity(t *testing.T) {
+	keyJSON, err := EncryptKeys(newTestKeys(t), "password", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file map[string]json.RawMessage
+	if err := json.Unmarshal(keyJSON, &file); err != nil {
+		t.Fatal(err)
+	}
+	file["publicIdentity"], err = json.Marshal(newTestKeys(t).PublicIdentity())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptKeys(tampered, "password"); err == nil {
+		t.Errorf("keystore file with somebody else's public identity was accepted")
+	}
+}
+
+func TestWriteKeysFileDoesNotOverwrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keys.json")
+
+	k := newTestKeys(t)
+	if err := WriteKeysFile(path, k, "password", LightScryptN, LightScryptP); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("keystore file has permissions %v, expected 0600", info.Mode().Perm())
+	}
+
+	if err := WriteKeysFile(path, newTestKeys(t), "password", LightScryptN, LightScryptP); err == nil {
+		t.Errorf("existing keystore file was overwritten")
+	}
+	k2, err := ReadKeysFile(path, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k2.PublicIdentity().Equal(k.PublicIdentity()) {
+		t.Errorf("keystore file holds different keys after failed write")
+	}
+}
+
+func TestKeysMatchPublicIdentity(t *testing.T) {
+	k := newTestKeys(t)
+	identity := k.PublicIdentity()
+	msg := []byte("message")
+
+	onChainSig, err := k.SignOnChain(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers := signature.EthAddresses{identity.OnChainSigningAddress: 1}
+	if oid, err := signature.VerifyOnChain(msg, onChainSig, signers); err != nil || oid != 1 {
+		t.Errorf("on-chain signature doesn't verify against the public identity: %v", err)
+	}
+
+	offChainSig, err := k.SignOffChain(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !signature.OffchainPublicKey(identity.OffchainPublicKey).Verify(msg, offChainSig) {
+		t.Errorf("off-chain signature doesn't verify against the public identity")
+	}
+
+	// Diffie-Hellman with the other party's SharedSecretEncryptionPublicKey
+	// must agree
+	k2 := newTestKeys(t)
+	pk1 := [32]byte(identity.SharedSecretEncryptionPublicKey)
+	pk2 := [32]byte(k2.PublicIdentity().SharedSecretEncryptionPublicKey)
+	p1, err := k.ConfigDiffieHellman(&pk2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := k2.ConfigDiffieHellman(&pk1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *p1 != *p2 {
+		t.Errorf("Diffie-Hellman against the public identity disagrees")
+	}
+}
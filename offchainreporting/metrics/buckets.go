@@ -0,0 +1,6 @@
+package metrics
+
+// LatencyBuckets are histogram buckets, in seconds, suitable for the latency
+// of calls to the services the library depends on, e.g. data sources and
+// databases
+var LatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
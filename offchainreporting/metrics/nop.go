@@ -0,0 +1,24 @@
+package metrics
+
+import "github.com/SeerLink/libocr/offchainreporting/types"
+
+// Nop discards all metrics
+var Nop types.Metrics = nop{}
+
+// OrNop returns m, or Nop if m is nil
+func OrNop(m types.Metrics) types.Metrics {
+	if m == nil {
+		return Nop
+	}
+	return m
+}
+
+type nop struct{}
+
+func (nop) Counter(types.MetricOpts) types.Counter                { return nop{} }
+func (nop) Gauge(types.MetricOpts) types.Gauge                    { return nop{} }
+func (nop) Histogram(types.MetricOpts, []float64) types.Histogram { return nop{} }
+func (nop) Inc(...string)                                         {}
+func (nop) Add(float64, ...string)                                {}
+func (nop) Set(float64, ...string)                                {}
+func (nop) Observe(float64, ...string)                            {}
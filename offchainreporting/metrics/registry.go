@@ -0,0 +1,299 @@
+// Package metrics contains implementations of types.Metrics.
+//
+// Registry keeps metrics in memory and serves them in the Prometheus text
+// exposition format, so that they can be scraped by Prometheus without
+// depending on its client library. Applications which already use the
+// Prometheus client library can implement types.Metrics on top of their own
+// registry instead.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// Registry is a types.Metrics which serves its metrics over HTTP in the
+// Prometheus text exposition format.
+type Registry struct {
+	mutex    sync.Mutex
+	families map[string]*family
+}
+
+var (
+	_ types.Metrics = &Registry{}
+	_ http.Handler  = &Registry{}
+)
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{families: map[string]*family{}}
+}
+
+// With returns a types.Metrics which registers its metrics with r, and adds
+// constLabels to all of them. Use it to tell apart the metrics of several
+// oracles sharing a registry, e.g. r.With(map[string]string{"job": "eth-usd"}).
+func (r *Registry) With(constLabels map[string]string) types.Metrics {
+	names := make([]string, 0, len(constLabels))
+	for name := range constLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = constLabels[name]
+	}
+	return labeled{r, names, values}
+}
+
+func (r *Registry) Counter(opts types.MetricOpts) types.Counter {
+	return r.With(nil).Counter(opts)
+}
+
+func (r *Registry) Gauge(opts types.MetricOpts) types.Gauge {
+	return r.With(nil).Gauge(opts)
+}
+
+func (r *Registry) Histogram(opts types.MetricOpts, buckets []float64) types.Histogram {
+	return r.With(nil).Histogram(opts, buckets)
+}
+
+// ServeHTTP serves all metrics in the Prometheus text exposition format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = r.Write(w)
+}
+
+// Write writes all metrics to w in the Prometheus text exposition format
+func (r *Registry) Write(w io.Writer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		r.families[name].write(bw)
+	}
+	return bw.Flush()
+}
+
+// register returns the family for opts, creating it if necessary. Panics if a
+// family with the same name but a different type or labels exists, since
+// that's a programming error.
+func (r *Registry) register(typ string, opts types.MetricOpts, buckets []float64) *family {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if f, ok := r.families[opts.Name]; ok {
+		if f.typ != typ || !equalStrings(f.labelNames, opts.LabelNames) {
+			panic(fmt.Sprintf("metric %s registered twice with different type or labels", opts.Name))
+		}
+		return f
+	}
+	f := &family{
+		r,
+		opts.Name,
+		opts.Help,
+		typ,
+		append([]string{}, opts.LabelNames...),
+		append([]float64{}, buckets...),
+		map[string]*series{},
+	}
+	r.families[opts.Name] = f
+	return f
+}
+
+type labeled struct {
+	registry    *Registry
+	constNames  []string
+	constValues []string
+}
+
+func (l labeled) opts(opts types.MetricOpts) types.MetricOpts {
+	opts.LabelNames = append(append([]string{}, l.constNames...), opts.LabelNames...)
+	return opts
+}
+
+func (l labeled) Counter(opts types.MetricOpts) types.Counter {
+	return metric{l.registry.register(typeCounter, l.opts(opts), nil), l.constValues}
+}
+
+func (l labeled) Gauge(opts types.MetricOpts) types.Gauge {
+	return metric{l.registry.register(typeGauge, l.opts(opts), nil), l.constValues}
+}
+
+func (l labeled) Histogram(opts types.MetricOpts, buckets []float64) types.Histogram {
+	return metric{l.registry.register(typeHistogram, l.opts(opts), buckets), l.constValues}
+}
+
+type family struct {
+	registry   *Registry
+	name       string
+	help       string
+	typ        string
+	labelNames []string
+	buckets    []float64
+	series     map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	// value holds the value of a counter or gauge, and the sum of a histogram
+	value float64
+	// bucketCounts[i] counts the observations <= buckets[i], but not those in
+	// lower buckets
+	bucketCounts []uint64
+	count        uint64
+}
+
+// metric implements types.Counter, types.Gauge and types.Histogram. Its
+// family's type determines which of them are valid to call.
+type metric struct {
+	family      *family
+	constValues []string
+}
+
+func (m metric) Inc(labelValues ...string) {
+	m.update(labelValues, func(s *series) { s.value++ })
+}
+
+func (m metric) Add(delta float64, labelValues ...string) {
+	m.update(labelValues, func(s *series) { s.value += delta })
+}
+
+func (m metric) Set(value float64, labelValues ...string) {
+	m.update(labelValues, func(s *series) { s.value = value })
+}
+
+func (m metric) Observe(value float64, labelValues ...string) {
+	m.update(labelValues, func(s *series) {
+		s.value += value
+		s.count++
+		i := sort.SearchFloat64s(m.family.buckets, value)
+		if i < len(s.bucketCounts) {
+			s.bucketCounts[i]++
+		}
+	})
+}
+
+func (m metric) update(labelValues []string, f func(*series)) {
+	values := append(append([]string{}, m.constValues...), labelValues...)
+	if len(values) != len(m.family.labelNames) {
+		panic(fmt.Sprintf("metric %s has %d labels, got %d values",
+			m.family.name, len(m.family.labelNames), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	r := m.family.registry
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	s, ok := m.family.series[key]
+	if !ok {
+		s = &series{values, 0, make([]uint64, len(m.family.buckets)), 0}
+		m.family.series[key] = s
+	}
+	f(s)
+}
+
+func (f *family) write(w *bufio.Writer) {
+	if len(f.series) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		if f.typ != typeHistogram {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(f.labelNames, s.labelValues, "", ""), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.bucketCounts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name,
+				formatLabels(f.labelNames, s.labelValues, "le", formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name,
+			formatLabels(f.labelNames, s.labelValues, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, formatLabels(f.labelNames, s.labelValues, "", ""), formatFloat(s.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, formatLabels(f.labelNames, s.labelValues, "", ""), s.count)
+	}
+}
+
+// formatLabels renders names and values as {name="value",...}, followed by
+// extraName="extraValue" unless extraName is empty
+func formatLabels(names []string, values []string, extraName string, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escapeLabelValue(values[i])))
+	}
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
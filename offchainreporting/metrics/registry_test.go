@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func exposition(t *testing.T, r *Registry) string {
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRegistryExposition(t *testing.T) {
+	r := NewRegistry()
+	m := r.With(map[string]string{"job": "eth-usd", "chain": "1"})
+
+	counter := m.Counter(types.MetricOpts{"ocr_messages_total", "Messages received.", []string{"type"}})
+	counter.Inc("observe")
+	counter.Add(2, "observe")
+	counter.Inc("final")
+
+	m.Gauge(types.MetricOpts{"ocr_epoch", "Current epoch.", nil}).Set(7)
+
+	histogram := m.Histogram(types.MetricOpts{"ocr_latency_seconds", "Latency.", nil}, []float64{0.1, 1})
+	histogram.Observe(0.05)
+	histogram.Observe(0.1) // bounds are inclusive
+	histogram.Observe(0.5)
+	histogram.Observe(3)
+
+	// Families without series aren't written
+	m.Counter(types.MetricOpts{"ocr_unused_total", "Unused.", nil})
+
+	expected := `# HELP ocr_epoch Current epoch.
+# TYPE ocr_epoch gauge
+ocr_epoch{chain="1",job="eth-usd"} 7
+# HELP ocr_latency_seconds Latency.
+# TYPE ocr_latency_seconds histogram
+ocr_latency_seconds_bucket{chain="1",job="eth-usd",le="0.1"} 2
+ocr_latency_seconds_bucket{chain="1",job="eth-usd",le="1"} 3
+ocr_latency_seconds_bucket{chain="1",job="eth-usd",le="+Inf"} 4
+ocr_latency_seconds_sum{chain="1",job="eth-usd"} 3.65
+ocr_latency_seconds_count{chain="1",job="eth-usd"} 4
+# HELP ocr_messages_total Messages received.
+# TYPE ocr_messages_total counter
+ocr_messages_total{chain="1",job="eth-usd",type="final"} 1
+ocr_messages_total{chain="1",job="eth-usd",type="observe"} 3
+`
+	if got := exposition(t, r); got != expected {
+		t.Errorf("got exposition\n%s\nexpected\n%s", got, expected)
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Body.String() != expected {
+		t.Errorf("ServeHTTP served\n%s\nexpected\n%s", rec.Body.String(), expected)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("ServeHTTP served Content-Type %q", ct)
+	}
+}
+
+func TestRegistryEscaping(t *testing.T) {
+	r := NewRegistry()
+	r.Counter(types.MetricOpts{"errors_total", "Errors, by \\ and\nmessage.", []string{"error"}}).
+		Inc("quote \" backslash \\ newline \n end")
+
+	expected := `# HELP errors_total Errors, by \\ and\nmessage.
+# TYPE errors_total counter
+errors_total{error="quote \" backslash \\ newline \n end"} 1
+`
+	if got := exposition(t, r); got != expected {
+		t.Errorf("got exposition\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestRegistryLatencyBucketsAreCumulative(t *testing.T) {
+	r := NewRegistry()
+	h := r.Histogram(types.MetricOpts{"latency_seconds", "Latency.", nil}, LatencyBuckets)
+	for _, v := range []float64{0.001, 0.02, 0.02, 0.7, 12, 60} {
+		h.Observe(v)
+	}
+
+	expected := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.005"} 1
+latency_seconds_bucket{le="0.01"} 1
+latency_seconds_bucket{le="0.025"} 3
+latency_seconds_bucket{le="0.05"} 3
+latency_seconds_bucket{le="0.1"} 3
+latency_seconds_bucket{le="0.25"} 3
+latency_seconds_bucket{le="0.5"} 3
+latency_seconds_bucket{le="1"} 4
+latency_seconds_bucket{le="2.5"} 4
+latency_seconds_bucket{le="5"} 4
+latency_seconds_bucket{le="10"} 4
+latency_seconds_bucket{le="30"} 5
+latency_seconds_bucket{le="+Inf"} 6
+latency_seconds_sum 72.741
+latency_seconds_count 6
+`
+	if got := exposition(t, r); got != expected {
+		t.Errorf("got exposition\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestRegistryConflictingRegistrations(t *testing.T) {
+	expectPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected a panic", name)
+			}
+		}()
+		f()
+	}
+
+	r := NewRegistry()
+	opts := types.MetricOpts{"requests_total", "Requests.", []string{"path"}}
+	r.Counter(opts).Inc("/a")
+
+	// Registering the same metric again returns the existing family
+	r.Counter(opts).Inc("/a")
+	if got, expected := exposition(t, r), "# HELP requests_total Requests.\n# TYPE requests_total counter\nrequests_total{path=\"/a\"} 2\n"; got != expected {
+		t.Errorf("got exposition\n%s\nexpected\n%s", got, expected)
+	}
+
+	expectPanic("different type", func() { r.Gauge(opts) })
+	expectPanic("histogram", func() { r.Histogram(opts, LatencyBuckets) })
+	expectPanic("different labels", func() {
+		r.Counter(types.MetricOpts{"requests_total", "Requests.", []string{"method"}})
+	})
+	expectPanic("different const labels", func() {
+		r.With(map[string]string{"job": "eth-usd"}).Counter(opts)
+	})
+	expectPanic("wrong number of label values", func() { r.Counter(opts).Inc() })
+}
@@ -2,9 +2,14 @@ package offchainreporting
 
 import (
 	"context"
+	"io"
+	"net/http"
 
 	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/debughandler"
 	"github.com/SeerLink/libocr/offchainreporting/internal/managed"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/shim"
 	"github.com/SeerLink/libocr/offchainreporting/types"
 	"github.com/SeerLink/libocr/subprocesses"
 
@@ -44,9 +49,22 @@ type OracleArgs struct {
 	// Used to send logs to a monitor
 	MonitoringEndpoint types.MonitoringEndpoint
 
+	// Receives metrics about the protocol and the services it uses. Optional,
+	// may be nil. metrics.Registry serves them to Prometheus.
+	Metrics types.Metrics
+
 	// PrivateKeys contains the secret keys needed for the OCR protocol, and methods
 	// which use those keys without exposing them to the rest of the application.
 	PrivateKeys types.PrivateKeys
+
+	// Receives spans tracing the lifecycle of each protocol round. Optional,
+	// may be nil.
+	SpanExporter types.SpanExporter
+
+	// Receives a trace of all protocol messages sent and received by the
+	// oracle, and of its timer firings, which can be replayed to reproduce
+	// the oracle's decisions. Optional, may be nil.
+	MessageTraceWriter io.Writer
 }
 
 type Oracle struct {
@@ -60,6 +78,18 @@ type Oracle struct {
 
 	// cancel sends a cancel message to all subprocesses, via a context.Context
 	cancel context.CancelFunc
+
+	// ctx is cancelled once the Oracle is closed. It's created along with the
+	// Oracle, so that Status may read it before and during Start.
+	ctx context.Context
+
+	// chStarted is closed once the Oracle has been started
+	chStarted chan struct{}
+
+	// chStatusRequests forwards requests from Status to the managed oracle
+	chStatusRequests chan protocol.StatusRequest
+
+	evidence *shim.EvidenceStore
 }
 
 // NewOracle returns a newly initialized Oracle using the provided services
@@ -68,9 +98,15 @@ func NewOracle(args OracleArgs) (*Oracle, error) {
 	if err := SanityCheckLocalConfig(args.LocalConfig); err != nil {
 		return nil, errors.Wrapf(err, "bad local config while creating new oracle")
 	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Oracle{
-		oracleArgs: args,
-		started:    semaphore.NewWeighted(1),
+		oracleArgs:       args,
+		started:          semaphore.NewWeighted(1),
+		cancel:           cancel,
+		ctx:              ctx,
+		chStarted:        make(chan struct{}),
+		chStatusRequests: make(chan protocol.StatusRequest),
+		evidence:         shim.NewEvidenceStore(maxMisbehaviorEvidencePerOracle),
 	}, nil
 }
 
@@ -78,39 +114,88 @@ func NewOracle(args OracleArgs) (*Oracle, error) {
 func (o *Oracle) Start() error {
 	o.failIfAlreadyStarted()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	o.cancel = cancel
 	o.subprocesses.Go(func() {
-		defer cancel()
+		defer o.cancel()
 		managed.RunManagedOracle(
-			ctx,
+			o.ctx,
 
 			o.oracleArgs.Bootstrappers,
+			o.chStatusRequests,
 			o.oracleArgs.ContractConfigTracker,
 			o.oracleArgs.ContractTransmitter,
 			o.oracleArgs.Database,
 			o.oracleArgs.Datasource,
+			o.evidence,
 			o.oracleArgs.LocalConfig,
 			o.oracleArgs.Logger,
+			o.oracleArgs.Metrics,
 			o.oracleArgs.MonitoringEndpoint,
 			o.oracleArgs.BinaryNetworkEndpointFactory,
 			o.oracleArgs.PrivateKeys,
+			o.oracleArgs.SpanExporter,
+			o.oracleArgs.MessageTraceWriter,
 		)
 	})
+	close(o.chStarted)
 	return nil
 }
 
 // Close shuts down an oracle. Can safely be called multiple times.
 func (o *Oracle) Close() error {
-	if o.cancel != nil {
-		o.cancel()
-	}
+	o.cancel()
 	// Wait for all subprocesses to shut down, before shutting down other resources.
 	// (Wouldn't want anything to panic from attempting to use a closed resource.)
 	o.subprocesses.Wait()
 	return nil
 }
 
+// Status returns a snapshot of the state of the oracle. It returns an error if
+// the oracle hasn't been started, has been closed, or if ctx is done before
+// the oracle responds.
+func (o *Oracle) Status(ctx context.Context) (types.OracleStatus, error) {
+	select {
+	case <-o.chStarted:
+	default:
+		return types.OracleStatus{}, errors.New("oracle hasn't been started")
+	}
+	chResponse := make(chan types.OracleStatus, 1)
+	select {
+	case o.chStatusRequests <- protocol.StatusRequest{ctx, chResponse}:
+	case <-o.ctx.Done():
+		return types.OracleStatus{}, errors.New("oracle has been closed")
+	case <-ctx.Done():
+		return types.OracleStatus{}, errors.Wrap(ctx.Err(), "could not request status")
+	}
+	select {
+	case status := <-chResponse:
+		return status, nil
+	case <-o.ctx.Done():
+		return types.OracleStatus{}, errors.New("oracle has been closed")
+	case <-ctx.Done():
+		return types.OracleStatus{}, errors.Wrap(ctx.Err(), "could not receive status")
+	}
+}
+
+// DebugHandler returns an http.Handler serving liveness and readiness probes
+// at /healthz and /readyz, and the oracle's status as JSON at /status. The
+// caller is responsible for serving it, e.g. on an existing debug server.
+func (o *Oracle) DebugHandler() http.Handler {
+	return debughandler.NewOracleHandler(o.Status)
+}
+
+// maxMisbehaviorEvidencePerOracle is the number of pieces of misbehavior
+// evidence against each other oracle an Oracle keeps
+const maxMisbehaviorEvidencePerOracle = 10
+
+// MisbehaviorEvidence returns self-authenticating evidence of misbehavior by
+// other oracles that this oracle has observed, oldest first. Only the most
+// recent evidence against each oracle is kept. All misbehavior this oracle
+// notices, including messages which merely failed verification, is sent to
+// the MonitoringEndpoint as it is observed.
+func (o *Oracle) MisbehaviorEvidence() []types.MisbehaviorEvidence {
+	return o.evidence.Evidence()
+}
+
 func (o *Oracle) failIfAlreadyStarted() {
 	if !o.started.TryAcquire(1) {
 		panic("can only start an Oracle once")
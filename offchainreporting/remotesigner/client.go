@@ -0,0 +1,141 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/signature"
+	"github.com/SeerLink/libocr/offchainreporting/keystore"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// Client is a types.PrivateKeys whose secret-key operations are performed by
+// a signer listening on a Unix socket.
+//
+// The public keys are fetched once, by NewClient. Signatures returned by the
+// signer are checked against them, so that a misconfigured signer is caught
+// here, rather than by the other oracles or the contract.
+type Client struct {
+	httpClient *http.Client
+	publicKeys keystore.PublicIdentity
+}
+
+var _ types.PrivateKeys = (*Client)(nil)
+
+// NewClient returns a Client for the signer listening at socketPath. Each
+// request to the signer fails after timeout.
+func NewClient(socketPath string, timeout time.Duration) (*Client, error) {
+	c := &Client{
+		&http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+			Timeout: timeout,
+		},
+		keystore.PublicIdentity{},
+	}
+	if err := c.do(http.MethodGet, pathPublicKeys, nil, &c.publicKeys); err != nil {
+		return nil, errors.Wrap(err, "could not get public keys from signer")
+	}
+	return c, nil
+}
+
+func (c *Client) SignOnChain(msg []byte) ([]byte, error) {
+	var resp signResponse
+	if err := c.do(http.MethodPost, pathSignOnChain, signRequest{msg}, &resp); err != nil {
+		return nil, err
+	}
+	signers := signature.EthAddresses{c.publicKeys.OnChainSigningAddress: 0}
+	if _, err := signature.VerifyOnChain(msg, resp.Signature, signers); err != nil {
+		return nil, errors.Wrap(err, "signer returned an invalid on-chain signature")
+	}
+	return resp.Signature, nil
+}
+
+func (c *Client) SignOffChain(msg []byte) ([]byte, error) {
+	var resp signResponse
+	if err := c.do(http.MethodPost, pathSignOffChain, signRequest{msg}, &resp); err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(c.publicKeys.OffchainPublicKey), msg, resp.Signature) {
+		return nil, errors.Errorf("signer returned an invalid off-chain signature")
+	}
+	return resp.Signature, nil
+}
+
+func (c *Client) ConfigDiffieHellman(base *[curve25519.ScalarSize]byte) (*[curve25519.PointSize]byte, error) {
+	var resp configDiffieHellmanResponse
+	if err := c.do(http.MethodPost, pathConfigDiffieHellman, configDiffieHellmanRequest{base[:]}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.SharedPoint) != curve25519.PointSize {
+		return nil, errors.Errorf("signer returned a shared point of %d bytes, expected %d",
+			len(resp.SharedPoint), curve25519.PointSize)
+	}
+	var sharedPoint [curve25519.PointSize]byte
+	copy(sharedPoint[:], resp.SharedPoint)
+	return &sharedPoint, nil
+}
+
+func (c *Client) PublicKeyAddressOnChain() types.OnChainSigningAddress {
+	return c.publicKeys.OnChainSigningAddress
+}
+
+func (c *Client) PublicKeyOffChain() types.OffchainPublicKey {
+	return c.publicKeys.OffchainPublicKey
+}
+
+func (c *Client) PublicKeyConfig() [curve25519.PointSize]byte {
+	return c.publicKeys.SharedSecretEncryptionPublicKey
+}
+
+// do sends req, if non-nil, as the body of a request to path, and decodes the
+// response into resp
+func (c *Client) do(method string, path string, req interface{}, resp interface{}) error {
+	var body []byte
+	if req != nil {
+		var err error
+		body, err = json.Marshal(req)
+		if err != nil {
+			return errors.Wrap(err, "could not encode request")
+		}
+	}
+	// The host is ignored, since the transport always dials the socket
+	httpReq, err := http.NewRequest(method, "http://signer"+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return errors.Wrap(err, "request to signer failed")
+	}
+	defer httpResp.Body.Close()
+	respBody, err := ioutil.ReadAll(&io.LimitedReader{httpResp.Body, maxBodySize})
+	if err != nil {
+		return errors.Wrap(err, "could not read response from signer")
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil || errResp.Error == "" {
+			return errors.Errorf("signer responded with status %s", httpResp.Status)
+		}
+		return errors.Errorf("signer responded with status %s: %s", httpResp.Status, errResp.Error)
+	}
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		return errors.Wrap(err, "could not parse response from signer")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package remotesigner
+
+import (
+	"net"
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// SocketPermissions determine who may connect to the socket of ListenUnix
+type SocketPermissions struct {
+	// Mode holds the permission bits of the socket. Connecting requires write
+	// permission. Other users must not be granted any permissions, so Mode
+	// is at most 0660.
+	Mode os.FileMode
+	// Gid is the group the socket belongs to, or -1 to keep the group it is
+	// created with. Use it with a Mode of 0660 to let an oracle running as
+	// another user, but in group Gid, connect.
+	Gid int
+}
+
+// DefaultSocketPermissions only let the current user connect
+var DefaultSocketPermissions = SocketPermissions{0600, -1}
+
+// umaskMutex serializes changes of the process-wide umask by ListenUnix
+var umaskMutex sync.Mutex
+
+// ListenUnix listens on a Unix socket at path, which only the users perm
+// allows may connect to. A stale socket left at path, e.g. by a crashed
+// signer, is replaced; any other file at path is an error.
+//
+// The socket is created under a umask which makes it accessible to the
+// current user only, and then given perm, so no other user can connect before
+// its permissions are final. Since the umask is shared by the whole process,
+// files other goroutines create concurrently with ListenUnix may be created
+// with too restrictive permissions.
+func ListenUnix(path string, perm SocketPermissions) (net.Listener, error) {
+	if perm.Mode&^0660 != 0 {
+		return nil, errors.Errorf("socket mode %#o grants more than read and write permissions to "+
+			"the user and group", perm.Mode)
+	}
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, errors.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, errors.Wrap(err, "could not remove stale socket")
+		}
+	}
+	l, err := listenUnixRestricted(path)
+	if err != nil {
+		return nil, err
+	}
+	if perm.Gid >= 0 {
+		if err := os.Chown(path, -1, perm.Gid); err != nil {
+			l.Close()
+			return nil, errors.Wrap(err, "could not set socket group")
+		}
+	}
+	if err := os.Chmod(path, perm.Mode); err != nil {
+		l.Close()
+		return nil, errors.Wrap(err, "could not set socket permissions")
+	}
+	return l, nil
+}
+
+// listenUnixRestricted listens on a Unix socket at path, which is created
+// with mode 0600
+func listenUnixRestricted(path string) (net.Listener, error) {
+	umaskMutex.Lock()
+	defer umaskMutex.Unlock()
+	oldUmask := syscall.Umask(0177)
+	defer syscall.Umask(oldUmask)
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,53 @@
+// Package remotesigner lets an oracle's PrivateKeys live in a separate
+// process, the signer, which the oracle reaches over a Unix socket.
+//
+// The protocol is JSON over HTTP. Byte strings are 0x-prefixed hex.
+//
+//	GET  /v1/public-keys             -> keystore.PublicIdentity
+//	POST /v1/sign-on-chain           {"message"} -> {"signature"}
+//	POST /v1/sign-off-chain          {"message"} -> {"signature"}
+//	POST /v1/config-diffie-hellman   {"point"} -> {"sharedPoint"}
+//
+// Failed requests get a non-2xx status and {"error"}. Requests the signer's
+// signingpolicy.Policy refuses get 403 Forbidden.
+//
+// Client implements types.PrivateKeys on top of this protocol, and Server is
+// a reference signer, which serves requests with any types.PrivateKeys, e.g.
+// the keys of a keystore file, as far as its Policy allows. Command ocrsigner
+// runs a Server.
+package remotesigner
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	pathPublicKeys          = "/v1/public-keys"
+	pathSignOnChain         = "/v1/sign-on-chain"
+	pathSignOffChain        = "/v1/sign-off-chain"
+	pathConfigDiffieHellman = "/v1/config-diffie-hellman"
+)
+
+// Bound on the size of request and response bodies. Signed messages are at
+// most a few KB.
+const maxBodySize = 1 << 16
+
+type signRequest struct {
+	Message hexutil.Bytes `json:"message"`
+}
+
+type signResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+type configDiffieHellmanRequest struct {
+	Point hexutil.Bytes `json:"point"`
+}
+
+type configDiffieHellmanResponse struct {
+	SharedPoint hexutil.Bytes `json:"sharedPoint"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
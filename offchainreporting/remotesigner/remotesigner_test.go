@@ -0,0 +1,196 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package remotesigner
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/internal/test/testlogger"
+	"github.com/SeerLink/libocr/offchainreporting/keystore"
+	"github.com/SeerLink/libocr/offchainreporting/signingpolicy"
+	"github.com/SeerLink/libocr/offchainreporting/telemetry"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+var testReportContext = protocol.ReportContext{types.ConfigDigest{1, 2, 3}, 7, 9}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// startSigner serves keys on a socket in dir, and returns a client for it
+func startSigner(t *testing.T, dir string, keys types.PrivateKeys, policy signingpolicy.Policy) (*Client, func()) {
+	path := filepath.Join(dir, "signer.sock")
+	listener, err := ListenUnix(path, DefaultSocketPermissions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &http.Server{Handler: NewServer(keys, policy, testlogger.Nop{})}
+	go server.Serve(listener)
+	client, err := NewClient(path, 10*time.Second)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return client, func() { server.Close() }
+}
+
+func testObservation(t *testing.T, v int64) observation.Observation {
+	o, err := observation.MakeObservation(big.NewInt(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestClientServerRoundTrip(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	keys, err := keystore.NewKeys(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := signingpolicy.NewConfigDigestAllowlist([]types.ConfigDigest{testReportContext.ConfigDigest})
+	client, stop := startSigner(t, dir, keys, policy)
+	defer stop()
+
+	if !client.publicKeys.Equal(keys.PublicIdentity()) {
+		t.Fatalf("client has the public keys %v, expected %v", client.publicKeys, keys.PublicIdentity())
+	}
+
+	// The client checks all signatures against the signer's public keys
+	obs := testObservation(t, 10)
+	so, err := protocol.MakeSignedObservation(obs, testReportContext, client.SignOffChain)
+	if err != nil {
+		t.Fatalf("could not sign observation: %v", err)
+	}
+	if err := so.Verify(testReportContext, keys.PublicKeyOffChain()); err != nil {
+		t.Errorf("signed observation doesn't verify: %v", err)
+	}
+	aos := protocol.AttributedObservations{{obs, 0}, {testObservation(t, 11), 1}}
+	if _, err := protocol.MakeAttestedReportOne(aos, testReportContext, client.SignOnChain); err != nil {
+		t.Errorf("could not sign report: %v", err)
+	}
+	envelope, err := telemetry.Sign(client.SignOffChain, testReportContext.ConfigDigest, 3, false, []byte{1})
+	if err != nil {
+		t.Fatalf("could not sign telemetry: %v", err)
+	}
+	if _, err := telemetry.DecodeAndVerify(envelope, []types.OffchainPublicKey{3: keys.PublicKeyOffChain()}); err != nil {
+		t.Errorf("signed telemetry doesn't verify: %v", err)
+	}
+
+	var point [32]byte
+	point[0] = 9
+	shared, err := client.ConfigDiffieHellman(&point)
+	if err != nil {
+		t.Fatalf("could not compute Diffie-Hellman: %v", err)
+	}
+	expected, err := keys.ConfigDiffieHellman(&point)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *shared != *expected {
+		t.Errorf("signer computed %x, expected %x", *shared, *expected)
+	}
+}
+
+func TestServerRefusesForeignMessages(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	keys, err := keystore.NewKeys(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := signingpolicy.NewConfigDigestAllowlist([]types.ConfigDigest{testReportContext.ConfigDigest})
+	client, stop := startSigner(t, dir, keys, policy)
+	defer stop()
+
+	var observationMessage []byte
+	_, err = protocol.MakeSignedObservation(testObservation(t, 10), testReportContext, func(msg []byte) ([]byte, error) {
+		observationMessage = msg
+		return keys.SignOffChain(msg)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherContext := protocol.ReportContext{types.ConfigDigest{4, 5, 6}, 7, 9}
+
+	for name, sign := range map[string]func() ([]byte, error){
+		"arbitrary message": func() ([]byte, error) {
+			return client.SignOffChain([]byte("transfer all funds"))
+		},
+		"observation signed on-chain": func() ([]byte, error) {
+			return client.SignOnChain(observationMessage)
+		},
+		"non-canonical observation": func() ([]byte, error) {
+			return client.SignOffChain(append(append([]byte{}, observationMessage...), 0))
+		},
+		"observation for unknown config": func() ([]byte, error) {
+			so, err := protocol.MakeSignedObservation(testObservation(t, 10), otherContext, client.SignOffChain)
+			return so.Signature, err
+		},
+	} {
+		sig, err := sign()
+		if err == nil {
+			t.Errorf("%s: signer returned signature %x", name, sig)
+		} else if !strings.Contains(err.Error(), "403") {
+			t.Errorf("%s: expected the signer to refuse with 403, got %v", name, err)
+		}
+	}
+}
+
+func TestListenUnixPermissions(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "signer.sock")
+
+	for _, mode := range []os.FileMode{0600, 0660} {
+		l, err := ListenUnix(path, SocketPermissions{mode, -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode()&os.ModeSocket == 0 || fi.Mode().Perm() != mode {
+			t.Errorf("socket has mode %v, expected %v", fi.Mode(), mode)
+		}
+		// Closing the listener removes the socket, so replacing a stale
+		// socket needs one that is left behind
+		l.(*net.UnixListener).SetUnlinkOnClose(false)
+		l.Close()
+	}
+
+	for _, mode := range []os.FileMode{0666, 0604, 0700, 01600} {
+		if l, err := ListenUnix(path, SocketPermissions{mode, -1}); err == nil {
+			l.Close()
+			t.Errorf("mode %#o was accepted", mode)
+		}
+	}
+
+	notSocket := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(notSocket, []byte{}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := ListenUnix(notSocket, DefaultSocketPermissions); err == nil {
+		l.Close()
+		t.Errorf("replaced a file which isn't a socket")
+	}
+}
@@ -0,0 +1,148 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/keystore"
+	"github.com/SeerLink/libocr/offchainreporting/signingpolicy"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// Server is a reference signer. It serves the remote signing protocol with
+// keys, refusing the requests policy doesn't authorize.
+type Server struct {
+	keys   types.PrivateKeys
+	logger types.Logger
+	mux    *http.ServeMux
+}
+
+var _ http.Handler = (*Server)(nil)
+
+func NewServer(keys types.PrivateKeys, policy signingpolicy.Policy, logger types.Logger) *Server {
+	s := &Server{signingpolicy.Enforce(keys, policy), logger, http.NewServeMux()}
+	s.mux.HandleFunc(pathPublicKeys, s.handlePublicKeys)
+	s.mux.HandleFunc(pathSignOnChain, s.handleSign(signingpolicy.OperationSignOnChain, s.keys.SignOnChain))
+	s.mux.HandleFunc(pathSignOffChain, s.handleSign(signingpolicy.OperationSignOffChain, s.keys.SignOffChain))
+	s.mux.HandleFunc(pathConfigDiffieHellman, s.handleConfigDiffieHellman)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handlePublicKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	s.writeJSON(w, keystore.PublicIdentity{
+		s.keys.PublicKeyAddressOnChain(),
+		s.keys.PublicKeyOffChain(),
+		types.SharedSecretEncryptionPublicKey(s.keys.PublicKeyConfig()),
+	})
+}
+
+func (s *Server) handleSign(
+	operation signingpolicy.Operation,
+	sign func(msg []byte) ([]byte, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req signRequest
+		if !s.readRequest(w, r, &req) {
+			return
+		}
+		sig, err := sign(req.Message)
+		if err != nil {
+			s.writeSigningError(w, operation, err)
+			return
+		}
+		s.writeJSON(w, signResponse{sig})
+	}
+}
+
+func (s *Server) handleConfigDiffieHellman(w http.ResponseWriter, r *http.Request) {
+	var req configDiffieHellmanRequest
+	if !s.readRequest(w, r, &req) {
+		return
+	}
+	if len(req.Point) != curve25519.PointSize {
+		s.writeError(w, http.StatusBadRequest, errors.Errorf(
+			"point must be %d bytes, got %d", curve25519.PointSize, len(req.Point)))
+		return
+	}
+	var base [curve25519.ScalarSize]byte
+	copy(base[:], req.Point)
+	sharedPoint, err := s.keys.ConfigDiffieHellman(&base)
+	if err != nil {
+		s.writeSigningError(w, signingpolicy.OperationConfigDiffieHellman, err)
+		return
+	}
+	s.writeJSON(w, configDiffieHellmanResponse{sharedPoint[:]})
+}
+
+// readRequest decodes the body of the POST request r into v. If that fails,
+// it responds with an error and returns false.
+func (s *Server) readRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		s.writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+		return false
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, errors.Wrap(err, "could not read request"))
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		s.writeError(w, http.StatusBadRequest, errors.Wrap(err, "could not parse request"))
+		return false
+	}
+	return true
+}
+
+func (s *Server) writeSigningError(w http.ResponseWriter, operation signingpolicy.Operation, err error) {
+	if refused, ok := err.(signingpolicy.RefusedError); ok {
+		s.logger.Warn("remotesigner: refused request", types.LogFields{
+			"operation":    operation.String(),
+			"kind":         refused.Request.Kind.String(),
+			"configDigest": refused.Request.ConfigDigest.Hex(),
+			"epoch":        refused.Request.Epoch,
+			"round":        refused.Request.Round,
+			"reason":       refused.Reason.Error(),
+		})
+		s.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	s.logger.Error("remotesigner: request failed", types.LogFields{
+		"operation": operation.String(),
+		"error":     err,
+	})
+	s.writeError(w, http.StatusInternalServerError, err)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	s.write(w, status, errorResponse{err.Error()})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v interface{}) {
+	s.write(w, http.StatusOK, v)
+}
+
+func (s *Server) write(w http.ResponseWriter, status int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		// assertion
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(b); err != nil {
+		s.logger.Debug("remotesigner: could not write response", types.LogFields{
+			"error": err,
+		})
+	}
+}
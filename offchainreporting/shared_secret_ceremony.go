@@ -0,0 +1,80 @@
+package offchainreporting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SeerLink/libocr/offchainreporting/confighelper"
+	"github.com/SeerLink/libocr/offchainreporting/internal/ceremony"
+	"github.com/SeerLink/libocr/offchainreporting/loghelper"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+type SharedSecretCeremonyArgs struct {
+	// A factory for producing network endpoints. The ceremony uses its own
+	// endpoint, separate from that of any running Oracle.
+	BinaryNetworkEndpointFactory types.BinaryNetworkEndpointFactory
+
+	// Bootstrappers is the list of bootstrap node addresses
+	Bootstrappers []string
+
+	// Logger logs stuff
+	Logger types.Logger
+
+	// Nonce distinguishes ceremonies among the same oracles. All oracles must
+	// use the same nonce, and a nonce must not be reused, e.g. the contract
+	// address followed by a counter of the ceremonies held for the contract.
+	Nonce []byte
+
+	// Oracles are the oracles of the config the shared secret is for, in
+	// oracle id order. All oracles must take part in the ceremony, and must
+	// pass the same list.
+	Oracles []confighelper.OracleIdentity
+
+	// PrivateKeys contains the keys of the oracle running the ceremony
+	PrivateKeys types.PrivateKeys
+
+	// ResendInterval is how often messages are resent, in case they got lost.
+	// Optional, defaults to 2s.
+	ResendInterval time.Duration
+}
+
+// RunSharedSecretCeremony lets the oracles of a config jointly choose the
+// config's shared secret, so that the dealer of the config cannot choose or
+// learn it. Each oracle runs RunSharedSecretCeremony with the same Oracles and
+// Nonce, and gets the same SharedSecretEncryptions, which the dealer passes to
+// confighelper.ContractSetConfigArgsWithSharedSecretEncryptions. Since all
+// honest oracles output the same encryptions, the dealer should compare the
+// results of several oracles, rather than trust one of them.
+//
+// RunSharedSecretCeremony blocks until the ceremony completes, or fails
+// because ctx expired before all oracles took part, or because an oracle
+// misbehaved.
+func RunSharedSecretCeremony(
+	ctx context.Context,
+	args SharedSecretCeremonyArgs,
+) (confighelper.SharedSecretEncryptions, error) {
+	participants := []ceremony.Participant{}
+	for _, oracle := range args.Oracles {
+		participants = append(participants, ceremony.Participant{
+			oracle.OnChainSigningAddress,
+			oracle.PeerID,
+			oracle.SharedSecretEncryptionPublicKey,
+		})
+	}
+	logger := loghelper.MakeLoggerWithContext(args.Logger, types.LogFields{
+		"ceremonyID": fmt.Sprintf("%x", ceremony.CeremonyID(participants, args.Nonce)),
+	})
+	return ceremony.Run(
+		ctx,
+
+		args.Bootstrappers,
+		logger,
+		args.BinaryNetworkEndpointFactory,
+		args.Nonce,
+		participants,
+		args.PrivateKeys,
+		args.ResendInterval,
+	)
+}
@@ -0,0 +1,182 @@
+package signingpolicy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// SignedMessage records a report or observation a SigningGuard let through
+type SignedMessage struct {
+	Kind         MessageKind
+	ConfigDigest types.ConfigDigest
+	Epoch        uint32
+	Round        uint8
+	// MessageHash is the SHA-256 hash of the signed message
+	MessageHash [sha256.Size]byte
+}
+
+// GuardState is the state a SigningGuard persists: the SignedMessage with the
+// highest epoch and round, for each kind of message and ConfigDigest
+type GuardState struct {
+	Signed []SignedMessage
+}
+
+// GuardStore persistently stores the state of a SigningGuard
+type GuardStore interface {
+	// ReadGuardState returns the last state written, or nil if there is none
+	ReadGuardState() (*GuardState, error)
+	// WriteGuardState must only return once state is durably stored
+	WriteGuardState(state GuardState) error
+}
+
+type guardKey struct {
+	kind         MessageKind
+	configDigest types.ConfigDigest
+}
+
+// SigningGuard is a Policy which keeps an oracle from signing two different
+// reports, or two different observations, for the same round, even across
+// crashes and restarts. The protocol signs at most one of each per
+// (ConfigDigest, epoch, round), and moves through epochs and rounds in
+// increasing order. So for each kind of message and ConfigDigest, the guard
+// remembers the highest epoch and round it signed for, and the hash of the
+// message it signed, and refuses
+//
+// - messages for earlier rounds, and
+//
+// - messages for the same round which differ from the one it signed.
+//
+// Signing the same message again is allowed, so that a request can be retried
+// after its response was lost. The guard only covers reports and observations;
+// it authorizes everything else, so combine it with a policy such as
+// ConfigDigestAllowlist, which refuses unknown messages, and consult the other
+// policy first, so that refused requests aren't recorded:
+//
+//	All(allowlist, guard)
+//
+// Keys held in the oracle's process can be guarded with Enforce(keys, guard).
+//
+// The guard records a request which advances the epoch and round it signed for
+// in its GuardStore before authorizing it, and refuses it if that fails. So
+// the first signature of each report and observation waits for the
+// GuardStore, while retries and unguarded messages don't. All its methods are
+// thread-safe, but there must be only one guard per key and GuardStore, or
+// they can't see each other's signatures.
+type SigningGuard struct {
+	mutex  sync.Mutex
+	store  GuardStore
+	signed map[guardKey]SignedMessage
+}
+
+var _ Policy = (*SigningGuard)(nil)
+
+// NewSigningGuard returns a SigningGuard which resumes from the state in
+// store
+func NewSigningGuard(store GuardStore) (*SigningGuard, error) {
+	state, err := store.ReadGuardState()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read signing guard state")
+	}
+	g := &SigningGuard{sync.Mutex{}, store, map[guardKey]SignedMessage{}}
+	if state != nil {
+		for _, s := range state.Signed {
+			if !guardedKind(s.Kind) {
+				return nil, errors.Errorf("signing guard state has a message of unguarded kind %v", s.Kind)
+			}
+			k := guardKey{s.Kind, s.ConfigDigest}
+			if _, ok := g.signed[k]; ok {
+				return nil, errors.Errorf("signing guard state has two entries for %v with config digest %v",
+					s.Kind, s.ConfigDigest.Hex())
+			}
+			g.signed[k] = s
+		}
+	}
+	return g, nil
+}
+
+func guardedKind(kind MessageKind) bool {
+	return kind == MessageKindReport || kind == MessageKindObservation
+}
+
+func (g *SigningGuard) Authorize(r Request) error {
+	if !guardedKind(r.Kind) {
+		return nil
+	}
+	next := SignedMessage{r.Kind, r.ConfigDigest, r.Epoch, r.Round, sha256.Sum256(r.Message)}
+	k := guardKey{r.Kind, r.ConfigDigest}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if last, ok := g.signed[k]; ok {
+		switch {
+		case last.Epoch == next.Epoch && last.Round == next.Round:
+			if !bytes.Equal(last.MessageHash[:], next.MessageHash[:]) {
+				return errors.Errorf("refusing to sign %v for epoch %d round %d, "+
+					"which conflicts with the %v already signed for that round",
+					r.Kind, r.Epoch, r.Round, r.Kind)
+			}
+			return nil
+		case next.Epoch < last.Epoch || (next.Epoch == last.Epoch && next.Round < last.Round):
+			return errors.Errorf("refusing to sign %v for epoch %d round %d, "+
+				"since %v was already signed for the later epoch %d round %d",
+				r.Kind, r.Epoch, r.Round, r.Kind, last.Epoch, last.Round)
+		}
+	}
+
+	// next advances the round, which must be durable before we authorize it
+	state := GuardState{make([]SignedMessage, 0, len(g.signed)+1)}
+	for k2, s := range g.signed {
+		if k2 != k {
+			state.Signed = append(state.Signed, s)
+		}
+	}
+	state.Signed = append(state.Signed, next)
+	if err := g.store.WriteGuardState(state); err != nil {
+		return errors.Wrap(err, "could not persist signing guard state")
+	}
+	g.signed[k] = next
+	return nil
+}
+
+// Prune forgets what was signed for configs whose ConfigDigest isn't in
+// configDigests, so that the state doesn't grow with every config the keys
+// were ever used for. Call it whenever the configs allowed to be signed for
+// change, e.g. after ConfigDigestAllowlist.Set.
+//
+// Once pruned, a config is no longer guarded, so only prune configs which
+// will never be allowed again. Each setConfig call on the contract produces a
+// new ConfigDigest, so configs which have been replaced on chain are safe to
+// prune.
+func (g *SigningGuard) Prune(configDigests []types.ConfigDigest) error {
+	keep := map[types.ConfigDigest]bool{}
+	for _, cd := range configDigests {
+		keep[cd] = true
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	state := GuardState{make([]SignedMessage, 0, len(g.signed))}
+	for _, s := range g.signed {
+		if keep[s.ConfigDigest] {
+			state.Signed = append(state.Signed, s)
+		}
+	}
+	if len(state.Signed) == len(g.signed) {
+		return nil
+	}
+	if err := g.store.WriteGuardState(state); err != nil {
+		return errors.Wrap(err, "could not persist signing guard state")
+	}
+	for k := range g.signed {
+		if !keep[k.configDigest] {
+			delete(g.signed, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package signingpolicy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// Version of the guard state file format
+const guardStateFileVersion = 1
+
+type guardStateJSON struct {
+	Version int                 `json:"version"`
+	Signed  []signedMessageJSON `json:"signed"`
+}
+
+type signedMessageJSON struct {
+	Kind         string        `json:"kind"`
+	ConfigDigest hexutil.Bytes `json:"configDigest"`
+	Epoch        uint32        `json:"epoch"`
+	Round        uint8         `json:"round"`
+	MessageHash  hexutil.Bytes `json:"messageHash"`
+}
+
+// FileGuardStore is a GuardStore which keeps the state in a JSON file. The
+// file is replaced atomically on each write, so a crash leaves either the old
+// or the new state behind.
+type FileGuardStore struct {
+	path string
+}
+
+var _ GuardStore = FileGuardStore{}
+
+func NewFileGuardStore(path string) FileGuardStore {
+	return FileGuardStore{path}
+}
+
+func (s FileGuardStore) ReadGuardState() (*GuardState, error) {
+	b, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var sj guardStateJSON
+	if err := json.Unmarshal(b, &sj); err != nil {
+		return nil, errors.Wrap(err, "could not parse guard state file")
+	}
+	if sj.Version != guardStateFileVersion {
+		return nil, errors.Errorf("unknown guard state file version %d", sj.Version)
+	}
+	state := GuardState{make([]SignedMessage, 0, len(sj.Signed))}
+	for i, mj := range sj.Signed {
+		m := SignedMessage{MessageKindUnknown, types.ConfigDigest{}, mj.Epoch, mj.Round, [32]byte{}}
+		switch mj.Kind {
+		case MessageKindReport.String():
+			m.Kind = MessageKindReport
+		case MessageKindObservation.String():
+			m.Kind = MessageKindObservation
+		default:
+			return nil, errors.Errorf("entry %d of guard state file has unknown kind %q", i, mj.Kind)
+		}
+		if m.ConfigDigest, err = types.BytesToConfigDigest(mj.ConfigDigest); err != nil {
+			return nil, errors.Wrapf(err, "entry %d of guard state file has invalid config digest", i)
+		}
+		if len(mj.MessageHash) != len(m.MessageHash) {
+			return nil, errors.Errorf("entry %d of guard state file has message hash of %d bytes, expected %d",
+				i, len(mj.MessageHash), len(m.MessageHash))
+		}
+		copy(m.MessageHash[:], mj.MessageHash)
+		state.Signed = append(state.Signed, m)
+	}
+	return &state, nil
+}
+
+func (s FileGuardStore) WriteGuardState(state GuardState) error {
+	sj := guardStateJSON{guardStateFileVersion, make([]signedMessageJSON, 0, len(state.Signed))}
+	for _, m := range state.Signed {
+		configDigest, messageHash := m.ConfigDigest, m.MessageHash
+		sj.Signed = append(sj.Signed, signedMessageJSON{
+			m.Kind.String(),
+			configDigest[:],
+			m.Epoch,
+			m.Round,
+			messageHash[:],
+		})
+	}
+	// Sort, so that the file doesn't change with the order of state.Signed
+	sort.Slice(sj.Signed, func(i, j int) bool {
+		a, b := sj.Signed[i], sj.Signed[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.ConfigDigest.String() < b.ConfigDigest.String()
+	})
+	b, err := json.MarshalIndent(sj, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(s.path, b)
+}
+
+// writeFileAtomically replaces the file at path with one holding b, which
+// only the current user may read, and syncs it to disk
+func writeFileAtomically(path string, b []byte) error {
+	dir := filepath.Dir(path)
+	f, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	// Sync the directory, so that the rename survives a crash
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+package signingpolicy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+// memoryGuardStore keeps the state in memory. Writes fail while failWrites is
+// set.
+type memoryGuardStore struct {
+	state      *GuardState
+	writes     int
+	failWrites bool
+}
+
+var _ GuardStore = (*memoryGuardStore)(nil)
+
+func (s *memoryGuardStore) ReadGuardState() (*GuardState, error) {
+	return s.state, nil
+}
+
+func (s *memoryGuardStore) WriteGuardState(state GuardState) error {
+	if s.failWrites {
+		return errors.New("disk full")
+	}
+	s.writes++
+	s.state = &state
+	return nil
+}
+
+func newTestGuard(t *testing.T, store GuardStore) *SigningGuard {
+	g, err := NewSigningGuard(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func observationRequest(t *testing.T, configDigest types.ConfigDigest, epoch uint32, round uint8, v int64) Request {
+	repctx := protocol.ReportContext{configDigest, epoch, round}
+	return ParseRequest(OperationSignOffChain, testObservationMessage(t, repctx, v))
+}
+
+func reportRequest(t *testing.T, configDigest types.ConfigDigest, epoch uint32, round uint8, v int64) Request {
+	repctx := protocol.ReportContext{configDigest, epoch, round}
+	return ParseRequest(OperationSignOnChain, testReportMessage(t, repctx, v))
+}
+
+func TestSigningGuardRefusesConflictingMessagesForSameRound(t *testing.T) {
+	g := newTestGuard(t, &memoryGuardStore{})
+	cd := types.ConfigDigest{1}
+	if err := g.Authorize(observationRequest(t, cd, 1, 1, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Authorize(observationRequest(t, cd, 1, 1, 11)); err == nil {
+		t.Errorf("authorized a second observation for the same round")
+	}
+	// Reports are guarded separately from observations
+	if err := g.Authorize(reportRequest(t, cd, 1, 1, 10)); err != nil {
+		t.Errorf("refused a report for a round only an observation was signed for: %v", err)
+	}
+	if err := g.Authorize(reportRequest(t, cd, 1, 1, 11)); err == nil {
+		t.Errorf("authorized a second report for the same round")
+	}
+	// So are configs
+	if err := g.Authorize(observationRequest(t, types.ConfigDigest{2}, 1, 1, 11)); err != nil {
+		t.Errorf("refused an observation for another config: %v", err)
+	}
+}
+
+func TestSigningGuardAllowsRetries(t *testing.T) {
+	store := &memoryGuardStore{}
+	g := newTestGuard(t, store)
+	r := reportRequest(t, types.ConfigDigest{1}, 1, 1, 10)
+	for i := 0; i < 3; i++ {
+		if err := g.Authorize(r); err != nil {
+			t.Fatalf("refused retry %d: %v", i, err)
+		}
+	}
+	if store.writes != 1 {
+		t.Errorf("wrote the state %d times, expected once", store.writes)
+	}
+
+	// Only advancing the round writes the state again
+	for i := 0; i < 3; i++ {
+		if err := g.Authorize(reportRequest(t, types.ConfigDigest{1}, 1, 2, 10)); err != nil {
+			t.Fatalf("refused retry %d in the next round: %v", i, err)
+		}
+	}
+	if store.writes != 2 {
+		t.Errorf("wrote the state %d times, expected twice", store.writes)
+	}
+}
+
+func TestSigningGuardRefusesEarlierRounds(t *testing.T) {
+	g := newTestGuard(t, &memoryGuardStore{})
+	cd := types.ConfigDigest{1}
+	if err := g.Authorize(observationRequest(t, cd, 2, 5, 10)); err != nil {
+		t.Fatal(err)
+	}
+	for _, er := range []struct {
+		epoch uint32
+		round uint8
+	}{{2, 4}, {1, 5}, {1, 6}} {
+		if err := g.Authorize(observationRequest(t, cd, er.epoch, er.round, 10)); err == nil {
+			t.Errorf("authorized an observation for epoch %d round %d after epoch 2 round 5", er.epoch, er.round)
+		}
+	}
+	for _, er := range []struct {
+		epoch uint32
+		round uint8
+	}{{2, 6}, {3, 1}} {
+		if err := g.Authorize(observationRequest(t, cd, er.epoch, er.round, 10)); err != nil {
+			t.Errorf("refused an observation for the later epoch %d round %d: %v", er.epoch, er.round, err)
+		}
+	}
+}
+
+func TestSigningGuardIgnoresUnguardedKinds(t *testing.T) {
+	store := &memoryGuardStore{}
+	g := newTestGuard(t, store)
+	telemetryRequest := ParseRequest(OperationSignOffChain, testTelemetryMessage(t, types.ConfigDigest{1}, false))
+	for i := 0; i < 2; i++ {
+		if err := g.Authorize(telemetryRequest); err != nil {
+			t.Errorf("refused telemetry: %v", err)
+		}
+	}
+	if store.writes != 0 {
+		t.Errorf("recorded telemetry")
+	}
+}
+
+func TestSigningGuardPersistsAcrossRestarts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signingpolicy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := NewFileGuardStore(filepath.Join(dir, "guard.json"))
+	cd := types.ConfigDigest{1}
+
+	g := newTestGuard(t, store)
+	signed := reportRequest(t, cd, 3, 2, 10)
+	if err := g.Authorize(signed); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Authorize(observationRequest(t, cd, 3, 3, 10)); err != nil {
+		t.Fatal(err)
+	}
+
+	g = newTestGuard(t, store)
+	if err := g.Authorize(signed); err != nil {
+		t.Errorf("refused to sign the same report again after a restart: %v", err)
+	}
+	if err := g.Authorize(reportRequest(t, cd, 3, 2, 11)); err == nil {
+		t.Errorf("authorized a conflicting report after a restart")
+	}
+	if err := g.Authorize(observationRequest(t, cd, 3, 2, 10)); err == nil {
+		t.Errorf("authorized an observation for an earlier round after a restart")
+	}
+}
+
+func TestSigningGuardRefusesWhenStoreFails(t *testing.T) {
+	store := &memoryGuardStore{}
+	g := newTestGuard(t, store)
+	cd := types.ConfigDigest{1}
+
+	store.failWrites = true
+	if err := g.Authorize(observationRequest(t, cd, 1, 1, 10)); err == nil {
+		t.Fatalf("authorized an observation which couldn't be recorded")
+	}
+
+	// The refused observation must not have been recorded, so a different
+	// observation for the same round may still be signed
+	store.failWrites = false
+	if err := g.Authorize(observationRequest(t, cd, 1, 1, 11)); err != nil {
+		t.Errorf("refused an observation after the store recovered: %v", err)
+	}
+}
+
+func TestSigningGuardPrune(t *testing.T) {
+	store := &memoryGuardStore{}
+	g := newTestGuard(t, store)
+	old, current := types.ConfigDigest{1}, types.ConfigDigest{2}
+	for _, r := range []Request{
+		observationRequest(t, old, 5, 5, 10),
+		reportRequest(t, old, 5, 5, 10),
+		observationRequest(t, current, 5, 5, 10),
+	} {
+		if err := g.Authorize(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store.failWrites = true
+	if err := g.Prune([]types.ConfigDigest{current}); err == nil {
+		t.Errorf("pruning succeeded although the store failed")
+	}
+	if err := g.Authorize(observationRequest(t, old, 5, 4, 10)); err == nil {
+		t.Errorf("failed pruning forgot what was signed")
+	}
+
+	store.failWrites = false
+	if err := g.Prune([]types.ConfigDigest{current}); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.state.Signed) != 1 || store.state.Signed[0].ConfigDigest != current {
+		t.Errorf("persisted %v after pruning, expected only the current config", store.state.Signed)
+	}
+	writes := store.writes
+	if err := g.Prune([]types.ConfigDigest{current}); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != writes {
+		t.Errorf("wrote the state although there was nothing to prune")
+	}
+
+	g = newTestGuard(t, store)
+	if err := g.Authorize(observationRequest(t, current, 5, 5, 11)); err == nil {
+		t.Errorf("pruning forgot about a config that is still allowed")
+	}
+	if err := g.Authorize(observationRequest(t, old, 5, 4, 10)); err != nil {
+		t.Errorf("pruned config is still guarded: %v", err)
+	}
+}
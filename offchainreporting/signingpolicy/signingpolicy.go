@@ -0,0 +1,246 @@
+// Package signingpolicy lets the holder of an oracle's PrivateKeys decide
+// which requests to serve, e.g. in a signer which keeps the keys out of the
+// oracle's process (see package remotesigner).
+//
+// ParseRequest tells which kind of message a request is for, and the report
+// context the message belongs to, using the same DomainSeparationTag the
+// protocol puts into the signed messages. A Policy then decides whether to
+// serve the request, and Enforce applies a Policy to a types.PrivateKeys.
+// SigningGuard is a Policy which keeps an oracle from signing conflicting
+// reports or observations, even across restarts.
+package signingpolicy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/telemetry"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// Operation is a secret-key operation of types.PrivateKeys
+type Operation int
+
+const (
+	OperationSignOnChain Operation = iota
+	OperationSignOffChain
+	OperationConfigDiffieHellman
+)
+
+func (o Operation) String() string {
+	switch o {
+	case OperationSignOnChain:
+		return "SignOnChain"
+	case OperationSignOffChain:
+		return "SignOffChain"
+	case OperationConfigDiffieHellman:
+		return "ConfigDiffieHellman"
+	}
+	return fmt.Sprintf("Operation(%d)", int(o))
+}
+
+// MessageKind is the kind of message a request is for
+type MessageKind int
+
+const (
+	// MessageKindUnknown is any message the protocol doesn't produce
+	MessageKindUnknown MessageKind = iota
+	// MessageKindReport is an on-chain report, signed with SignOnChain for an
+	// AttestedReportOne
+	MessageKindReport
+	// MessageKindObservation is an observation, signed with SignOffChain for
+	// a SignedObservation
+	MessageKindObservation
+	// MessageKindTelemetry is a telemetry envelope, signed with SignOffChain
+	MessageKindTelemetry
+	// MessageKindConfigSharedSecret is the Diffie-Hellman point of a config's
+	// SharedSecretEncryptions, passed to ConfigDiffieHellman
+	MessageKindConfigSharedSecret
+)
+
+func (k MessageKind) String() string {
+	switch k {
+	case MessageKindUnknown:
+		return "unknown"
+	case MessageKindReport:
+		return "report"
+	case MessageKindObservation:
+		return "observation"
+	case MessageKindTelemetry:
+		return "telemetry"
+	case MessageKindConfigSharedSecret:
+		return "config shared secret"
+	}
+	return fmt.Sprintf("MessageKind(%d)", int(k))
+}
+
+// Request describes a call to one of the secret-key operations of
+// types.PrivateKeys
+type Request struct {
+	Operation Operation
+	Kind      MessageKind
+	// ConfigDigest of the config the message belongs to. Only set for
+	// reports, observations and telemetry.
+	ConfigDigest types.ConfigDigest
+	// Epoch and Round the message belongs to. Only set for reports and
+	// observations.
+	Epoch uint32
+	Round uint8
+	// Message is the message to sign, or the point to multiply for
+	// OperationConfigDiffieHellman
+	Message []byte
+}
+
+// ParseRequest classifies a call of operation on message. Messages which the
+// protocol wouldn't pass to operation are of MessageKindUnknown.
+func ParseRequest(operation Operation, message []byte) Request {
+	r := Request{operation, MessageKindUnknown, types.ConfigDigest{}, 0, 0, message}
+	switch operation {
+	case OperationSignOnChain:
+		if repctx, err := protocol.ParseOnChainReport(message); err == nil {
+			r.Kind = MessageKindReport
+			r.ConfigDigest, r.Epoch, r.Round = repctx.ConfigDigest, repctx.Epoch, repctx.Round
+		}
+	case OperationSignOffChain:
+		if repctx, _, err := protocol.ParseSignedObservationWireMessage(message); err == nil {
+			r.Kind = MessageKindObservation
+			r.ConfigDigest, r.Epoch, r.Round = repctx.ConfigDigest, repctx.Epoch, repctx.Round
+		} else if configDigest, ok := telemetry.ConfigDigestOfSignedMessage(message); ok {
+			r.Kind = MessageKindTelemetry
+			r.ConfigDigest = configDigest
+		}
+	case OperationConfigDiffieHellman:
+		if len(message) == curve25519.PointSize {
+			r.Kind = MessageKindConfigSharedSecret
+		}
+	}
+	return r
+}
+
+// Policy decides which requests to serve
+type Policy interface {
+	// Authorize returns an error if r must not be served. Policies which keep
+	// state, e.g. to refuse conflicting requests, should record r before
+	// returning nil, since it will be served right after.
+	Authorize(r Request) error
+}
+
+// PolicyFunc is a Policy implemented by a function
+type PolicyFunc func(r Request) error
+
+func (f PolicyFunc) Authorize(r Request) error {
+	return f(r)
+}
+
+// All returns a Policy which serves a request only if all of policies do.
+// They're consulted in order, until one refuses.
+func All(policies ...Policy) Policy {
+	return PolicyFunc(func(r Request) error {
+		for _, p := range policies {
+			if err := p.Authorize(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ConfigDigestAllowlist is a Policy which only serves requests for messages
+// the protocol produces, and only signs messages belonging to configs whose
+// ConfigDigest is allowed. ConfigDiffieHellman is always served, since the
+// oracle needs it to decode a new config before its ConfigDigest can be
+// allowed, and it reveals nothing but the config's shared secret.
+//
+// All its methods are thread-safe.
+type ConfigDigestAllowlist struct {
+	mutex         sync.RWMutex
+	configDigests map[types.ConfigDigest]bool
+}
+
+var _ Policy = (*ConfigDigestAllowlist)(nil)
+
+func NewConfigDigestAllowlist(configDigests []types.ConfigDigest) *ConfigDigestAllowlist {
+	a := &ConfigDigestAllowlist{}
+	a.Set(configDigests)
+	return a
+}
+
+// Set replaces the allowed ConfigDigests
+func (a *ConfigDigestAllowlist) Set(configDigests []types.ConfigDigest) {
+	allowed := map[types.ConfigDigest]bool{}
+	for _, cd := range configDigests {
+		allowed[cd] = true
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.configDigests = allowed
+}
+
+func (a *ConfigDigestAllowlist) Authorize(r Request) error {
+	switch r.Kind {
+	case MessageKindUnknown:
+		return errors.Errorf("refusing %v on a message the protocol doesn't produce", r.Operation)
+	case MessageKindConfigSharedSecret:
+		return nil
+	}
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	if !a.configDigests[r.ConfigDigest] {
+		return errors.Errorf("refusing to sign %v for unknown config digest %v", r.Kind, r.ConfigDigest.Hex())
+	}
+	return nil
+}
+
+// RefusedError is returned by the PrivateKeys of Enforce for requests the
+// Policy refused
+type RefusedError struct {
+	Request Request
+	Reason  error
+}
+
+func (e RefusedError) Error() string {
+	return fmt.Sprintf("signing policy refused %v: %v", e.Request.Operation, e.Reason)
+}
+
+// Enforce returns PrivateKeys which serve requests with keys, but only those
+// policy authorizes. Refused requests fail with a RefusedError.
+func Enforce(keys types.PrivateKeys, policy Policy) types.PrivateKeys {
+	return enforcedPrivateKeys{keys, policy}
+}
+
+type enforcedPrivateKeys struct {
+	types.PrivateKeys
+	policy Policy
+}
+
+func (k enforcedPrivateKeys) authorize(operation Operation, message []byte) error {
+	r := ParseRequest(operation, message)
+	if err := k.policy.Authorize(r); err != nil {
+		return RefusedError{r, err}
+	}
+	return nil
+}
+
+func (k enforcedPrivateKeys) SignOnChain(msg []byte) ([]byte, error) {
+	if err := k.authorize(OperationSignOnChain, msg); err != nil {
+		return nil, err
+	}
+	return k.PrivateKeys.SignOnChain(msg)
+}
+
+func (k enforcedPrivateKeys) SignOffChain(msg []byte) ([]byte, error) {
+	if err := k.authorize(OperationSignOffChain, msg); err != nil {
+		return nil, err
+	}
+	return k.PrivateKeys.SignOffChain(msg)
+}
+
+func (k enforcedPrivateKeys) ConfigDiffieHellman(base *[curve25519.ScalarSize]byte) (*[curve25519.PointSize]byte, error) {
+	if err := k.authorize(OperationConfigDiffieHellman, base[:]); err != nil {
+		return nil, err
+	}
+	return k.PrivateKeys.ConfigDiffieHellman(base)
+}
@@ -0,0 +1,188 @@
+package signingpolicy
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/telemetry"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+var testReportContext = protocol.ReportContext{types.ConfigDigest{1, 2, 3}, 7, 9}
+
+func testObservation(t *testing.T, v int64) observation.Observation {
+	o, err := observation.MakeObservation(big.NewInt(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+// signedMessage returns the message sign is called with
+func signedMessage(t *testing.T, sign func(signer func([]byte) ([]byte, error)) error) []byte {
+	var msg []byte
+	err := sign(func(b []byte) ([]byte, error) {
+		msg = append([]byte{}, b...)
+		return []byte{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func testObservationMessage(t *testing.T, repctx protocol.ReportContext, v int64) []byte {
+	return signedMessage(t, func(signer func([]byte) ([]byte, error)) error {
+		_, err := protocol.MakeSignedObservation(testObservation(t, v), repctx, signer)
+		return err
+	})
+}
+
+func testReportMessage(t *testing.T, repctx protocol.ReportContext, v int64) []byte {
+	report, err := protocol.AttributedObservations{
+		{testObservation(t, v), 0},
+		{testObservation(t, v+1), 1},
+	}.OnChainReport(repctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func testTelemetryMessage(t *testing.T, configDigest types.ConfigDigest, batch bool) []byte {
+	return signedMessage(t, func(signer func([]byte) ([]byte, error)) error {
+		_, err := telemetry.Sign(signer, configDigest, 3, batch, []byte{1, 2, 3})
+		return err
+	})
+}
+
+func TestParseRequestClassifiesProtocolMessages(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		operation Operation
+		message   []byte
+		expected  Request
+	}{
+		{
+			"observation",
+			OperationSignOffChain,
+			testObservationMessage(t, testReportContext, 10),
+			Request{OperationSignOffChain, MessageKindObservation, testReportContext.ConfigDigest, 7, 9, nil},
+		},
+		{
+			"report",
+			OperationSignOnChain,
+			testReportMessage(t, testReportContext, 10),
+			Request{OperationSignOnChain, MessageKindReport, testReportContext.ConfigDigest, 7, 9, nil},
+		},
+		{
+			"telemetry",
+			OperationSignOffChain,
+			testTelemetryMessage(t, testReportContext.ConfigDigest, false),
+			Request{OperationSignOffChain, MessageKindTelemetry, testReportContext.ConfigDigest, 0, 0, nil},
+		},
+		{
+			"telemetry batch",
+			OperationSignOffChain,
+			testTelemetryMessage(t, testReportContext.ConfigDigest, true),
+			Request{OperationSignOffChain, MessageKindTelemetry, testReportContext.ConfigDigest, 0, 0, nil},
+		},
+		{
+			"config shared secret",
+			OperationConfigDiffieHellman,
+			make([]byte, 32),
+			Request{OperationConfigDiffieHellman, MessageKindConfigSharedSecret, types.ConfigDigest{}, 0, 0, nil},
+		},
+	} {
+		r := ParseRequest(c.operation, c.message)
+		c.expected.Message = c.message
+		if r.Operation != c.expected.Operation || r.Kind != c.expected.Kind ||
+			r.ConfigDigest != c.expected.ConfigDigest || r.Epoch != c.expected.Epoch ||
+			r.Round != c.expected.Round || string(r.Message) != string(c.expected.Message) {
+			t.Errorf("%s: parsed %+v, expected %+v", c.name, r, c.expected)
+		}
+	}
+}
+
+func TestParseRequestRefusesForeignMessages(t *testing.T) {
+	observationMessage := testObservationMessage(t, testReportContext, 10)
+	reportMessage := testReportMessage(t, testReportContext, 10)
+	telemetryMessage := testTelemetryMessage(t, testReportContext.ConfigDigest, false)
+	modified := func(msg []byte, f func(b []byte) []byte) []byte {
+		return f(append([]byte{}, msg...))
+	}
+
+	type foreignCase struct {
+		name      string
+		operation Operation
+		message   []byte
+	}
+	cases := []foreignCase{
+		// protocol messages passed to the wrong operation
+		{"observation signed on-chain", OperationSignOnChain, observationMessage},
+		{"report signed off-chain", OperationSignOffChain, reportMessage},
+		{"telemetry signed on-chain", OperationSignOnChain, telemetryMessage},
+		{"observation as Diffie-Hellman point", OperationConfigDiffieHellman, observationMessage},
+		// non-canonical encodings of protocol messages
+		{"observation with trailing byte", OperationSignOffChain,
+			modified(observationMessage, func(b []byte) []byte { return append(b, 0) })},
+		{"truncated observation", OperationSignOffChain, observationMessage[:len(observationMessage)-1]},
+		{"observation with non-zero padding", OperationSignOffChain,
+			modified(observationMessage, func(b []byte) []byte { b[0] = 1; return b })},
+		{"report with trailing byte", OperationSignOnChain,
+			modified(reportMessage, func(b []byte) []byte { return append(b, 0) })},
+		{"report with non-zero padding", OperationSignOnChain,
+			modified(reportMessage, func(b []byte) []byte { b[0] = 1; return b })},
+		{"telemetry with invalid batch flag", OperationSignOffChain,
+			modified(telemetryMessage, func(b []byte) []byte { b[len(b)-4] = 2; return b })},
+		{"truncated telemetry", OperationSignOffChain, telemetryMessage[:20]},
+		// other messages
+		{"empty off-chain", OperationSignOffChain, []byte{}},
+		{"empty on-chain", OperationSignOnChain, []byte{}},
+		{"short point", OperationConfigDiffieHellman, make([]byte, 31)},
+		{"unknown operation", Operation(42), observationMessage},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		b := make([]byte, rng.Intn(2*len(reportMessage)))
+		rng.Read(b)
+		cases = append(cases,
+			foreignCase{"random off-chain", OperationSignOffChain, b},
+			foreignCase{"random on-chain", OperationSignOnChain, b},
+		)
+	}
+
+	allowlist := NewConfigDigestAllowlist([]types.ConfigDigest{testReportContext.ConfigDigest})
+	for _, c := range cases {
+		r := ParseRequest(c.operation, c.message)
+		if r.Kind != MessageKindUnknown {
+			t.Errorf("%s: classified %x as %v", c.name, c.message, r.Kind)
+		}
+		if err := allowlist.Authorize(r); err == nil {
+			t.Errorf("%s: allowlist authorized %x", c.name, c.message)
+		}
+	}
+}
+
+func TestConfigDigestAllowlist(t *testing.T) {
+	allowlist := NewConfigDigestAllowlist([]types.ConfigDigest{testReportContext.ConfigDigest})
+	other := protocol.ReportContext{types.ConfigDigest{4, 5, 6}, 7, 9}
+	allowed := ParseRequest(OperationSignOffChain, testObservationMessage(t, testReportContext, 10))
+	unknown := ParseRequest(OperationSignOffChain, testObservationMessage(t, other, 10))
+	if err := allowlist.Authorize(allowed); err != nil {
+		t.Errorf("refused an allowed config digest: %v", err)
+	}
+	if err := allowlist.Authorize(unknown); err == nil {
+		t.Errorf("authorized an unknown config digest")
+	}
+	allowlist.Set([]types.ConfigDigest{other.ConfigDigest})
+	if err := allowlist.Authorize(allowed); err == nil {
+		t.Errorf("authorized a config digest that is no longer allowed")
+	}
+	if err := allowlist.Authorize(unknown); err != nil {
+		t.Errorf("refused a newly allowed config digest: %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+// Package telemetry lets monitors authenticate the telemetry oracles send to
+// their MonitoringEndpoint when LocalConfig.SignTelemetry is set.
+//
+// Each call to MonitoringEndpoint.SendLog then carries a serialized
+// TelemetryEnvelope around a single TelemetryWrapper, and each call to
+// MonitoringEndpointBatcher.SendLogBatch carries a (possibly compressed)
+// TelemetryEnvelope around a TelemetryBatch. The envelope is signed with the
+// offchain key of the oracle that produced the telemetry, so a monitor holding
+// the oracle's OffchainPublicKey can prove the telemetry's origin to a third
+// party.
+package telemetry
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/SeerLink/libocr/offchainreporting/internal/serialization/protobuf"
+	"github.com/SeerLink/libocr/offchainreporting/internal/signature"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// envelopeDomainSeparator prefixes all signed envelope contents. Its first
+// byte is non-zero, so envelope signatures cannot be confused with signatures
+// on observations, which start with a zero-padded report context.
+const envelopeDomainSeparator = "ocr telemetry envelope v1\x00"
+
+// Envelope is a decoded TelemetryEnvelope
+type Envelope struct {
+	ConfigDigest types.ConfigDigest
+	OracleID     types.OracleID
+	// Batch is true iff Payload is a serialized TelemetryBatch. Otherwise, it
+	// is a serialized TelemetryWrapper.
+	Batch     bool
+	Payload   []byte
+	Signature []byte
+}
+
+// Sign wraps payload in a TelemetryEnvelope signed by signer, which is
+// usually PrivateKeys.SignOffChain, and returns its serialization.
+func Sign(
+	signer func(msg []byte) (sig []byte, err error),
+	configDigest types.ConfigDigest,
+	oracleID types.OracleID,
+	batch bool,
+	payload []byte,
+) ([]byte, error) {
+	e := Envelope{configDigest, oracleID, batch, payload, nil}
+	sig, err := signer(e.signedMessage())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign telemetry envelope")
+	}
+	return proto.Marshal(&protobuf.TelemetryEnvelope{
+		ConfigDigest: configDigest[:],
+		OracleID:     uint32(oracleID),
+		Batch:        batch,
+		Payload:      payload,
+		Signature:    sig,
+	})
+}
+
+// Decode parses a serialized TelemetryEnvelope, without checking its
+// signature. Use the ConfigDigest and OracleID of the result to look up the
+// public key to pass to Verify.
+func Decode(envelope []byte) (Envelope, error) {
+	var pb protobuf.TelemetryEnvelope
+	if err := proto.Unmarshal(envelope, &pb); err != nil {
+		return Envelope{}, errors.Wrap(err, "could not unmarshal telemetry envelope")
+	}
+	var configDigest types.ConfigDigest
+	if len(pb.ConfigDigest) != len(configDigest) {
+		return Envelope{}, errors.Errorf("telemetry envelope has config digest of length %d, expected %d",
+			len(pb.ConfigDigest), len(configDigest))
+	}
+	copy(configDigest[:], pb.ConfigDigest)
+	return Envelope{
+		configDigest,
+		types.OracleID(pb.OracleID),
+		pb.Batch,
+		pb.Payload,
+		pb.Signature,
+	}, nil
+}
+
+// Verify returns an error unless e carries a valid signature by publicKey
+func (e Envelope) Verify(publicKey types.OffchainPublicKey) error {
+	if !signature.OffchainPublicKey(publicKey).Verify(e.signedMessage(), e.Signature) {
+		return errors.Errorf("telemetry envelope from oracle %d has invalid signature", e.OracleID)
+	}
+	return nil
+}
+
+// DecodeAndVerify decodes envelope and verifies its signature against the
+// public key of the claimed sender. publicKeys must be the offchain public
+// keys of the oracles of the envelope's configuration, in oracle id order, as
+// found in the configuration's OracleIdentities.
+func DecodeAndVerify(envelope []byte, publicKeys []types.OffchainPublicKey) (Envelope, error) {
+	e, err := Decode(envelope)
+	if err != nil {
+		return Envelope{}, err
+	}
+	if int(e.OracleID) >= len(publicKeys) {
+		return Envelope{}, errors.Errorf("telemetry envelope claims to come from oracle %d, but there are only %d oracles",
+			e.OracleID, len(publicKeys))
+	}
+	if err := e.Verify(publicKeys[e.OracleID]); err != nil {
+		return Envelope{}, err
+	}
+	return e, nil
+}
+
+// MaxDecompressedSize bounds the size of decompressed telemetry batches, so
+// that a malicious oracle cannot exhaust a monitor's memory with a small
+// compressed batch that expands enormously. Telemetry events take at most a
+// few kilobytes each, so this leaves plenty of room for batches of thousands
+// of events.
+const MaxDecompressedSize = 16 * 1024 * 1024
+
+// Decompress undoes the compression of telemetry batches, for use on the
+// arguments of SendLogBatch calls with compressed set. It returns an error if
+// the decompressed batch exceeds MaxDecompressedSize.
+func Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress telemetry")
+	}
+	defer r.Close()
+	rv, err := ioutil.ReadAll(io.LimitReader(r, MaxDecompressedSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress telemetry")
+	}
+	if len(rv) > MaxDecompressedSize {
+		return nil, errors.Errorf("decompressed telemetry exceeds %d bytes", MaxDecompressedSize)
+	}
+	return rv, nil
+}
+
+// ConfigDigestOfSignedMessage returns the ConfigDigest of the envelope whose
+// signature is on msg, and false if msg isn't the message signed for an
+// envelope. It lets signers tell envelopes apart from other messages.
+func ConfigDigestOfSignedMessage(msg []byte) (types.ConfigDigest, bool) {
+	var configDigest types.ConfigDigest
+	// domain separator, config digest, oracle id, batch flag
+	batchFlag := len(envelopeDomainSeparator) + len(configDigest) + 4
+	if len(msg) < batchFlag+1 ||
+		!bytes.HasPrefix(msg, []byte(envelopeDomainSeparator)) ||
+		msg[batchFlag] > 1 {
+		return types.ConfigDigest{}, false
+	}
+	copy(configDigest[:], msg[len(envelopeDomainSeparator):])
+	return configDigest, true
+}
+
+// signedMessage returns the bytes the envelope signature is made on. All
+// fields except the payload have fixed length, so the encoding is
+// unambiguous.
+func (e Envelope) signedMessage() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(envelopeDomainSeparator)
+	buf.Write(e.ConfigDigest[:])
+	var oracleID [4]byte
+	binary.BigEndian.PutUint32(oracleID[:], uint32(e.OracleID))
+	buf.Write(oracleID[:])
+	if e.Batch {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+	buf.Write(e.Payload)
+	return buf.Bytes()
+}
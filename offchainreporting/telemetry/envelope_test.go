@@ -0,0 +1,148 @@
+package telemetry
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol"
+	"github.com/SeerLink/libocr/offchainreporting/internal/protocol/observation"
+	"github.com/SeerLink/libocr/offchainreporting/types"
+)
+
+func testKeys(t *testing.T, n int) ([]ed25519.PrivateKey, []types.OffchainPublicKey) {
+	privateKeys := []ed25519.PrivateKey{}
+	publicKeys := []types.OffchainPublicKey{}
+	for i := 0; i < n; i++ {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privateKeys = append(privateKeys, privateKey)
+		publicKeys = append(publicKeys, types.OffchainPublicKey(publicKey))
+	}
+	return privateKeys, publicKeys
+}
+
+func signer(key ed25519.PrivateKey) func([]byte) ([]byte, error) {
+	return func(msg []byte) ([]byte, error) {
+		return ed25519.Sign(key, msg), nil
+	}
+}
+
+func TestSignDecodeAndVerify(t *testing.T) {
+	privateKeys, publicKeys := testKeys(t, 3)
+	configDigest := types.ConfigDigest{1, 2, 3}
+	payload := []byte{4, 5, 6}
+
+	envelope, err := Sign(signer(privateKeys[1]), configDigest, 1, true, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := DecodeAndVerify(envelope, publicKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ConfigDigest != configDigest || e.OracleID != 1 || !e.Batch || !bytes.Equal(e.Payload, payload) {
+		t.Errorf("envelope changed in round trip: %+v", e)
+	}
+
+	// Oracle 1's envelope, checked against the wrong key for oracle 1
+	_, otherPublicKeys := testKeys(t, 3)
+	if _, err := DecodeAndVerify(envelope, otherPublicKeys); err == nil {
+		t.Errorf("envelope verified against the wrong key")
+	}
+
+	// Oracle 0 claims its envelope comes from oracle 1
+	envelope, err = Sign(signer(privateKeys[0]), configDigest, 1, true, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeAndVerify(envelope, publicKeys); err == nil {
+		t.Errorf("envelope signed by oracle 0 verified as oracle 1's")
+	}
+
+	envelope, err = Sign(signer(privateKeys[0]), configDigest, 3, true, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeAndVerify(envelope, publicKeys); err == nil {
+		t.Errorf("envelope from oracle 3 of a config with 3 oracles was accepted")
+	}
+}
+
+func compress(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompressIsBounded(t *testing.T) {
+	data := make([]byte, MaxDecompressedSize)
+	data[0] = 1
+	if decompressed, err := Decompress(compress(t, data)); err != nil {
+		t.Errorf("could not decompress batch of maximal size: %v", err)
+	} else if !bytes.Equal(decompressed, data) {
+		t.Errorf("batch changed in decompression")
+	}
+
+	bomb := compress(t, make([]byte, MaxDecompressedSize+1))
+	if _, err := Decompress(bomb); err == nil {
+		t.Errorf("batch exceeding MaxDecompressedSize was decompressed")
+	}
+}
+
+// signedMessage returns the message sign is called with
+func signedMessage(t *testing.T, sign func(signer func([]byte) ([]byte, error)) error) []byte {
+	var msg []byte
+	err := sign(func(b []byte) ([]byte, error) {
+		msg = append([]byte{}, b...)
+		return []byte{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestConfigDigestOfSignedMessage(t *testing.T) {
+	configDigest := types.ConfigDigest{1, 2, 3}
+	for _, batch := range []bool{false, true} {
+		msg := signedMessage(t, func(signer func([]byte) ([]byte, error)) error {
+			_, err := Sign(signer, configDigest, 3, batch, []byte{1, 2, 3})
+			return err
+		})
+		if cd, ok := ConfigDigestOfSignedMessage(msg); !ok || cd != configDigest {
+			t.Errorf("envelope with batch=%v: got %v, %v", batch, cd, ok)
+		}
+	}
+
+	repctx := protocol.ReportContext{configDigest, 1, 2}
+	o, err := observation.MakeObservation(big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	observationMsg := signedMessage(t, func(signer func([]byte) ([]byte, error)) error {
+		_, err := protocol.MakeSignedObservation(o, repctx, signer)
+		return err
+	})
+	if _, ok := ConfigDigestOfSignedMessage(observationMsg); ok {
+		t.Errorf("observation signing payload was taken for an envelope")
+	}
+
+	reportMsg, err := protocol.AttributedObservations{{o, 0}, {o, 1}}.OnChainReport(repctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ConfigDigestOfSignedMessage(reportMsg); ok {
+		t.Errorf("report signing payload was taken for an envelope")
+	}
+}
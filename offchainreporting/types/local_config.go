@@ -52,6 +52,23 @@ type LocalConfig struct {
 	// blocking forever on an observation would break the oracle.)
 	DataSourceTimeout time.Duration
 
+	// Maximum number of telemetry events sent in one batch, if the
+	// MonitoringEndpoint implements MonitoringEndpointBatcher. Defaults to 100
+	// if zero.
+	TelemetryBatchSize int
+
+	// Maximum time a telemetry event waits for its batch to fill up before the
+	// batch is sent anyway. Defaults to one second if zero.
+	TelemetryBatchInterval time.Duration
+
+	// Whether to gzip-compress telemetry batches
+	TelemetryCompression bool
+
+	// Whether to wrap telemetry in envelopes signed with the oracle's offchain
+	// key, so that monitors can attribute it to the oracle. See package
+	// offchainreporting/telemetry for the format and a verifier.
+	SignTelemetry bool
+
 	// DANGER, this turns off all kinds of sanity checks. May be useful for testing.
 	// Set this to EnableDangerousDevelopmentMode to turn on dev mode.
 	DevelopmentMode string
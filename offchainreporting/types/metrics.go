@@ -0,0 +1,47 @@
+package types
+
+// Metrics creates the metrics reported by the library. Its design follows the
+// Prometheus data model, so that it can be implemented on top of a Prometheus
+// registry, but package metrics also contains a standalone implementation.
+//
+// The library may request the same metric several times, e.g. once per
+// config, always with the same options. Implementations should then return
+// the same metric.
+//
+// All its functions, and those of the metrics it returns, should be
+// thread-safe.
+type Metrics interface {
+	Counter(opts MetricOpts) Counter
+	Gauge(opts MetricOpts) Gauge
+	// Histogram creates a histogram with the given upper bounds for its
+	// buckets, in increasing order
+	Histogram(opts MetricOpts, buckets []float64) Histogram
+}
+
+// MetricOpts describes a metric. Name follows the Prometheus naming
+// conventions, e.g. ocr_rounds_started_total.
+type MetricOpts struct {
+	Name       string
+	Help       string
+	LabelNames []string
+}
+
+// Counter is a monotonically increasing metric. Calls must pass one value for
+// each of the metric's LabelNames.
+type Counter interface {
+	Inc(labelValues ...string)
+	// Add increases the counter by delta, which must not be negative
+	Add(delta float64, labelValues ...string)
+}
+
+// Gauge is a metric which can go up and down. Calls must pass one value for
+// each of the metric's LabelNames.
+type Gauge interface {
+	Set(value float64, labelValues ...string)
+}
+
+// Histogram samples observations into buckets. Calls must pass one value for
+// each of the metric's LabelNames.
+type Histogram interface {
+	Observe(value float64, labelValues ...string)
+}
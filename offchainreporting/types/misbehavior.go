@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// MisbehaviorEvidence records misbehavior by another oracle. Evidence sent as
+// telemetry may be a message which failed verification, such as an
+// observation with an invalid signature. Since OCR messages carry no
+// signature by their sender, such evidence is only as trustworthy as the
+// oracle that reports it. Evidence returned by Oracle.MisbehaviorEvidence is
+// self-authenticating instead: it consists of two conflicting messages whose
+// signatures prove the misbehavior.
+type MisbehaviorEvidence struct {
+	Time         time.Time
+	ConfigDigest ConfigDigest
+	Epoch        uint32
+	Round        uint8
+	// Sender is the oracle the offending message was received from
+	Sender OracleID
+	// Check names the verification that failed: "observe", "report",
+	// "reportReq", "attestedReport" or "reportReqConflict", or
+	// "observerEquivocation" for observers who signed conflicting observations
+	Check  string
+	Reason string
+	// Message is the offending message, serialized as a MessageWrapper
+	// protobuf. For self-authenticating evidence, the signatures in Message
+	// and ConflictingMessage allow third parties holding the contract config
+	// to check the evidence for themselves.
+	Message []byte
+	// ConflictingMessage is only set for observer equivocation. It is
+	// serialized like Message. Message and ConflictingMessage are both observe
+	// messages, whose signed observations for the same round differ.
+	ConflictingMessage []byte
+}
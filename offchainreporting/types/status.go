@@ -0,0 +1,111 @@
+package types
+
+import "time"
+
+// OracleStatus is a read-only snapshot of the state of a running oracle
+type OracleStatus struct {
+	// Running is false if the oracle isn't running the protocol, e.g. because
+	// it hasn't found a valid config yet. In that case, all other fields are
+	// zero values.
+	Running      bool
+	ConfigDigest ConfigDigest
+	OracleID     OracleID
+	// F is the maximum number of faulty oracles the config tolerates
+	F int
+
+	// Epoch and Leader are the pacemaker's current epoch and its leader
+	Epoch  uint32
+	Leader OracleID
+	// NewEpochs[j] is the highest epoch oracle j has sent in a newepoch message
+	// during the current epoch
+	NewEpochs []uint32
+
+	// Round is the current round of the epoch, as seen by this oracle as a
+	// follower, and Phase the follower's progress through it: "observe",
+	// "report", "finalEcho" or "completed".
+	Round uint8
+	Phase string
+	// LeaderPhase is the phase of the round this oracle leads: "observe",
+	// "grace", "report" or "final". Empty unless this oracle is the leader.
+	LeaderPhase string
+
+	// PendingTransmissions lists the reports awaiting transmission, ordered by
+	// their scheduled transmission time
+	PendingTransmissions []PendingTransmissionStatus
+	// RecentRounds lists the outcomes of the most recent reports received by
+	// the transmission protocol, oldest first
+	RecentRounds []RoundOutcome
+
+	// Network describes the connectivity of the oracle's network endpoint.
+	// nil unless the endpoint implements NetworkStatusReporter.
+	Network *NetworkStatus
+}
+
+// PendingTransmissionStatus describes a report awaiting transmission
+type PendingTransmissionStatus struct {
+	Epoch  uint32
+	Round  uint8
+	Time   time.Time
+	Median Observation
+}
+
+// RoundOutcome describes what happened to the report of a round once the
+// transmission protocol was done with it. Reason says why, unless the report
+// was transmitted.
+type RoundOutcome struct {
+	Epoch   uint32
+	Round   uint8
+	Time    time.Time
+	Outcome TransmissionOutcome
+	Reason  string
+}
+
+// TransmissionOutcome is what the transmission protocol did with a report
+type TransmissionOutcome string
+
+const (
+	// The report was transmitted to the contract
+	TransmissionOutcomeTransmitted TransmissionOutcome = "transmitted"
+	// The report was not transmitted
+	TransmissionOutcomeNotTransmitted TransmissionOutcome = "not transmitted"
+	// The transmission was attempted but failed
+	TransmissionOutcomeFailed TransmissionOutcome = "failed"
+)
+
+// BootstrapNodeStatus is a read-only snapshot of the state of a running
+// bootstrap node
+type BootstrapNodeStatus struct {
+	// Running is false if the bootstrap node hasn't found a valid config yet.
+	// In that case, all other fields are zero values.
+	Running      bool
+	ConfigDigest ConfigDigest
+
+	// Network describes the connectivity of the node's bootstrapper. nil unless
+	// the bootstrapper implements NetworkStatusReporter.
+	Network *NetworkStatus
+}
+
+// NetworkStatus describes the connectivity of a BinaryNetworkEndpoint or a
+// Bootstrapper
+type NetworkStatus struct {
+	// Peers contains an entry for every peer of the config. For a
+	// BinaryNetworkEndpoint, Peers[i] describes oracle i.
+	Peers []PeerStatus
+	// RoutingTableSize is the number of peers in the DHT routing table used
+	// for peer discovery
+	RoutingTableSize int
+}
+
+// PeerStatus describes the connection to a single peer
+type PeerStatus struct {
+	PeerID    string
+	Connected bool
+}
+
+// NetworkStatusReporter is optionally implemented by BinaryNetworkEndpoints
+// and Bootstrappers to report on their connectivity for debugging purposes.
+//
+// All its functions should be thread-safe.
+type NetworkStatusReporter interface {
+	NetworkStatus() NetworkStatus
+}
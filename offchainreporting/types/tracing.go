@@ -0,0 +1,49 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// TraceID identifies the trace of a single protocol round. It is derived
+// deterministically from (configDigest, epoch, round), so that the spans
+// recorded by all oracles for the same round end up in the same trace.
+// It has the same size as an OpenTelemetry trace ID.
+type TraceID [16]byte
+
+func (t TraceID) Hex() string {
+	return fmt.Sprintf("%x", t[:])
+}
+
+// SpanID identifies a span within a trace. It has the same size as an
+// OpenTelemetry span ID.
+type SpanID [8]byte
+
+func (s SpanID) Hex() string {
+	return fmt.Sprintf("%x", s[:])
+}
+
+// Span is a completed, timed step in the lifecycle of a protocol round, such
+// as the collection of an observation or the transmission of a report.
+type Span struct {
+	TraceID TraceID
+	SpanID  SpanID
+	// ParentSpanID is the zero SpanID for spans without a parent
+	ParentSpanID SpanID
+	Name         string
+	Start        time.Time
+	End          time.Time
+	Attributes   map[string]interface{}
+}
+
+// SpanExporter receives the spans recorded by the protocol. Its shape follows
+// the OpenTelemetry SpanExporter, so that adapting it to an OpenTelemetry
+// pipeline is straightforward.
+//
+// ExportSpan must not block. Implementations should buffer spans and
+// (optionally) drop them if the buffer reaches capacity.
+//
+// All its functions should be thread-safe.
+type SpanExporter interface {
+	ExportSpan(span Span)
+}
@@ -110,6 +110,19 @@ type MonitoringEndpoint interface {
 	SendLog(log []byte)
 }
 
+// MonitoringEndpointBatcher is optionally implemented by MonitoringEndpoints
+// which accept telemetry in batches. If it is implemented, the library only
+// calls SendLogBatch, never SendLog.
+//
+// All its functions should be thread-safe.
+type MonitoringEndpointBatcher interface {
+	MonitoringEndpoint
+	// SendLogBatch sends a serialized TelemetryBatch protobuf, or a
+	// TelemetryEnvelope around one if LocalConfig.SignTelemetry is set. It is
+	// gzip-compressed if compressed is true.
+	SendLogBatch(batch []byte, compressed bool)
+}
+
 // ContractTransmitter sends new reports to the OffchainAggregator smart contract.
 //
 // All its functions should be thread-safe.
@@ -187,7 +200,9 @@ type SharedSecretEncryptionPublicKey [curve25519.PointSize]byte // X25519
 // key which encrypts the offchain configuration data passed through the OffchainAggregator
 // smart contract.
 //
-// All its functions should be thread-safe.
+// All its functions should be thread-safe. Package keystore provides a
+// reference implementation, and package remotesigner one which keeps the keys
+// in a separate signer process.
 type PrivateKeys interface {
 
 	// SignOnChain returns an ethereum-style ECDSA secp256k1 signature on msg. See
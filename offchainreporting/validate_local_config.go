@@ -63,6 +63,20 @@ func SanityCheckLocalConfig(c types.LocalConfig) (err error) {
 			1*time.Second, 20*time.Second,
 		))
 
+	if c.TelemetryBatchSize < 0 {
+		err = multierr.Append(err, errors.Errorf(
+			"telemetry batch size must not be negative, but is currently %v",
+			c.TelemetryBatchSize))
+	}
+	if c.TelemetryBatchInterval != 0 {
+		err = multierr.Append(err,
+			boundTimeDuration(
+				c.TelemetryBatchInterval,
+				"telemetry batch interval",
+				10*time.Millisecond, 1*time.Minute,
+			))
+	}
+
 	const minContractConfigConfirmations = 1
 	const maxContractConfigConfirmations = 10
 	if !(1 <= c.ContractConfigConfirmations && c.ContractConfigConfirmations <= 9) {
@@ -10,10 +10,24 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/SeerLink/libocr/clock"
 )
 
 type Subprocesses struct {
 	wg sync.WaitGroup
+
+	// Clock used by BlockForAtMost, which also tracks the goroutines started
+	// by Go if it's a clock.Tracker. Optional, defaults to clock.Real. Must not
+	// be changed while Go or BlockForAtMost may be running.
+	Clock clock.Clock
+}
+
+func (s *Subprocesses) clock() clock.Clock {
+	if s.Clock == nil {
+		return clock.Real
+	}
+	return s.Clock
 }
 
 // Wait blocks until all function calls from the Go method have returned.
@@ -24,10 +38,10 @@ func (s *Subprocesses) Wait() {
 // Go calls the given function in a new goroutine.
 func (s *Subprocesses) Go(f func()) {
 	s.wg.Add(1)
-	go func() {
+	clock.Go(s.clock(), func() {
 		defer s.wg.Done()
 		f()
-	}()
+	})
 }
 
 // BlockForAtMost invokes f and blocks for at most duration d before returning,
@@ -36,19 +50,33 @@ func (s *Subprocesses) Go(f func()) {
 // Otherwise, returns false.
 func (s *Subprocesses) BlockForAtMost(ctx context.Context, d time.Duration, f func(context.Context)) (ok bool) {
 	done := make(chan struct{})
-	childCtx, childCancel := context.WithTimeout(ctx, d)
+	childCtx, childCancel := s.clock().WithTimeout(ctx, d)
 	defer childCancel()
+	timer := s.clock().NewTimer(d)
+	defer timer.Stop()
 	s.Go(func() {
 		f(childCtx)
+		clock.Wake(s.clock())
 		close(done)
 	})
-	t := time.NewTimer(d)
-	defer t.Stop()
 
+	clock.Block(s.clock())
 	select {
 	case <-done:
+		clock.Unblock(s.clock())
+		clock.Woken(s.clock())
 		return true
-	case <-t.C:
+	case <-timer.C():
+		clock.Unblock(s.clock())
+		clock.Woken(s.clock())
+		// f's completion is recorded with Wake, so it must still be received
+		// once f returns
+		s.Go(func() {
+			clock.Block(s.clock())
+			<-done
+			clock.Unblock(s.clock())
+			clock.Woken(s.clock())
+		})
 		return false
 	}
 }
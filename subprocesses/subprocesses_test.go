@@ -0,0 +1,54 @@
+package subprocesses
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeerLink/libocr/clock"
+)
+
+func TestBlockForAtMostReleasesTimer(t *testing.T) {
+	v := clock.NewVirtual(time.Unix(0, 0))
+	s := Subprocesses{Clock: v}
+	if !s.BlockForAtMost(context.Background(), time.Hour, func(context.Context) {}) {
+		t.Fatal("BlockForAtMost must return true once f finished")
+	}
+	s.Wait()
+	for i := 0; ; i++ {
+		if _, ok := v.NextDeadline(); !ok {
+			break
+		}
+		if i == 100 {
+			t.Fatal("BlockForAtMost left a timer pending after f finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBlockForAtMostTimesOut(t *testing.T) {
+	v := clock.NewVirtual(time.Unix(0, 0))
+	s := Subprocesses{Clock: v}
+	chDone := make(chan bool)
+	chRelease := make(chan struct{})
+	go func() {
+		chDone <- s.BlockForAtMost(context.Background(), time.Second, func(context.Context) {
+			<-chRelease
+		})
+	}()
+	for {
+		if deadline, ok := v.NextDeadline(); ok {
+			v.AdvanceTo(deadline)
+		}
+		select {
+		case ok := <-chDone:
+			if ok {
+				t.Errorf("BlockForAtMost must return false if f didn't finish in time")
+			}
+			close(chRelease)
+			s.Wait()
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+}